@@ -2,12 +2,15 @@ package admin
 
 import (
 	"database/sql"
-	"fmt"
 	"log"
 	"strconv"
-	"time"
+	"strings"
 	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
+	"yuklovchiBot/pkg/telegram"
 	"yuklovchiBot/storage"
+	"yuklovchiBot/subscription"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 )
@@ -15,262 +18,314 @@ import (
 func HandleAdminCommand(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
 	chatID := msg.Chat.ID
 
-	if !storage.IsAdmin(int(chatID), db) {
-		msgResponse := tgbotapi.NewMessage(chatID, "Siz admin emassiz.")
-		botInstance.Send(msgResponse)
+	lang := i18n.For(chatID)
+	role := adminRole(chatID, db)
+	if role == "" {
+		msgResponse := tgbotapi.NewMessage(chatID, lang.T("admin.not_admin"))
+		sender.Send(botInstance, msgResponse)
 		return
 	}
 
-	adminKeyboard := tgbotapi.NewReplyKeyboard(
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Statistika"),
-			tgbotapi.NewKeyboardButton("Habar yuborish"),
-		),
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Kanal qo'shish"),
-			tgbotapi.NewKeyboardButton("Kanal o'chirish"),
-		),
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("Admin qo'shish"),
-			tgbotapi.NewKeyboardButton("Admin o'chirish"),
-		),
-		tgbotapi.NewKeyboardButtonRow(
-			tgbotapi.NewKeyboardButton("BackUp olish"),
-		),
-	)
+	msgResponse := tgbotapi.NewMessage(chatID, lang.T("admin.menu", roleLabel(role, lang)))
+	msgResponse.ReplyMarkup = adminKeyboard(role, lang)
+	sender.Send(botInstance, msgResponse)
+}
 
-	msgResponse := tgbotapi.NewMessage(chatID, "Admin buyrug'lari:")
-	msgResponse.ReplyMarkup = adminKeyboard
-	botInstance.Send(msgResponse)
+// adminKeyboard admin menyusi, faqat rolga ruxsat berilgan tugmalar bilan.
+// Tugma matnlari i18n katalogidagi "menu." kalitlari, handle ularni i18n.Match orqali taniydi.
+func adminKeyboard(role string, lang i18n.Lang) tgbotapi.ReplyKeyboardMarkup {
+	type button struct{ key, permission string }
+	layout := [][]button{
+		{{"menu.stats", models.PermStats}, {"menu.broadcast", models.PermBroadcast}},
+		{{"menu.schedule", models.PermBroadcast}, {"menu.scheduled", models.PermBroadcast}},
+		{{"menu.channel_add", models.PermChannels}, {"menu.channel_delete", models.PermChannels}},
+		{{"menu.admins", models.PermAdmins}, {"menu.admin_add", models.PermAdmins}},
+		{{"menu.backup", models.PermBackups}, {"menu.restore", models.PermRestore}},
+		{{"menu.backups", models.PermBackups}, {"menu.export", models.PermStats}},
+		{{"menu.channels", models.PermChannels}, {"menu.audit", models.PermAudit}},
+		{{"menu.sources", models.PermStats}},
+	}
+
+	var rows [][]tgbotapi.KeyboardButton
+	for _, line := range layout {
+		var row []tgbotapi.KeyboardButton
+		for _, b := range line {
+			if models.HasPermission(role, b.permission) {
+				row = append(row, tgbotapi.NewKeyboardButton(lang.T(b.key)))
+			}
+		}
+		if len(row) > 0 {
+			rows = append(rows, row)
+		}
+	}
+	return tgbotapi.NewReplyKeyboard(rows...)
 }
 
+// HandleChannelLink havola, @username, kanal ID si yoki kanaldan forward qilingan habarni qabul qiladi.
+// Kanal getChat orqali tekshiriladi va ID, username, nomi bilan saqlanadi.
 func HandleChannelLink(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
 
 	chatID := msg.Chat.ID
-	channelLink := msg.Text
+	lang := i18n.For(chatID)
 
-	if !storage.IsAdmin(int(chatID), db) {
-		msgResponse := tgbotapi.NewMessage(chatID, "Siz admin emassiz.")
-		botInstance.Send(msgResponse)
+	if !HasPermission(chatID, models.PermChannels, db) {
+		msgResponse := tgbotapi.NewMessage(chatID, lang.T("admin.not_admin"))
+		sender.Send(botInstance, msgResponse)
 		return
 	}
 
-	err := storage.AddChannelToDatabase(db, channelLink)
-	if err != nil {
-		log.Printf("Error adding channel to database: %v", err)
-		msgResponse := tgbotapi.NewMessage(chatID, "Kanalni qo'shishda xatolik yuz berdi.")
-		botInstance.Send(msgResponse)
+	var ref tgbotapi.ChatConfig
+	if msg.ForwardFromChat != nil {
+		ref.ChatID = msg.ForwardFromChat.ID
+	} else {
+		var err error
+		ref, err = parseChannelRef(msg.Text)
+		if err == errInviteLink {
+			msgResponse := tgbotapi.NewMessage(chatID, lang.T("channel.invite_link_unsupported"))
+			sender.Send(botInstance, msgResponse)
+			return
+		}
+		if err != nil {
+			msgResponse := tgbotapi.NewMessage(chatID, lang.T("channel.bad_format"))
+			sender.Send(botInstance, msgResponse)
+			return
+		}
+	}
+
+	channel, problem := resolveChannel(ref, lang, botInstance)
+	if problem != "" {
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, problem))
 		return
 	}
 
-	msgResponse := tgbotapi.NewMessage(chatID, "Kanal muvaffaqiyatli qo'shildi.")
-	botInstance.Send(msgResponse)
+	// Yopiq kanalga kirish uchun taklif havolasi kerak, uning turini admin tanlaydi
+	if channel.Username == "" {
+		askChannelInviteType(chatID, channel, lang, botInstance)
+		return
+	}
+
+	sender.Send(botInstance, tgbotapi.NewMessage(chatID, saveChannel(chatID, channel, lang, db)))
 }
 
 func DeleteChannel(chatID int64, messageID int, channel string, db *sql.DB, botInstance *tgbotapi.BotAPI) {
 
-	if !storage.IsAdmin(int(chatID), db) {
+	if !HasPermission(chatID, models.PermChannels, db) {
 		return
 	}
 
+	lang := i18n.For(chatID)
 	err := storage.DeleteChannelFromDatabase(db, channel)
 	if err != nil {
 		log.Printf("Error deleting channel from database: %v", err)
-		msgResponse := tgbotapi.NewMessage(chatID, "Kanalni o'chirishda xatolik yuz berdi.")
-		botInstance.Send(msgResponse)
+		msgResponse := tgbotapi.NewMessage(chatID, lang.T("channel.delete_error"))
+		sender.Send(botInstance, msgResponse)
 		return
 	}
 
-	msgResponse := tgbotapi.NewMessage(chatID, fmt.Sprintf("%s kanali muvaffaqiyatli o'chirildi.", channel))
-	botInstance.Send(msgResponse)
+	subscription.Invalidate()
+	Audit(db, chatID, models.AuditChannelDelete, channel, nil)
+
+	msgResponse := tgbotapi.NewMessage(chatID, lang.T("channel.deleted", channel))
+	sender.Send(botInstance, msgResponse)
 }
 
 func CancelChannelDeletion(chatID int64, messageID int, botInstance *tgbotapi.BotAPI) {
 
-	msgResponse := tgbotapi.NewMessage(chatID, "Kanal o'chirish bekor qilindi.")
-	botInstance.Send(msgResponse)
+	msgResponse := tgbotapi.NewMessage(chatID, i18n.For(chatID).T("channel.delete_cancelled"))
+	sender.Send(botInstance, msgResponse)
 
 	// Delete the previous message
 	deleteMsg := tgbotapi.NewDeleteMessage(chatID, messageID)
-	botInstance.Send(deleteMsg)
+	sender.Send(botInstance, deleteMsg)
 }
 
+// HandleAdminAdd yangi adminni qabul qiladi: forward qilingan habar, kontakt yoki "ID [rol]" matni
+// (rol: admin, moderator, analyst). Forward va kontaktda rol tugmalar orqali so'raladi.
 func HandleAdminAdd(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
 
 	chatID := msg.Chat.ID
 
-	if !storage.IsAdmin(int(chatID), db) {
+	role := adminRole(chatID, db)
+	if !models.HasPermission(role, models.PermAdmins) {
+		return
+	}
+
+	lang := i18n.For(chatID)
+	reply := func(text string) {
+		msgResponse := tgbotapi.NewMessage(chatID, text)
+		msgResponse.ReplyMarkup = adminKeyboard(role, lang)
+		sender.Send(botInstance, msgResponse)
+	}
+
+	switch {
+	case i18n.Match(msg.Text, "admins.pick_cancel") != "":
+		reply(lang.T("admins.add_cancelled"))
+		return
+	case msg.ForwardFrom != nil:
+		askAdminRole(chatID, int64(msg.ForwardFrom.ID), userName(msg.ForwardFrom.FirstName, msg.ForwardFrom.LastName, msg.ForwardFrom.UserName), role, botInstance)
+		return
+	case telegram.IsHiddenForward(msg):
+		reply(lang.T("admins.hidden_forward", lang.T("admins.pick_user")))
+		return
+	case msg.Contact != nil:
+		if msg.Contact.UserID == 0 {
+			reply(lang.T("admins.contact_no_account"))
+			return
+		}
+		askAdminRole(chatID, int64(msg.Contact.UserID), userName(msg.Contact.FirstName, msg.Contact.LastName, ""), role, botInstance)
+		return
+	}
+
+	fields := strings.Fields(msg.Text)
+	if len(fields) == 0 {
+		reply(lang.T("admins.bad_id"))
 		return
 	}
 
-	adminID, err := strconv.ParseInt(msg.Text, 10, 64)
+	adminID, err := strconv.ParseInt(fields[0], 10, 64)
 	if err != nil {
 		log.Printf("Error parsing admin ID: %v", err)
-		msgResponse := tgbotapi.NewMessage(chatID, "Noto'g'ri admin ID formati.")
-		botInstance.Send(msgResponse)
+		reply(lang.T("admins.bad_id"))
 		return
 	}
 
-	err = storage.AddAdminToDatabase(db, adminID)
-	if err != nil {
+	newRole := models.RoleAdmin
+	if len(fields) > 1 {
+		newRole = strings.ToLower(fields[1])
+	}
+	reply(addAdmin(chatID, adminID, newRole, lang, db, botInstance))
+}
+
+// addAdmin adminni saqlaydi va foydalanuvchiga ko'rsatiladigan natija matnini qaytaradi
+func addAdmin(chatID, adminID int64, role string, lang i18n.Lang, db *sql.DB, botInstance *tgbotapi.BotAPI) string {
+	if !models.IsValidRole(role) || role == models.RoleOwner {
+		return lang.T("admins.bad_role")
+	}
+	if adminID == Config.OwnerID {
+		return lang.T("admins.owner_role")
+	}
+
+	if err := storage.AddAdminToDatabase(db, adminID, role); err != nil {
 		log.Printf("Error adding admin to database: %v", err)
-		msgResponse := tgbotapi.NewMessage(chatID, "Admin qo'shishda xatolik yuz berdi.")
-		botInstance.Send(msgResponse)
-		return
+		return lang.T("admins.add_error")
 	}
 
-	msgResponse := tgbotapi.NewMessage(chatID, "Admin muvaffaqiyatli qo'shildi.")
-	botInstance.Send(msgResponse)
+	Audit(db, chatID, models.AuditAdminAdd, strconv.FormatInt(adminID, 10), map[string]interface{}{"role": role})
+	go SyncCommands(adminID, db, botInstance)
+
+	return lang.T("admins.added", roleLabel(role, lang))
 }
 
 func HandleAdminRemove(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
 	chatID := msg.Chat.ID
 
-	if !storage.IsAdmin(int(chatID), db) {
+	if !HasPermission(chatID, models.PermAdmins, db) {
 		return
 	}
 
-	adminID, err := strconv.ParseInt(msg.Text, 10, 64)
+	lang := i18n.For(chatID)
+	adminID, err := strconv.ParseInt(strings.TrimSpace(msg.Text), 10, 64)
 	if err != nil {
 		log.Printf("Error parsing admin ID: %v", err)
-		msgResponse := tgbotapi.NewMessage(chatID, "Noto'g'ri admin ID formati.")
-		botInstance.Send(msgResponse)
+		msgResponse := tgbotapi.NewMessage(chatID, lang.T("admins.bad_id"))
+		sender.Send(botInstance, msgResponse)
 		return
 	}
 
-	err = storage.RemoveAdminFromDatabase(db, adminID)
+	msgResponse := tgbotapi.NewMessage(chatID, removeAdmin(chatID, adminID, lang, db, botInstance))
+	sender.Send(botInstance, msgResponse)
+}
+
+// removeAdmin adminni o'chiradi va natija matnini qaytaradi. O'zini va owner'ni o'chirib bo'lmaydi.
+func removeAdmin(chatID, adminID int64, lang i18n.Lang, db *sql.DB, botInstance *tgbotapi.BotAPI) string {
+	if adminID == chatID {
+		return lang.T("admins.remove_self")
+	}
+	if adminID == Config.OwnerID {
+		return lang.T("admins.remove_owner")
+	}
+
+	removed, err := storage.RemoveAdminFromDatabase(db, adminID)
 	if err != nil {
 		log.Printf("Error removing admin from database: %v", err)
-		msgResponse := tgbotapi.NewMessage(chatID, "Admin o'chirishda xatolik yuz berdi.")
-		botInstance.Send(msgResponse)
-		return
+		return lang.T("admins.remove_error")
 	}
+	if !removed {
+		return lang.T("admins.not_found")
+	}
+
+	Audit(db, chatID, models.AuditAdminRemove, strconv.FormatInt(adminID, 10), nil)
+	go SyncCommands(adminID, db, botInstance)
 
-	msgResponse := tgbotapi.NewMessage(chatID, "Admin muvaffaqiyatli o'chirildi.")
-	botInstance.Send(msgResponse)
+	return lang.T("admins.removed")
 }
 
 func DisplayChannelsForDeletion(chatID int64, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !HasPermission(chatID, models.PermChannels, db) {
+		return
+	}
+
+	lang := i18n.For(chatID)
 	channels, err := storage.GetChannelsFromDatabase(db)
 	if err != nil {
 		log.Printf("Error getting channels from database: %v", err)
-		msgResponse := tgbotapi.NewMessage(chatID, "Kanallarni olishda xatolik yuz berdi.")
-		botInstance.Send(msgResponse)
+		msgResponse := tgbotapi.NewMessage(chatID, lang.T("channel.list_error"))
+		sender.Send(botInstance, msgResponse)
 		return
 	}
 
 	var rows [][]tgbotapi.InlineKeyboardButton
 	for _, channel := range channels {
-		button := tgbotapi.NewInlineKeyboardButtonData(channel, "delete_channel_"+channel)
+		button := tgbotapi.NewInlineKeyboardButtonData(channel.Name(), "delete_channel_"+channel.Key())
 		rows = append(rows, tgbotapi.NewInlineKeyboardRow(button))
 	}
 
 	inlineKeyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
-	msgResponse := tgbotapi.NewMessage(chatID, "O'chirilishi kerak bo'lgan kanalni tanlang:")
+	msgResponse := tgbotapi.NewMessage(chatID, lang.T("channel.delete_choose"))
 	msgResponse.ReplyMarkup = inlineKeyboard
-	botInstance.Send(msgResponse)
+	sender.Send(botInstance, msgResponse)
 }
 
 func AskForChannelDeletionConfirmation(chatID int64, messageID int, channel string, botInstance *tgbotapi.BotAPI) {
-	confirmButton := tgbotapi.NewInlineKeyboardButtonData("Ha", "confirm_delete_channel_"+channel)
-	cancelButton := tgbotapi.NewInlineKeyboardButtonData("Yo'q", "cancel_delete_channel")
+	lang := i18n.For(chatID)
+	confirmButton := tgbotapi.NewInlineKeyboardButtonData(lang.T("common.yes"), "confirm_delete_channel_"+channel)
+	cancelButton := tgbotapi.NewInlineKeyboardButtonData(lang.T("common.no"), "cancel_delete_channel")
 
 	inlineKeyboard := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(confirmButton, cancelButton),
 	)
-	msgResponse := tgbotapi.NewMessage(chatID, fmt.Sprintf("%s kanalini o'chirmoqchimisiz?", channel))
+	msgResponse := tgbotapi.NewMessage(chatID, lang.T("channel.delete_confirm", channel))
 	msgResponse.ReplyMarkup = inlineKeyboard
-	botInstance.Send(msgResponse)
+	sender.Send(botInstance, msgResponse)
 
 	// Delete the previous message
 	deleteMsg := tgbotapi.NewDeleteMessage(chatID, messageID)
-	botInstance.Send(deleteMsg)
+	sender.Send(botInstance, deleteMsg)
 }
 
 func HandleStatistics(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
 	chatID := msg.Chat.ID
 
-	if !storage.IsAdmin(int(chatID), db) {
-		return
-	}
-
-	// Fetch user statistics from the database
-	totalUsers, err := storage.GetTotalUsers(db)
-	if err != nil {
-		log.Printf("Error getting total users: %v", err)
-		msgResponse := tgbotapi.NewMessage(chatID, "Statistikani olishda xatolik yuz berdi.")
-		botInstance.Send(msgResponse)
-		return
-	}
-
-	todayUsers, err := storage.GetTodayUsers(db)
-	if err != nil {
-		log.Printf("Error getting today's users: %v", err)
-		msgResponse := tgbotapi.NewMessage(chatID, "Statistikani olishda xatolik yuz berdi.")
-		botInstance.Send(msgResponse)
+	if !HasPermission(chatID, models.PermStats, db) {
 		return
 	}
 
-	lastMonthUsers, err := storage.GetLastMonthUsers(db)
-	if err != nil {
-		log.Printf("Error getting last month's users: %v", err)
-		msgResponse := tgbotapi.NewMessage(chatID, "Statistikani olishda xatolik yuz berdi.")
-		botInstance.Send(msgResponse)
-		return
-	}
-
-	// Create the response message
-	statsMessage := fmt.Sprintf(
-		"Foydalanuvchilar statistikasi:\n\nBugun qo'shilgan foydalanuvchilar: %d\nOxirgi 1 oy ichida qo'shilgan foydalanuvchilar: %d\nUmumiy foydalanuvchilar soni: %d",
-		todayUsers, lastMonthUsers, totalUsers,
-	)
-
-	msgResponse := tgbotapi.NewMessage(chatID, statsMessage)
-	botInstance.Send(msgResponse)
+	sendStatistics(chatID, 0, statsPeriodToday, db, botInstance)
 }
 
 func HandleBroadcastMessage(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
 	chatID := msg.Chat.ID
 
-	if !storage.IsAdmin(int(chatID), db) {
-		return
-	}
-
-	if msg.Text == "/cancel" {
-		msgResponse := tgbotapi.NewMessage(chatID, "Habar yuborish bekor qilindi.")
-		botInstance.Send(msgResponse)
+	if !HasPermission(chatID, models.PermBroadcast, db) {
 		return
 	}
 
-	users, err := storage.GetAllUsers(db)
-	if err != nil {
-		log.Printf("Error retrieving users: %v", err)
-		msgResponse := tgbotapi.NewMessage(chatID, "Foydalanuvchilarni olishda xatolik yuz berdi.")
-		botInstance.Send(msgResponse)
-		return
-	}
-
-	go sendBroadcastMessage(users, msg.Text, chatID, botInstance)
-	msgResponse := tgbotapi.NewMessage(chatID, fmt.Sprintf("Habar %d foydalanuvchilarga yuborilmoqda...", len(users)))
-	botInstance.Send(msgResponse)
-}
-
-func sendBroadcastMessage(users []models.User, message string, adminChatID int64, botInstance *tgbotapi.BotAPI) {
-	ticker := time.NewTicker(200 * time.Millisecond)
-	defer ticker.Stop()
-
-	count := 0
-	for _, user := range users {
-		<-ticker.C
-		msg := tgbotapi.NewMessage(user.ID, message)
-		if _, err := botInstance.Send(msg); err != nil {
-			log.Printf("Error sending message to user %d: %v", user.ID, err)
-		} else {
-			count++
-		}
+	draft, ok := broadcastDrafts[chatID]
+	if !ok {
+		draft = &models.Broadcast{AdminID: chatID, SourceChatID: chatID, Audience: models.Audience{Kind: models.AudienceAll}}
+		broadcastDrafts[chatID] = draft
 	}
+	draft.SourceMessageID = msg.MessageID
 
-	log.Printf("Broadcast completed. Sent %d messages.", count)
-	msgResponse := tgbotapi.NewMessage(adminChatID, fmt.Sprintf("BroadcasadminChatIDt completed. Sent %d messages.", count))
-	botInstance.Send(msgResponse)
+	sendBroadcastOptions(chatID, draft, botInstance)
 }
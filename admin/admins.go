@@ -0,0 +1,232 @@
+package admin
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
+	"yuklovchiBot/pkg/state"
+	"yuklovchiBot/pkg/telegram"
+	"yuklovchiBot/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// RequestIDAdmin "Foydalanuvchini tanlash" tugmasining request_id si, users_shared javobi shu bo'yicha ajratiladi
+const RequestIDAdmin = 1
+
+// DisplayAdmins adminlar ro'yxatini ismlari va o'chirish tugmalari bilan ko'rsatadi.
+// messageID 0 bo'lmasa, mavjud xabar yangilanadi.
+func DisplayAdmins(chatID int64, messageID int, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	displayAdmins(chatID, messageID, "", db, botInstance)
+}
+
+func displayAdmins(chatID int64, messageID int, notice string, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !HasPermission(chatID, models.PermAdmins, db) {
+		return
+	}
+
+	lang := i18n.For(chatID)
+	admins, err := storage.GetAdminList(db)
+	if err != nil {
+		log.Printf("Error getting admins: %v", err)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("admins.list_error")))
+		return
+	}
+
+	var sb strings.Builder
+	if notice != "" {
+		sb.WriteString(notice + "\n\n")
+	}
+	sb.WriteString(lang.T("admins.title", len(admins)) + "\n")
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i, a := range admins {
+		name := chatName(a.ID, botInstance)
+		sb.WriteString(fmt.Sprintf("\n%d. %s — %s\n    ID: %d", i+1, name, roleLabel(a.Role, lang), a.ID))
+
+		if a.Role == models.RoleOwner || a.ID == chatID {
+			continue
+		}
+		button := tgbotapi.NewInlineKeyboardButtonData("❌ "+truncate(name, 40), fmt.Sprintf("admin_del|%d", a.ID))
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(button))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(lang.T("admins.add"), "admin_new")))
+	inlineKeyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+
+	if messageID != 0 {
+		editMsg := tgbotapi.NewEditMessageText(chatID, messageID, sb.String())
+		editMsg.ReplyMarkup = &inlineKeyboard
+		sender.Send(botInstance, editMsg)
+		return
+	}
+
+	msgResponse := tgbotapi.NewMessage(chatID, sb.String())
+	msgResponse.ReplyMarkup = inlineKeyboard
+	sender.Send(botInstance, msgResponse)
+}
+
+// AskAdminRemoval o'chirishni tasdiqlashni so'raydi. data: "admin_del|<id>"
+func AskAdminRemoval(chatID int64, messageID int, data string, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !HasPermission(chatID, models.PermAdmins, db) {
+		return
+	}
+
+	adminID, err := strconv.ParseInt(strings.TrimPrefix(data, "admin_del|"), 10, 64)
+	if err != nil {
+		log.Printf("Error parsing admin ID: %v", err)
+		return
+	}
+
+	lang := i18n.For(chatID)
+	inlineKeyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("common.yes"), fmt.Sprintf("admin_del_ok|%d", adminID)),
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("common.no"), "admins"),
+		),
+	)
+	editMsg := tgbotapi.NewEditMessageText(chatID, messageID,
+		lang.T("admins.remove_confirm", chatName(adminID, botInstance), adminID))
+	editMsg.ReplyMarkup = &inlineKeyboard
+	sender.Send(botInstance, editMsg)
+}
+
+// ConfirmAdminRemoval tasdiqlangan adminni o'chirib, ro'yxatni yangilaydi. data: "admin_del_ok|<id>"
+func ConfirmAdminRemoval(chatID int64, messageID int, data string, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !HasPermission(chatID, models.PermAdmins, db) {
+		return
+	}
+
+	adminID, err := strconv.ParseInt(strings.TrimPrefix(data, "admin_del_ok|"), 10, 64)
+	if err != nil {
+		log.Printf("Error parsing admin ID: %v", err)
+		return
+	}
+
+	displayAdmins(chatID, messageID, removeAdmin(chatID, adminID, i18n.For(chatID), db, botInstance), db, botInstance)
+}
+
+// AskNewAdmin yangi adminni tanlash usullarini ko'rsatadi: foydalanuvchini tanlash tugmasi,
+// kontakt, forward qilingan habar yoki ID
+func AskNewAdmin(chatID int64, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !HasPermission(chatID, models.PermAdmins, db) {
+		return
+	}
+
+	lang := i18n.For(chatID)
+	isBot := false
+	keyboard := telegram.ReplyKeyboardMarkup{
+		Keyboard: [][]telegram.KeyboardButton{
+			{{
+				Text: lang.T("admins.pick_user"),
+				RequestUsers: &telegram.KeyboardButtonRequestUsers{
+					RequestID:       RequestIDAdmin,
+					UserIsBot:       &isBot,
+					MaxQuantity:     1,
+					RequestName:     true,
+					RequestUsername: true,
+				},
+			}},
+			{{Text: lang.T("admins.pick_cancel")}},
+		},
+		ResizeKeyboard: true,
+	}
+
+	state.UserStates[chatID] = "waiting_for_admin_id"
+	msgResponse := tgbotapi.NewMessage(chatID, lang.T("admins.pick_prompt", lang.T("admins.pick_user")))
+	msgResponse.ReplyMarkup = keyboard
+	sender.Send(botInstance, msgResponse)
+}
+
+// HandleAdminShared "Foydalanuvchini tanlash" tugmasi orqali tanlangan foydalanuvchi uchun rol so'raydi
+func HandleAdminShared(chatID int64, shared *telegram.UsersShared, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	role := adminRole(chatID, db)
+	if !models.HasPermission(role, models.PermAdmins) || len(shared.Users) == 0 {
+		return
+	}
+
+	u := shared.Users[0]
+	name := userName(u.FirstName, u.LastName, u.Username)
+	if name == "" {
+		name = chatName(u.UserID, botInstance)
+	}
+	askAdminRole(chatID, u.UserID, name, role, botInstance)
+}
+
+// askAdminRole admin menyusini qaytaradi va tanlangan foydalanuvchi uchun rol tugmalarini yuboradi
+func askAdminRole(chatID, userID int64, name, role string, botInstance *tgbotapi.BotAPI) {
+	lang := i18n.For(chatID)
+	msgResponse := tgbotapi.NewMessage(chatID, fmt.Sprintf("👤 %s (%d)", name, userID))
+	msgResponse.ReplyMarkup = adminKeyboard(role, lang)
+	sender.Send(botInstance, msgResponse)
+
+	var row []tgbotapi.InlineKeyboardButton
+	for _, r := range models.Roles {
+		if r == models.RoleOwner {
+			continue
+		}
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(roleLabel(r, lang), fmt.Sprintf("admin_role|%d|%s", userID, r)))
+	}
+	inlineKeyboard := tgbotapi.NewInlineKeyboardMarkup(
+		row,
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(lang.T("common.cancel"), fmt.Sprintf("admin_role|%d|cancel", userID))),
+	)
+	roleMsg := tgbotapi.NewMessage(chatID, lang.T("admins.choose_role"))
+	roleMsg.ReplyMarkup = inlineKeyboard
+	sender.Send(botInstance, roleMsg)
+}
+
+// HandleAdminRole rol tugmasini qayta ishlaydi. data: "admin_role|<id>|<rol|cancel>"
+func HandleAdminRole(chatID int64, messageID int, data string, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !HasPermission(chatID, models.PermAdmins, db) {
+		return
+	}
+
+	parts := strings.Split(data, "|")
+	if len(parts) != 3 {
+		return
+	}
+	adminID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		log.Printf("Error parsing admin ID: %v", err)
+		return
+	}
+
+	lang := i18n.For(chatID)
+	if parts[2] == "cancel" {
+		sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("admins.add_cancelled")))
+		return
+	}
+
+	sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, addAdmin(chatID, adminID, parts[2], lang, db, botInstance)))
+}
+
+// chatName foydalanuvchi ismini getChat orqali oladi. Bot bilan hech yozishmagan bo'lsa, ID qaytadi.
+func chatName(userID int64, botInstance *tgbotapi.BotAPI) string {
+	chat, err := botInstance.GetChat(tgbotapi.ChatConfig{ChatID: userID})
+	if err != nil {
+		log.Printf("Error getting chat %d: %v", userID, err)
+		return strconv.FormatInt(userID, 10)
+	}
+
+	if name := userName(chat.FirstName, chat.LastName, chat.UserName); name != "" {
+		return name
+	}
+	return strconv.FormatInt(userID, 10)
+}
+
+// userName "Ism Familiya (@username)" ko'rinishida, bo'sh qismlar tushirib qoldiriladi
+func userName(firstName, lastName, username string) string {
+	name := strings.TrimSpace(firstName + " " + lastName)
+	if username != "" {
+		if name == "" {
+			return "@" + username
+		}
+		name += " (@" + username + ")"
+	}
+	return name
+}
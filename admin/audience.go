@@ -0,0 +1,235 @@
+package admin
+
+import (
+	"database/sql"
+	"log"
+	"strconv"
+	"strings"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
+	"yuklovchiBot/pkg/state"
+	"yuklovchiBot/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// Tayyorlanayotgan (hali yuborilmagan) broadcastlar
+var broadcastDrafts = make(map[int64]*models.Broadcast)
+
+// AskBroadcastAudience "Habar yuborish" bosilganda auditoriya tanlash tugmalarini yuboradi
+func AskBroadcastAudience(chatID int64, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !HasPermission(chatID, models.PermBroadcast, db) {
+		return
+	}
+
+	lang := i18n.For(chatID)
+	audienceButton := func(text string, a models.Audience) tgbotapi.InlineKeyboardButton {
+		return tgbotapi.NewInlineKeyboardButtonData(text, "bc_aud|"+a.String())
+	}
+
+	inlineKeyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			audienceButton(lang.T("audience.all_button"), models.Audience{Kind: models.AudienceAll}),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			audienceButton(lang.T("audience.new_button", 7), models.Audience{Kind: models.AudienceNew, Days: 7}),
+			audienceButton(lang.T("audience.new_button", 30), models.Audience{Kind: models.AudienceNew, Days: 30}),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			audienceButton(lang.T("audience.active_button", 7), models.Audience{Kind: models.AudienceActive, Days: 7}),
+			audienceButton(lang.T("audience.active_button", 30), models.Audience{Kind: models.AudienceActive, Days: 30}),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			audienceButton(lang.T("audience.lang_uz"), models.Audience{Kind: models.AudienceLanguage, Value: "uz"}),
+			audienceButton(lang.T("audience.lang_ru"), models.Audience{Kind: models.AudienceLanguage, Value: "ru"}),
+			audienceButton(lang.T("audience.lang_en"), models.Audience{Kind: models.AudienceLanguage, Value: "en"}),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			audienceButton("Instagram", models.Audience{Kind: models.AudiencePlatform, Value: models.PlatformInstagram}),
+			audienceButton("TikTok", models.Audience{Kind: models.AudiencePlatform, Value: models.PlatformTikTok}),
+			audienceButton("YouTube", models.Audience{Kind: models.AudiencePlatform, Value: models.PlatformYouTube}),
+		),
+	)
+
+	msgResponse := tgbotapi.NewMessage(chatID, lang.T("audience.prompt"))
+	msgResponse.ReplyMarkup = inlineKeyboard
+	sender.Send(botInstance, msgResponse)
+}
+
+// HandleBroadcastAudience tanlangan auditoriyani saqlab, habarni so'raydi.
+// data: "bc_aud|<audience>"
+func HandleBroadcastAudience(chatID int64, messageID int, data string, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !HasPermission(chatID, models.PermBroadcast, db) {
+		return
+	}
+
+	lang := i18n.For(chatID)
+	audience := models.ParseAudience(strings.TrimPrefix(data, "bc_aud|"))
+	count, err := storage.CountAudience(db, audience)
+	if err != nil {
+		log.Printf("Error counting broadcast audience: %v", err)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("audience.count_error")))
+		return
+	}
+
+	broadcastDrafts[chatID] = &models.Broadcast{AdminID: chatID, SourceChatID: chatID, Audience: audience}
+	state.UserStates[chatID] = "waiting_for_broadcast_message"
+
+	text := lang.T("broadcast.ask_message", audienceLabel(audience, lang), count)
+	sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, text))
+}
+
+// HandleBroadcastVariant A/B test uchun ikkinchi (B) variantni qabul qiladi
+func HandleBroadcastVariant(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	chatID := msg.Chat.ID
+
+	draft, ok := broadcastDrafts[chatID]
+	if !ok || !HasPermission(chatID, models.PermBroadcast, db) {
+		return
+	}
+
+	draft.VariantBMessageID = msg.MessageID
+	sendBroadcastOptions(chatID, draft, botInstance)
+}
+
+// HandleBroadcastButton "Matn | https://havola" ko'rinishidagi tugmani qabul qiladi.
+// Tugma bosilishi har bir variant uchun alohida hisoblanadi.
+func HandleBroadcastButton(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	chatID := msg.Chat.ID
+
+	draft, ok := broadcastDrafts[chatID]
+	if !ok || !HasPermission(chatID, models.PermBroadcast, db) {
+		return
+	}
+
+	text, link, found := strings.Cut(msg.Text, "|")
+	text, link = strings.TrimSpace(text), strings.TrimSpace(link)
+	if !found || text == "" || !(strings.HasPrefix(link, "https://") || strings.HasPrefix(link, "http://")) {
+		state.UserStates[chatID] = "waiting_for_broadcast_button"
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, i18n.For(chatID).T("broadcast.button_format")))
+		return
+	}
+
+	draft.ButtonText = text
+	draft.ButtonURL = link
+	sendBroadcastOptions(chatID, draft, botInstance)
+}
+
+// HandleBroadcastOption habar tayyor bo'lgandan keyingi tugmalarni qayta ishlaydi.
+// data: "bc_opt|<variant|button|send|cancel>"
+func HandleBroadcastOption(chatID int64, messageID int, data string, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !HasPermission(chatID, models.PermBroadcast, db) {
+		return
+	}
+
+	lang := i18n.For(chatID)
+	draft, ok := broadcastDrafts[chatID]
+	if !ok || draft.SourceMessageID == 0 {
+		sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("broadcast.draft_missing")))
+		return
+	}
+
+	switch strings.TrimPrefix(data, "bc_opt|") {
+	case "variant":
+		state.UserStates[chatID] = "waiting_for_broadcast_variant"
+		sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("broadcast.ask_variant")))
+	case "button":
+		state.UserStates[chatID] = "waiting_for_broadcast_button"
+		sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("broadcast.ask_button")))
+	case "send":
+		delete(broadcastDrafts, chatID)
+		sender.Send(botInstance, tgbotapi.NewDeleteMessage(chatID, messageID))
+		if _, err := startBroadcast(*draft, db, botInstance); err != nil {
+			log.Printf("Error creating broadcast: %v", err)
+			sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("broadcast.start_error")))
+		}
+	default:
+		delete(broadcastDrafts, chatID)
+		sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("broadcast.cancelled")))
+	}
+}
+
+func sendBroadcastOptions(chatID int64, draft *models.Broadcast, botInstance *tgbotapi.BotAPI) {
+	lang := i18n.For(chatID)
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if draft.VariantBMessageID == 0 {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(lang.T("broadcast.add_variant"), "bc_opt|variant")))
+	}
+	if draft.ButtonText == "" {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(lang.T("broadcast.add_button"), "bc_opt|button")))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(lang.T("broadcast.send"), "bc_opt|send"),
+		tgbotapi.NewInlineKeyboardButtonData(lang.T("broadcast.cancel"), "bc_opt|cancel"),
+	))
+
+	text := lang.T("broadcast.audience", audienceLabel(draft.Audience, lang))
+	if draft.VariantBMessageID != 0 {
+		text += lang.T("broadcast.ab_note")
+	}
+	if draft.ButtonText != "" {
+		text += lang.T("broadcast.button_line", draft.ButtonText, draft.ButtonURL)
+	}
+
+	msgResponse := tgbotapi.NewMessage(chatID, text)
+	msgResponse.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	msgResponse.DisableWebPagePreview = true
+	sender.Send(botInstance, msgResponse)
+}
+
+// RecordBroadcastClick broadcast tugmasi bosilganini yozadi va foydalanuvchiga havolani yuboradi.
+// data: "bc_click|<broadcast_id>|<variant>"
+func RecordBroadcastClick(callbackQuery *tgbotapi.CallbackQuery, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	chatID := callbackQuery.Message.Chat.ID
+
+	parts := strings.Split(callbackQuery.Data, "|")
+	if len(parts) != 3 {
+		log.Printf("Unknown broadcast click data: %s", callbackQuery.Data)
+		return
+	}
+	broadcastID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		log.Printf("Error parsing broadcast ID: %v", err)
+		return
+	}
+
+	b, err := storage.GetBroadcast(db, broadcastID)
+	if err != nil {
+		log.Printf("Error getting broadcast %d: %v", broadcastID, err)
+		return
+	}
+
+	if err := storage.AddBroadcastClick(db, broadcastID, int64(callbackQuery.From.ID), parts[2]); err != nil {
+		log.Printf("Error saving broadcast %d click: %v", broadcastID, err)
+	}
+	botInstance.AnswerCallbackQuery(tgbotapi.NewCallback(callbackQuery.ID, ""))
+
+	if b.ButtonURL == "" {
+		return
+	}
+	msgResponse := tgbotapi.NewMessage(chatID, b.ButtonURL)
+	msgResponse.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonURL(b.ButtonText, b.ButtonURL)),
+	)
+	sender.Send(botInstance, msgResponse)
+}
+
+func audienceLabel(a models.Audience, lang i18n.Lang) string {
+	switch a.Kind {
+	case models.AudienceNew:
+		return lang.T("audience.new", a.Days)
+	case models.AudienceActive:
+		return lang.T("audience.active", a.Days)
+	case models.AudienceLanguage:
+		return lang.T("audience.language", a.Value)
+	case models.AudiencePlatform:
+		return lang.T("audience.platform", a.Value)
+	}
+	return lang.T("audience.all")
+}
+
+// clickRate yetkazilgan habarlarga nisbatan tugma bosish foizi
+func clickRate(s models.BroadcastStats) string {
+	return percent(s.Clicks, s.Sent)
+}
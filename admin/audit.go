@@ -0,0 +1,200 @@
+package admin
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
+	"yuklovchiBot/pkg/state"
+	"yuklovchiBot/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const auditPageSize = 10
+
+var auditSections = []string{"channel", "admin", "broadcast", "schedule", "backup", "export"}
+
+// Admin bo'yicha filtr kiritilayotganda tanlangan bo'lim saqlanib turadi
+var auditSectionDrafts = make(map[int64]string)
+
+// Audit admin amalini admin_audit jadvaliga yozadi. Xatolik faqat logga chiqadi, amalning o'zi to'xtatilmaydi.
+func Audit(db *sql.DB, actorID int64, action, target string, payload map[string]interface{}) {
+	e := models.AuditEntry{ActorID: actorID, Action: action, Target: target}
+	if len(payload) > 0 {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			log.Printf("Error encoding audit payload: %v", err)
+		}
+		e.Payload = string(data)
+	}
+
+	if err := storage.AddAuditEntry(db, e); err != nil {
+		log.Printf("Error saving audit entry %s: %v", action, err)
+	}
+}
+
+// DisplayAuditLog amallar tarixini sahifalab ko'rsatadi. messageID 0 bo'lmasa xabar yangilanadi.
+// data: "audit|<sahifa>|<actor_id>|<bo'lim>", actor_id 0 va bo'sh bo'lim — filtrsiz
+func DisplayAuditLog(chatID int64, messageID int, data string, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !HasPermission(chatID, models.PermAudit, db) {
+		return
+	}
+
+	var page int
+	var filter models.AuditFilter
+	if parts := strings.Split(data, "|"); len(parts) == 4 {
+		page, _ = strconv.Atoi(parts[1])
+		filter.ActorID, _ = strconv.ParseInt(parts[2], 10, 64)
+		filter.Section = parts[3]
+	}
+
+	lang := i18n.For(chatID)
+
+	// Keyingi sahifa borligini bilish uchun bitta ortiq yozuv olinadi
+	entries, err := storage.GetAuditEntries(db, filter, auditPageSize+1, page*auditPageSize)
+	if err != nil {
+		log.Printf("Error getting audit entries: %v", err)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("audit.load_error")))
+		return
+	}
+	hasNext := len(entries) > auditPageSize
+	if hasNext {
+		entries = entries[:auditPageSize]
+	}
+
+	text := auditLogText(entries, filter, page, lang)
+	keyboard := auditKeyboard(filter, page, hasNext, lang)
+
+	if messageID != 0 {
+		editMsg := tgbotapi.NewEditMessageText(chatID, messageID, text)
+		editMsg.ReplyMarkup = &keyboard
+		sender.Send(botInstance, editMsg)
+		return
+	}
+
+	msgResponse := tgbotapi.NewMessage(chatID, text)
+	msgResponse.ReplyMarkup = keyboard
+	sender.Send(botInstance, msgResponse)
+}
+
+// AskAuditActor admin ID sini so'raydi ("audit_actor|<bo'lim>" tugmasi)
+func AskAuditActor(chatID int64, messageID int, data string, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !HasPermission(chatID, models.PermAudit, db) {
+		return
+	}
+
+	auditSectionDrafts[chatID] = strings.TrimPrefix(data, "audit_actor|")
+	state.UserStates[chatID] = "waiting_for_audit_actor"
+	sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, i18n.For(chatID).T("audit.ask_actor")))
+}
+
+// HandleAuditActor kiritilgan ID yoki forward qilingan habar egasi bo'yicha tarixni ko'rsatadi
+func HandleAuditActor(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	chatID := msg.Chat.ID
+
+	section, ok := auditSectionDrafts[chatID]
+	delete(auditSectionDrafts, chatID)
+	if !ok || !HasPermission(chatID, models.PermAudit, db) {
+		return
+	}
+
+	var actorID int64
+	if msg.ForwardFrom != nil {
+		actorID = int64(msg.ForwardFrom.ID)
+	} else {
+		id, err := strconv.ParseInt(strings.TrimSpace(msg.Text), 10, 64)
+		if err != nil {
+			sender.Send(botInstance, tgbotapi.NewMessage(chatID, i18n.For(chatID).T("admins.bad_id")))
+			return
+		}
+		actorID = id
+	}
+
+	DisplayAuditLog(chatID, 0, fmt.Sprintf("audit|0|%d|%s", actorID, section), db, botInstance)
+}
+
+func auditLogText(entries []models.AuditEntry, filter models.AuditFilter, page int, lang i18n.Lang) string {
+	loc := Config.Location()
+
+	var sb strings.Builder
+	sb.WriteString(lang.T("audit.title", page+1))
+	if filter.ActorID != 0 {
+		sb.WriteString(lang.T("audit.actor", filter.ActorID))
+	}
+	if filter.Section != "" {
+		sb.WriteString(lang.T("audit.section", auditSectionLabel(filter.Section, lang)))
+	}
+	sb.WriteString("\n")
+
+	if len(entries) == 0 {
+		sb.WriteString(lang.T("audit.empty"))
+	}
+	for _, e := range entries {
+		actor := strconv.FormatInt(e.ActorID, 10)
+		if e.ActorID == models.AuditSystem {
+			actor = lang.T("audit.system")
+		}
+		sb.WriteString(fmt.Sprintf("#%d · %s · %s\n%s", e.ID, e.CreatedAt.In(loc).Format("2006-01-02 15:04"), actor, e.Action))
+		if e.Target != "" {
+			sb.WriteString(" → " + e.Target)
+		}
+		sb.WriteString("\n")
+		if e.Payload != "" {
+			sb.WriteString("    " + truncate(e.Payload, 150) + "\n")
+		}
+	}
+
+	return sb.String()
+}
+
+func auditKeyboard(filter models.AuditFilter, page int, hasNext bool, lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	data := func(page int, actorID int64, section string) string {
+		return fmt.Sprintf("audit|%d|%d|%s", page, actorID, section)
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	sections := append([]string{""}, auditSections...)
+	for i := 0; i < len(sections); i += 4 {
+		var row []tgbotapi.InlineKeyboardButton
+		for _, section := range sections[i:min(i+4, len(sections))] {
+			text := auditSectionLabel(section, lang)
+			if section == filter.Section {
+				text = "• " + text
+			}
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData(text, data(0, filter.ActorID, section)))
+		}
+		rows = append(rows, row)
+	}
+
+	actorRow := []tgbotapi.InlineKeyboardButton{tgbotapi.NewInlineKeyboardButtonData(lang.T("audit.by_actor"), "audit_actor|"+filter.Section)}
+	if filter.ActorID != 0 {
+		actorRow = append(actorRow, tgbotapi.NewInlineKeyboardButtonData(lang.T("audit.all_actors"), data(0, 0, filter.Section)))
+	}
+	rows = append(rows, actorRow)
+
+	var nav []tgbotapi.InlineKeyboardButton
+	if page > 0 {
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(lang.T("page.prev"), data(page-1, filter.ActorID, filter.Section)))
+	}
+	if hasNext {
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(lang.T("page.next"), data(page+1, filter.ActorID, filter.Section)))
+	}
+	if len(nav) > 0 {
+		rows = append(rows, nav)
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func auditSectionLabel(section string, lang i18n.Lang) string {
+	if section == "" {
+		return lang.T("audit.section_all")
+	}
+	return lang.T("audit.section_" + section)
+}
@@ -0,0 +1,79 @@
+package admin
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/cron"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
+	"yuklovchiBot/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// DisplayBackups oxirgi backuplar tarixini va keyingi avtomatik backup vaqtini ko'rsatadi
+func DisplayBackups(chatID int64, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !HasPermission(chatID, models.PermBackups, db) {
+		return
+	}
+
+	lang := i18n.For(chatID)
+	backups, err := storage.GetRecentBackups(db, 10)
+	if err != nil {
+		log.Printf("Error getting backups: %v", err)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("backups.load_error")))
+		return
+	}
+
+	cfg := Config
+	loc := cfg.Location()
+
+	var sb strings.Builder
+	sb.WriteString(lang.T("backups.title"))
+	if schedule, err := cron.Parse(cfg.BackupSchedule); err == nil {
+		if next := schedule.Next(time.Now().In(loc)); !next.IsZero() {
+			sb.WriteString(lang.T("backups.next", next.Format("2006-01-02 15:04"), cfg.BackupSchedule))
+		}
+	} else {
+		sb.WriteString(lang.T("backups.auto_off"))
+	}
+	sb.WriteString(lang.T("backups.retention", cfg.BackupRetention))
+
+	if len(backups) == 0 {
+		sb.WriteString(lang.T("backups.empty"))
+	}
+	for _, b := range backups {
+		icon := "✅"
+		if b.Status != models.BackupSuccess {
+			icon = "❌"
+		}
+		sb.WriteString(fmt.Sprintf("%s %s · %s · %s\n", icon, b.CreatedAt.In(loc).Format("2006-01-02 15:04"),
+			backupTriggerLabel(b.Trigger, lang), b.Duration.Round(100*time.Millisecond)))
+		if b.Status == models.BackupSuccess {
+			sb.WriteString(fmt.Sprintf("    %s, %s\n    %s\n", b.FileName, formatBytes(b.Bytes), b.Destination))
+		} else {
+			sb.WriteString(fmt.Sprintf("    %s\n", truncate(b.Error, 200)))
+		}
+	}
+
+	sender.Send(botInstance, tgbotapi.NewMessage(chatID, sb.String()))
+}
+
+func backupTriggerLabel(trigger string, lang i18n.Lang) string {
+	if trigger == models.BackupScheduled {
+		return lang.T("backups.trigger_scheduled")
+	}
+	return lang.T("backups.trigger_manual")
+}
+
+func truncate(s string, max int) string {
+	r := []rune(strings.TrimSpace(s))
+	if len(r) <= max {
+		return string(r)
+	}
+	return string(r[:max]) + "…"
+}
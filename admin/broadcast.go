@@ -0,0 +1,316 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
+	"yuklovchiBot/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const (
+	broadcastBatchSize        = 25
+	broadcastInterval         = 200 * time.Millisecond
+	broadcastProgressInterval = 5 * time.Second
+	broadcastPollInterval     = 10 * time.Second
+)
+
+// broadcastWake yangi broadcast yaratilganda yoki davom ettirilganda workerni uyg'otadi
+var broadcastWake = make(chan struct{}, 1)
+
+func wakeBroadcastWorker() {
+	select {
+	case broadcastWake <- struct{}{}:
+	default:
+	}
+}
+
+// StartBroadcastWorker "running" holatidagi broadcastlarni ketma-ket yuboradi.
+// Jarayon qayta ishga tushsa, yuborilmagan foydalanuvchilar bazadan olinib davom ettiriladi.
+func StartBroadcastWorker(ctx context.Context, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	ticker := time.NewTicker(broadcastPollInterval)
+	defer ticker.Stop()
+
+	for {
+		broadcasts, err := storage.GetRunningBroadcasts(db)
+		if err != nil {
+			log.Printf("Error getting running broadcasts: %v", err)
+		}
+
+		for _, b := range broadcasts {
+			if ctx.Err() != nil {
+				return
+			}
+			runBroadcast(ctx, b, db, botInstance)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("Stopping broadcast worker...")
+			return
+		case <-broadcastWake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// startBroadcast admin chatida progress xabarini ochadi, broadcastni yaratadi va workerni uyg'otadi
+func startBroadcast(b models.Broadcast, db *sql.DB, botInstance *tgbotapi.BotAPI) (int64, error) {
+	progressMsg, err := sender.Send(botInstance, tgbotapi.NewMessage(b.AdminID, i18n.For(b.AdminID).T("broadcast.starting")))
+	if err != nil {
+		return 0, err
+	}
+	b.ProgressMessageID = progressMsg.MessageID
+
+	broadcastID, err := storage.CreateBroadcast(db, b)
+	if err != nil {
+		return 0, err
+	}
+
+	Audit(db, b.AdminID, models.AuditBroadcastStart, strconv.FormatInt(broadcastID, 10), map[string]interface{}{
+		"audience":  b.Audience.String(),
+		"ab_test":   b.VariantBMessageID != 0,
+		"button":    b.ButtonURL,
+		"source_id": b.SourceMessageID,
+	})
+
+	// Broadcast saqlangan, worker uni baribir yuboradi, shuning uchun ID xatolik bilan ham qaytariladi
+	b, err = storage.GetBroadcast(db, broadcastID)
+	if err != nil {
+		wakeBroadcastWorker()
+		return broadcastID, err
+	}
+
+	updateBroadcastProgress(b, db, botInstance, 0)
+	wakeBroadcastWorker()
+	return broadcastID, nil
+}
+
+func runBroadcast(ctx context.Context, b models.Broadcast, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	limiter := time.NewTicker(broadcastInterval)
+	defer limiter.Stop()
+
+	startedAt := time.Now()
+	processed := 0
+	var lastProgress time.Time
+
+	for {
+		current, err := storage.GetBroadcast(db, b.ID)
+		if err != nil {
+			log.Printf("Error getting broadcast %d: %v", b.ID, err)
+			return
+		}
+		if current.Status != models.BroadcastRunning {
+			updateBroadcastProgress(current, db, botInstance, 0)
+			return
+		}
+
+		recipients, err := storage.GetPendingRecipients(db, b.ID, broadcastBatchSize)
+		if err != nil {
+			log.Printf("Error getting broadcast %d recipients: %v", b.ID, err)
+			return
+		}
+
+		if len(recipients) == 0 {
+			if _, err := storage.SetBroadcastStatus(db, b.ID, models.BroadcastCompleted); err != nil {
+				log.Printf("Error completing broadcast %d: %v", b.ID, err)
+				return
+			}
+			current.Status = models.BroadcastCompleted
+			updateBroadcastProgress(current, db, botInstance, 0)
+
+			log.Printf("Broadcast %d completed.", b.ID)
+			msgResponse := tgbotapi.NewMessage(b.AdminID, i18n.For(b.AdminID).T("broadcast.finished"))
+			msgResponse.ReplyToMessageID = current.ProgressMessageID
+			sender.Send(botInstance, msgResponse)
+			return
+		}
+
+		for _, r := range recipients {
+			select {
+			case <-ctx.Done():
+				return
+			case <-limiter.C:
+			}
+
+			status, errText := deliverBroadcast(current, r, botInstance)
+			if err := storage.MarkBroadcastRecipient(db, b.ID, r.UserID, status, errText); err != nil {
+				log.Printf("Error updating broadcast %d recipient %d: %v", b.ID, r.UserID, err)
+			}
+			processed++
+		}
+
+		if time.Since(lastProgress) >= broadcastProgressInterval {
+			perMessage := time.Since(startedAt) / time.Duration(processed)
+			updateBroadcastProgress(current, db, botInstance, perMessage)
+			lastProgress = time.Now()
+		}
+	}
+}
+
+func deliverBroadcast(b models.Broadcast, r models.BroadcastRecipient, botInstance *tgbotapi.BotAPI) (string, string) {
+	var replyMarkup string
+	if b.ButtonText != "" {
+		data := fmt.Sprintf("bc_click|%d|%s", b.ID, r.Variant)
+		keyboard := tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(b.ButtonText, data)),
+		)
+		markup, err := json.Marshal(keyboard)
+		if err != nil {
+			return models.RecipientFailed, err.Error()
+		}
+		replyMarkup = string(markup)
+	}
+
+	err := copyMessage(botInstance, r.UserID, b.SourceChatID, b.MessageID(r.Variant), replyMarkup)
+	if err == nil {
+		return models.RecipientSent, ""
+	}
+
+	log.Printf("Error sending message to user %d: %v", r.UserID, err)
+	if sender.InactiveStatus(err) != "" {
+		return models.RecipientBlocked, err.Error()
+	}
+	return models.RecipientFailed, err.Error()
+}
+
+// copyMessage admin yuborgan xabarni (matn, rasm, video va h.k.) foydalanuvchiga nusxalaydi
+func copyMessage(botInstance *tgbotapi.BotAPI, chatID, fromChatID int64, messageID int, replyMarkup string) error {
+	params := url.Values{}
+	params.Add("chat_id", strconv.FormatInt(chatID, 10))
+	params.Add("from_chat_id", strconv.FormatInt(fromChatID, 10))
+	params.Add("message_id", strconv.Itoa(messageID))
+	if replyMarkup != "" {
+		params.Add("reply_markup", replyMarkup)
+	}
+
+	_, err := sender.Request(botInstance, "copyMessage", params)
+	return err
+}
+
+// updateBroadcastProgress admin chatidagi progress xabarini yangilaydi.
+// perMessage bitta xabarga ketgan o'rtacha vaqt, 0 bo'lsa standart interval olinadi.
+func updateBroadcastProgress(b models.Broadcast, db *sql.DB, botInstance *tgbotapi.BotAPI, perMessage time.Duration) {
+	stats, err := storage.GetBroadcastStats(db, b.ID)
+	if err != nil {
+		log.Printf("Error getting broadcast %d stats: %v", b.ID, err)
+		return
+	}
+
+	if perMessage <= 0 {
+		perMessage = broadcastInterval
+	}
+	lang := i18n.For(b.AdminID)
+	text := broadcastProgressText(b, stats, perMessage*time.Duration(stats.Pending), lang)
+	if b.VariantBMessageID != 0 {
+		variants, err := storage.GetBroadcastVariantStats(db, b.ID)
+		if err != nil {
+			log.Printf("Error getting broadcast %d variant stats: %v", b.ID, err)
+		}
+		for _, variant := range []string{models.VariantA, models.VariantB} {
+			v := variants[variant]
+			text += lang.T("broadcast.variant_stats", variant, v.Sent, v.Total, v.Failed, v.Blocked)
+			if b.ButtonText != "" {
+				text += lang.T("broadcast.variant_clicks", v.Clicks, clickRate(v))
+			}
+		}
+	}
+	keyboard := broadcastControlKeyboard(b, lang)
+
+	editMsg := tgbotapi.NewEditMessageText(b.AdminID, b.ProgressMessageID, text)
+	editMsg.ReplyMarkup = keyboard
+	if _, err := sender.Send(botInstance, editMsg); err != nil {
+		log.Printf("Error editing broadcast %d progress: %v", b.ID, err)
+	}
+}
+
+func broadcastProgressText(b models.Broadcast, s models.BroadcastStats, eta time.Duration, lang i18n.Lang) string {
+	text := lang.T("broadcast.progress",
+		b.ID, lang.T("broadcast.status_"+b.Status), audienceLabel(b.Audience, lang), s.Sent, s.Failed, s.Blocked, s.Pending, s.Total,
+	)
+	if b.ButtonText != "" {
+		text += lang.T("broadcast.clicks", s.Clicks, clickRate(s))
+	}
+	if b.Status == models.BroadcastRunning && s.Pending > 0 {
+		text += lang.T("broadcast.eta", eta.Round(time.Second))
+	}
+	return text
+}
+
+func broadcastControlKeyboard(b models.Broadcast, lang i18n.Lang) *tgbotapi.InlineKeyboardMarkup {
+	id := strconv.FormatInt(b.ID, 10)
+	cancelButton := tgbotapi.NewInlineKeyboardButtonData(lang.T("broadcast.cancel"), "broadcast_cancel|"+id)
+
+	var keyboard tgbotapi.InlineKeyboardMarkup
+	switch b.Status {
+	case models.BroadcastRunning:
+		pauseButton := tgbotapi.NewInlineKeyboardButtonData(lang.T("broadcast.pause"), "broadcast_pause|"+id)
+		keyboard = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(pauseButton, cancelButton))
+	case models.BroadcastPaused:
+		resumeButton := tgbotapi.NewInlineKeyboardButtonData(lang.T("broadcast.resume"), "broadcast_resume|"+id)
+		keyboard = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(resumeButton, cancelButton))
+	default:
+		return nil
+	}
+	return &keyboard
+}
+
+// HandleBroadcastControl progress xabaridagi to'xtatish/davom ettirish/bekor qilish tugmalarini qayta ishlaydi.
+// data: "broadcast_<action>|<broadcast_id>"
+func HandleBroadcastControl(chatID int64, data string, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !HasPermission(chatID, models.PermBroadcast, db) {
+		return
+	}
+
+	parts := strings.SplitN(data, "|", 2)
+	if len(parts) != 2 {
+		log.Printf("Unknown broadcast callback data: %s", data)
+		return
+	}
+	broadcastID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		log.Printf("Error parsing broadcast ID: %v", err)
+		return
+	}
+
+	var status, action string
+	switch parts[0] {
+	case "broadcast_pause":
+		status, action = models.BroadcastPaused, models.AuditBroadcastPause
+	case "broadcast_resume":
+		status, action = models.BroadcastRunning, models.AuditBroadcastResume
+	case "broadcast_cancel":
+		status, action = models.BroadcastCancelled, models.AuditBroadcastCancel
+	default:
+		log.Printf("Unknown broadcast callback data: %s", data)
+		return
+	}
+
+	if _, err := storage.SetBroadcastStatus(db, broadcastID, status); err != nil {
+		log.Printf("Error updating broadcast %d status: %v", broadcastID, err)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, i18n.For(chatID).T("broadcast.status_error")))
+		return
+	}
+	Audit(db, chatID, action, parts[1], nil)
+
+	b, err := storage.GetBroadcast(db, broadcastID)
+	if err != nil {
+		log.Printf("Error getting broadcast %d: %v", broadcastID, err)
+		return
+	}
+	updateBroadcastProgress(b, db, botInstance, 0)
+
+	if b.Status == models.BroadcastRunning {
+		wakeBroadcastWorker()
+	}
+}
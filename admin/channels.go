@@ -0,0 +1,162 @@
+package admin
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
+	"yuklovchiBot/pkg/telegram"
+	"yuklovchiBot/storage"
+	"yuklovchiBot/subscription"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+var (
+	channelUsernameRe = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{3,31}$`)
+	// https://t.me/c/<id>/<post> — yopiq kanal postiga havola
+	channelPostLinkRe = regexp.MustCompile(`^c/(\d+)(/\d+)?$`)
+)
+
+// Taklif havolasi turi tanlanayotgan yopiq kanallar
+var channelDrafts = make(map[int64]models.Channel)
+
+var (
+	errChannelFormat = errors.New("noto'g'ri kanal formati")
+	errInviteLink    = errors.New("taklif havolasi")
+)
+
+// parseChannelRef admin yuborgan matndan getChat uchun parametr ajratadi.
+// Qabul qilinadi: https://t.me/foo, t.me/foo, @foo, foo, -100123..., https://t.me/c/123/45
+func parseChannelRef(text string) (tgbotapi.ChatConfig, error) {
+	ref := strings.TrimSpace(text)
+
+	if id, err := strconv.ParseInt(ref, 10, 64); err == nil {
+		return tgbotapi.ChatConfig{ChatID: id}, nil
+	}
+
+	lower := strings.ToLower(ref)
+	for _, prefix := range []string{"https://", "http://", "www."} {
+		if strings.HasPrefix(lower, prefix) {
+			ref, lower = ref[len(prefix):], lower[len(prefix):]
+		}
+	}
+	for _, host := range []string{"t.me/", "telegram.me/"} {
+		if strings.HasPrefix(lower, host) {
+			ref = ref[len(host):]
+			break
+		}
+	}
+	ref = strings.TrimSuffix(ref, "/")
+
+	if strings.HasPrefix(ref, "+") || strings.HasPrefix(ref, "joinchat/") {
+		return tgbotapi.ChatConfig{}, errInviteLink
+	}
+	if m := channelPostLinkRe.FindStringSubmatch(ref); m != nil {
+		id, err := strconv.ParseInt("-100"+m[1], 10, 64)
+		if err != nil {
+			return tgbotapi.ChatConfig{}, errChannelFormat
+		}
+		return tgbotapi.ChatConfig{ChatID: id}, nil
+	}
+
+	// t.me/foo/123 — oddiy post havolasi
+	if i := strings.IndexByte(ref, '/'); i > 0 {
+		ref = ref[:i]
+	}
+	ref = strings.TrimPrefix(ref, "@")
+	if !channelUsernameRe.MatchString(ref) {
+		return tgbotapi.ChatConfig{}, errChannelFormat
+	}
+	return tgbotapi.ChatConfig{SuperGroupUsername: "@" + ref}, nil
+}
+
+// resolveChannel kanal mavjudligini va bot unda admin ekanini tekshiradi.
+// Qaytgan xatolik matni to'g'ridan-to'g'ri adminga ko'rsatiladi.
+func resolveChannel(config tgbotapi.ChatConfig, lang i18n.Lang, botInstance *tgbotapi.BotAPI) (models.Channel, string) {
+	chat, err := botInstance.GetChat(config)
+	if err != nil {
+		return models.Channel{}, lang.T("channel.not_found")
+	}
+	if chat.Type != "channel" && chat.Type != "supergroup" && chat.Type != "group" {
+		return models.Channel{}, lang.T("channel.not_channel")
+	}
+
+	member, err := botInstance.GetChatMember(tgbotapi.ChatConfigWithUser{ChatID: chat.ID, UserID: botInstance.Self.ID})
+	if err != nil || (member.Status != "administrator" && member.Status != "creator") {
+		return models.Channel{}, lang.T("channel.bot_not_admin")
+	}
+
+	return models.Channel{ID: chat.ID, Username: chat.UserName, Title: chat.Title}, ""
+}
+
+func askChannelInviteType(chatID int64, channel models.Channel, lang i18n.Lang, botInstance *tgbotapi.BotAPI) {
+	channelDrafts[chatID] = channel
+
+	inlineKeyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(lang.T("channel.invite_plain"), "channel_invite|plain")),
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(lang.T("channel.invite_request"), "channel_invite|request")),
+		tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(lang.T("common.cancel"), "channel_invite|cancel")),
+	)
+	msgResponse := tgbotapi.NewMessage(chatID, lang.T("channel.private", channel.Name()))
+	msgResponse.ReplyMarkup = inlineKeyboard
+	sender.Send(botInstance, msgResponse)
+}
+
+// HandleChannelInviteType yopiq kanal uchun taklif havolasini yaratib, kanalni saqlaydi.
+// data: "channel_invite|<plain|request|cancel>"
+func HandleChannelInviteType(chatID int64, messageID int, data string, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !HasPermission(chatID, models.PermChannels, db) {
+		return
+	}
+
+	lang := i18n.For(chatID)
+	channel, ok := channelDrafts[chatID]
+	delete(channelDrafts, chatID)
+	kind := strings.TrimPrefix(data, "channel_invite|")
+	if !ok || kind == "cancel" {
+		sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("channel.add_cancelled")))
+		return
+	}
+
+	channel.JoinRequest = kind == "request"
+	link, err := telegram.CreateInviteLink(botInstance, channel.ID, lang.T("channel.invite_name"), channel.JoinRequest)
+	if err != nil {
+		log.Printf("Error creating invite link for %d: %v", channel.ID, err)
+		sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("channel.invite_error")))
+		return
+	}
+	channel.InviteLink = link.InviteLink
+
+	sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, saveChannel(chatID, channel, lang, db)))
+}
+
+// saveChannel kanalni saqlaydi va natija matnini qaytaradi
+func saveChannel(chatID int64, channel models.Channel, lang i18n.Lang, db *sql.DB) string {
+	added, err := storage.AddChannelToDatabase(db, channel)
+	if err != nil {
+		log.Printf("Error adding channel to database: %v", err)
+		return lang.T("channel.add_error")
+	}
+	if !added {
+		return lang.T("channel.exists", channel.Name())
+	}
+	subscription.Invalidate()
+
+	Audit(db, chatID, models.AuditChannelAdd, channel.Key(), map[string]interface{}{
+		"id":           channel.ID,
+		"title":        channel.Title,
+		"join_request": channel.JoinRequest,
+	})
+
+	text := lang.T("channel.added", channel.Name())
+	if channel.InviteLink != "" {
+		text += lang.T("channel.added_link", channel.InviteLink)
+	}
+	return text
+}
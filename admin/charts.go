@@ -0,0 +1,205 @@
+package admin
+
+import (
+	"database/sql"
+	"fmt"
+	"image/color"
+	"log"
+	"strings"
+	"time"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/chart"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
+	"yuklovchiBot/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const (
+	chartUsers     = "users"
+	chartDownloads = "downloads"
+	chartErrors    = "errors"
+
+	chartPeriodQuarter = "90d"
+)
+
+var chartPeriods = []string{statsPeriodWeek, statsPeriodMonth, chartPeriodQuarter}
+
+var platformColors = map[string]color.RGBA{
+	models.PlatformInstagram: {225, 48, 108, 255},
+	models.PlatformTikTok:    {37, 200, 200, 255},
+	models.PlatformYouTube:   {230, 33, 23, 255},
+}
+
+// HandleChart statistika grafigini rasm ko'rinishida yuboradi.
+// Statistika ekranidan "chart_open|<turi>|<davr>", grafik ostidagi tugmalardan "chart|<turi>|<davr>" keladi.
+// Grafik ostidagi tugma bosilganda eski rasm o'chirilib, yangisi yuboriladi (rasmni tahrirlab bo'lmaydi).
+func HandleChart(chatID int64, messageID int, data string, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !HasPermission(chatID, models.PermStats, db) {
+		return
+	}
+
+	parts := strings.Split(data, "|")
+	if len(parts) != 3 {
+		log.Printf("Unknown chart data: %s", data)
+		return
+	}
+	kind, period := parts[1], parts[2]
+
+	lang := i18n.For(chatID)
+	png, err := renderChart(kind, period, lang, db)
+	if err != nil {
+		log.Printf("Error rendering %s chart: %v", kind, err)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("chart.error")))
+		return
+	}
+
+	photo := tgbotapi.NewPhotoUpload(chatID, tgbotapi.FileBytes{Name: kind + ".png", Bytes: png})
+	photo.Caption = fmt.Sprintf("📈 %s: %s", chartLabel(kind, lang), statsPeriodLabel(period, lang))
+	photo.ReplyMarkup = chartKeyboard(kind, period, lang)
+	if _, err := sender.Send(botInstance, photo); err != nil {
+		log.Printf("Error sending %s chart: %v", kind, err)
+		return
+	}
+
+	if parts[0] == "chart" {
+		sender.Send(botInstance, tgbotapi.NewDeleteMessage(chatID, messageID))
+	}
+}
+
+func renderChart(kind, period string, lang i18n.Lang, db *sql.DB) ([]byte, error) {
+	since := statsPeriodStart(period)
+	days := chartDays(since)
+
+	labels := make([]string, len(days))
+	index := make(map[string]int, len(days))
+	for i, day := range days {
+		labels[i] = day.Format("02.01")
+		index[day.Format("2006-01-02")] = i
+	}
+
+	switch kind {
+	case chartUsers:
+		base, growth, err := storage.GetUserGrowth(db, since)
+		if err != nil {
+			return nil, err
+		}
+		newUsers := make([]float64, len(days))
+		for _, d := range growth {
+			if i, ok := index[d.Day.Format("2006-01-02")]; ok {
+				newUsers[i] = float64(d.Count)
+			}
+		}
+		total := make([]float64, len(days))
+		sum := float64(base)
+		for i, v := range newUsers {
+			sum += v
+			total[i] = sum
+		}
+		return chart.Line(chart.Chart{
+			Title:  lang.T("chart.users_title"),
+			Labels: labels,
+			Series: []chart.Series{{Name: lang.T("chart.users_total"), Values: total, Color: chart.Palette[3]}},
+		})
+
+	case chartDownloads, chartErrors:
+		daily, err := storage.GetDailyDownloads(db, since)
+		if err != nil {
+			return nil, err
+		}
+
+		if kind == chartErrors {
+			total := make([]int, len(days))
+			failed := make([]int, len(days))
+			for _, d := range daily {
+				if i, ok := index[d.Day.Format("2006-01-02")]; ok {
+					total[i] += d.Count
+					failed[i] += d.Failed
+				}
+			}
+			rate := make([]float64, len(days))
+			for i := range rate {
+				if total[i] > 0 {
+					rate[i] = float64(failed[i]) * 100 / float64(total[i])
+				}
+			}
+			return chart.Line(chart.Chart{
+				Title:   lang.T("chart.errors_title"),
+				Labels:  labels,
+				Series:  []chart.Series{{Name: lang.T("chart.errors_series"), Values: rate, Color: chart.Palette[2]}},
+				YSuffix: "%",
+			})
+		}
+
+		values := make(map[string][]float64, len(models.Platforms))
+		for _, p := range models.Platforms {
+			values[p] = make([]float64, len(days))
+		}
+		for _, d := range daily {
+			i, ok := index[d.Day.Format("2006-01-02")]
+			if _, known := values[d.Platform]; ok && known {
+				values[d.Platform][i] += float64(d.Count)
+			}
+		}
+		var series []chart.Series
+		for _, p := range models.Platforms {
+			series = append(series, chart.Series{Name: p, Values: values[p], Color: platformColors[p]})
+		}
+		return chart.StackedBar(chart.Chart{
+			Title:  lang.T("chart.downloads_title"),
+			Labels: labels,
+			Series: series,
+		})
+	}
+
+	return nil, fmt.Errorf("unknown chart kind %q", kind)
+}
+
+// chartDays since dan bugungacha bo'lgan kunlar (Toshkent vaqti bilan)
+func chartDays(since time.Time) []time.Time {
+	now := time.Now().In(Config.Location())
+	var days []time.Time
+	for day := since; !day.After(now); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+	return days
+}
+
+func chartKeyboard(kind, period string, lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	var kinds, periods []tgbotapi.InlineKeyboardButton
+	for _, k := range []string{chartUsers, chartDownloads, chartErrors} {
+		text := chartLabel(k, lang)
+		if k == kind {
+			text = "• " + text
+		}
+		kinds = append(kinds, tgbotapi.NewInlineKeyboardButtonData(text, "chart|"+k+"|"+period))
+	}
+	for _, p := range chartPeriods {
+		text := statsPeriodLabel(p, lang)
+		if p == period {
+			text = "• " + text
+		}
+		periods = append(periods, tgbotapi.NewInlineKeyboardButtonData(text, "chart|"+kind+"|"+p))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(kinds, periods)
+}
+
+// chartPeriodFor statistika ekranidagi davrga mos grafik davri
+func chartPeriodFor(period string) string {
+	switch period {
+	case statsPeriodWeek, statsPeriodMonth:
+		return period
+	case statsPeriodAll:
+		return chartPeriodQuarter
+	}
+	return statsPeriodWeek
+}
+
+func chartLabel(kind string, lang i18n.Lang) string {
+	switch kind {
+	case chartDownloads, chartErrors:
+		return lang.T("chart." + kind)
+	}
+	return lang.T("chart.users")
+}
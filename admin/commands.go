@@ -0,0 +1,111 @@
+package admin
+
+import (
+	"database/sql"
+	"log"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/telegram"
+	"yuklovchiBot/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// Buyruqlar menyusi. Tavsiflar i18n katalogidagi "cmd.<buyruq>" kalitlaridan olinadi.
+var (
+	userCommands  = []string{"start", "help", "history", "favourites", "invite", "settings", "lang", "cancel"}
+	adminCommands = []string{"start", "help", "history", "favourites", "invite", "settings", "lang", "cancel", "admin"}
+	groupCommands = []string{"help", "settings"}
+)
+
+// commandLanguages setMyCommands language_code va unga mos interfeys tili.
+// Bo'sh kod — tilga xos ro'yxati bo'lmagan foydalanuvchilar uchun.
+var commandLanguages = map[string]i18n.Lang{
+	"":   i18n.English,
+	"en": i18n.English,
+	"uz": i18n.UzLatin,
+	"ru": i18n.Russian,
+	"uk": i18n.Russian,
+	"be": i18n.Russian,
+	"kk": i18n.Russian,
+	"ky": i18n.Russian,
+	"tg": i18n.Russian,
+}
+
+// RegisterCommands foydalanuvchilar va guruhlar uchun buyruqlar menyusini har bir til uchun, adminlar
+// uchun esa alohida (chat scope) o'rnatadi. Bot ishga tushganda chaqiriladi.
+func RegisterCommands(db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	for code, lang := range commandLanguages {
+		scope := telegram.BotCommandScope{Type: "default"}
+		if err := telegram.SetMyCommands(botInstance, botCommands(userCommands, lang), scope, code); err != nil {
+			log.Printf("Error setting bot commands for %q: %v", code, err)
+		}
+		scope = telegram.BotCommandScope{Type: "all_group_chats"}
+		if err := telegram.SetMyCommands(botInstance, botCommands(groupCommands, lang), scope, code); err != nil {
+			log.Printf("Error setting group commands for %q: %v", code, err)
+		}
+	}
+
+	admins, err := storage.GetAdminList(db)
+	if err != nil {
+		log.Printf("Error getting admin list: %v", err)
+	}
+	for _, a := range admins {
+		SyncCommands(a.ID, db, botInstance)
+	}
+	if ownerID := Config.OwnerID; ownerID != 0 {
+		SyncCommands(ownerID, db, botInstance)
+	}
+}
+
+// SyncCommands admin uchun /admin buyrug'i bor menyuni uning tilida o'rnatadi, admin bo'lmasa
+// shaxsiy menyuni o'chiradi. Admin qo'shilganda, o'chirilganda va til o'zgarganda chaqiriladi.
+func SyncCommands(userID int64, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	scope := telegram.BotCommandScope{Type: "chat", ChatID: userID}
+
+	if adminRole(userID, db) == "" {
+		if err := telegram.DeleteMyCommands(botInstance, scope, ""); err != nil {
+			log.Printf("Error deleting bot commands for %d: %v", userID, err)
+		}
+		return
+	}
+
+	if err := telegram.SetMyCommands(botInstance, botCommands(adminCommands, i18n.For(userID)), scope, ""); err != nil {
+		log.Printf("Error setting bot commands for %d: %v", userID, err)
+	}
+}
+
+func botCommands(names []string, lang i18n.Lang) []telegram.BotCommand {
+	commands := make([]telegram.BotCommand, 0, len(names))
+	for _, name := range names {
+		commands = append(commands, telegram.BotCommand{Command: name, Description: lang.T("cmd." + name)})
+	}
+	return commands
+}
+
+// ClearDrafts /cancel bosilganda admin bo'limlarida yarim qolgan qoralamalarni o'chiradi.
+// Biror qoralama bo'lgan bo'lsa true qaytaradi.
+func ClearDrafts(chatID int64) bool {
+	_, channel := channelDrafts[chatID]
+	_, setting := channelSettingDrafts[chatID]
+	_, broadcast := broadcastDrafts[chatID]
+	_, schedule := scheduleDrafts[chatID]
+	_, audit := auditSectionDrafts[chatID]
+	_, export := exportDrafts[chatID]
+
+	delete(channelDrafts, chatID)
+	delete(channelSettingDrafts, chatID)
+	delete(broadcastDrafts, chatID)
+	delete(scheduleDrafts, chatID)
+	delete(auditSectionDrafts, chatID)
+	delete(exportDrafts, chatID)
+	return channel || setting || broadcast || schedule || audit || export
+}
+
+// MenuKeyboard admin menyusi; foydalanuvchi admin bo'lmasa false qaytaradi
+func MenuKeyboard(chatID int64, db *sql.DB) (tgbotapi.ReplyKeyboardMarkup, bool) {
+	role := adminRole(chatID, db)
+	if role == "" {
+		return tgbotapi.ReplyKeyboardMarkup{}, false
+	}
+	return adminKeyboard(role, i18n.For(chatID)), true
+}
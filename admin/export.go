@@ -0,0 +1,232 @@
+package admin
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/export"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
+	"yuklovchiBot/pkg/state"
+	"yuklovchiBot/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const exportRangeLayout = "20060102"
+
+var exportTables = []string{storage.ExportUsers, storage.ExportDownloads, storage.ExportBroadcasts}
+
+// O'z oralig'ini kiritayotgan admin qaysi jadvalni tanlagani
+var exportDrafts = make(map[int64]string)
+
+// HandleExport "Eksport" bosilganda jadval tanlash tugmalarini yuboradi
+func HandleExport(chatID int64, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !HasPermission(chatID, models.PermStats, db) {
+		return
+	}
+
+	lang := i18n.For(chatID)
+	var row []tgbotapi.InlineKeyboardButton
+	for _, table := range exportTables {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(exportTableLabel(table, lang), "export|"+table))
+	}
+
+	msgResponse := tgbotapi.NewMessage(chatID, lang.T("export.prompt"))
+	msgResponse.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(row)
+	sender.Send(botInstance, msgResponse)
+}
+
+// HandleExportCallback eksport bosqichlarini qayta ishlaydi:
+// "export|<jadval>" → davr, "export|<jadval>|<davr>" → format, "export|<jadval>|<davr>|<format>" → fayl.
+// davr: 7d, 30d, all, custom yoki "YYYYMMDD-YYYYMMDD"
+func HandleExportCallback(chatID int64, messageID int, data string, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !HasPermission(chatID, models.PermStats, db) {
+		return
+	}
+
+	lang := i18n.For(chatID)
+	parts := strings.Split(data, "|")
+	table := parts[1]
+
+	switch len(parts) {
+	case 2:
+		row := []tgbotapi.InlineKeyboardButton{}
+		for _, period := range []string{statsPeriodWeek, statsPeriodMonth, statsPeriodAll} {
+			row = append(row, tgbotapi.NewInlineKeyboardButtonData(statsPeriodLabel(period, lang), data+"|"+period))
+		}
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(lang.T("export.range_button"), data+"|custom"))
+
+		editMsg := tgbotapi.NewEditMessageText(chatID, messageID, lang.T("export.ask_period", exportTableLabel(table, lang)))
+		keyboard := tgbotapi.NewInlineKeyboardMarkup(row)
+		editMsg.ReplyMarkup = &keyboard
+		sender.Send(botInstance, editMsg)
+
+	case 3:
+		if parts[2] == "custom" {
+			exportDrafts[chatID] = table
+			state.UserStates[chatID] = "waiting_for_export_range"
+			sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("export.ask_range")))
+			return
+		}
+		sendExportFormats(chatID, messageID, table, parts[2], botInstance)
+
+	case 4:
+		from, to, err := parseExportPeriod(parts[2])
+		if err != nil {
+			log.Printf("Unknown export period: %s", data)
+			return
+		}
+		sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("export.preparing")))
+		go sendExport(chatID, messageID, table, parts[3], from, to, lang, db, botInstance)
+	}
+}
+
+// HandleExportRange admin kiritgan "YYYY-MM-DD YYYY-MM-DD" oralig'ini qabul qiladi
+func HandleExportRange(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	chatID := msg.Chat.ID
+
+	table, ok := exportDrafts[chatID]
+	if !ok || !HasPermission(chatID, models.PermStats, db) {
+		return
+	}
+
+	fields := strings.Fields(msg.Text)
+	var from, to time.Time
+	var err error
+	if len(fields) == 2 {
+		loc := Config.Location()
+		if from, err = time.ParseInLocation("2006-01-02", fields[0], loc); err == nil {
+			to, err = time.ParseInLocation("2006-01-02", fields[1], loc)
+		}
+	}
+	if len(fields) != 2 || err != nil || to.Before(from) {
+		state.UserStates[chatID] = "waiting_for_export_range"
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, i18n.For(chatID).T("export.range_format")))
+		return
+	}
+
+	delete(exportDrafts, chatID)
+	sendExportFormats(chatID, 0, table, from.Format(exportRangeLayout)+"-"+to.Format(exportRangeLayout), botInstance)
+}
+
+func sendExportFormats(chatID int64, messageID int, table, period string, botInstance *tgbotapi.BotAPI) {
+	lang := i18n.For(chatID)
+	prefix := "export|" + table + "|" + period + "|"
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("CSV", prefix+export.FormatCSV),
+		tgbotapi.NewInlineKeyboardButtonData("XLSX", prefix+export.FormatXLSX),
+	))
+	text := lang.T("export.ask_format", exportTableLabel(table, lang))
+
+	if messageID != 0 {
+		editMsg := tgbotapi.NewEditMessageText(chatID, messageID, text)
+		editMsg.ReplyMarkup = &keyboard
+		sender.Send(botInstance, editMsg)
+		return
+	}
+
+	msgResponse := tgbotapi.NewMessage(chatID, text)
+	msgResponse.ReplyMarkup = keyboard
+	sender.Send(botInstance, msgResponse)
+}
+
+// sendExport qatorlarni bazadan to'g'ridan-to'g'ri vaqtinchalik faylga yozadi va hujjat sifatida yuboradi
+func sendExport(chatID int64, messageID int, table, format string, from, to time.Time, lang i18n.Lang, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	fileName := fmt.Sprintf("%s_%s.%s", table, time.Now().In(Config.Location()).Format("2006-01-02_1504"), format)
+
+	// Fayl nomi hujjat nomi bo'lib ko'rinadi, shuning uchun alohida vaqtinchalik katalog ishlatiladi
+	dir, err := os.MkdirTemp("", "export_")
+	if err != nil {
+		log.Printf("Eksport katalogini yaratib bo'lmadi: %v", err)
+		sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("export.error")))
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	filePath := filepath.Join(dir, fileName)
+	count, err := writeExport(filePath, table, format, from, to, db)
+	if err != nil {
+		log.Printf("Error exporting %s: %v", table, err)
+		sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("export.error")))
+		return
+	}
+
+	doc := tgbotapi.NewDocumentUpload(chatID, filePath)
+	doc.Caption = lang.T("export.caption", exportTableLabel(table, lang), count, exportPeriodLabel(from, to, lang))
+	if _, err := sender.Send(botInstance, doc); err != nil {
+		log.Printf("Admin (%d) uchun eksportni yuborishda xatolik: %v", chatID, err)
+		sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("export.send_error")))
+		return
+	}
+
+	Audit(db, chatID, models.AuditExport, table, map[string]interface{}{
+		"format": format,
+		"period": exportPeriodLabel(from, to, i18n.Default),
+		"rows":   count,
+	})
+
+	sender.Send(botInstance, tgbotapi.NewDeleteMessage(chatID, messageID))
+}
+
+func writeExport(filePath, table, format string, from, to time.Time, db *sql.DB) (int, error) {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	w, err := export.New(format, file)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := storage.ExportTable(db, table, from, to, w.Write)
+	if err != nil {
+		return count, err
+	}
+	if err := w.Close(); err != nil {
+		return count, err
+	}
+	return count, file.Close()
+}
+
+// parseExportPeriod davrni [from, to) oralig'iga aylantiradi
+func parseExportPeriod(period string) (time.Time, time.Time, error) {
+	start, end, found := strings.Cut(period, "-")
+	if !found {
+		from := statsPeriodStart(period)
+		return from, time.Now().Add(time.Minute), nil
+	}
+
+	loc := Config.Location()
+	from, err := time.ParseInLocation(exportRangeLayout, start, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	to, err := time.ParseInLocation(exportRangeLayout, end, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return from, to.AddDate(0, 0, 1), nil
+}
+
+func exportPeriodLabel(from, to time.Time, lang i18n.Lang) string {
+	if from.IsZero() {
+		return lang.T("export.all_time")
+	}
+	return fmt.Sprintf("%s — %s", from.Format("2006-01-02"), to.Add(-time.Second).Format("2006-01-02"))
+}
+
+func exportTableLabel(table string, lang i18n.Lang) string {
+	switch table {
+	case storage.ExportDownloads, storage.ExportBroadcasts:
+		return lang.T("export." + table)
+	}
+	return lang.T("export.users")
+}
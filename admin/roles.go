@@ -0,0 +1,40 @@
+package admin
+
+import (
+	"database/sql"
+	"log"
+	"yuklovchiBot/config"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/storage"
+)
+
+// Config main'da bir marta yuklanadigan sozlamalar
+var Config config.Config
+
+// HasPermission foydalanuvchi berilgan amalni bajara oladimi. Config'dagi owner har doim barcha huquqlarga ega.
+func HasPermission(chatID int64, permission string, db *sql.DB) bool {
+	return models.HasPermission(adminRole(chatID, db), permission)
+}
+
+// adminRole foydalanuvchi roli, admin bo'lmasa ""
+func adminRole(chatID int64, db *sql.DB) string {
+	if ownerID := Config.OwnerID; ownerID != 0 && ownerID == chatID {
+		return models.RoleOwner
+	}
+
+	role, err := storage.GetAdminRole(db, chatID)
+	if err != nil {
+		log.Printf("Error getting admin role: %v", err)
+		return ""
+	}
+	return role
+}
+
+func roleLabel(role string, lang i18n.Lang) string {
+	switch role {
+	case models.RoleOwner, models.RoleModerator, models.RoleAnalyst:
+		return lang.T("role." + role)
+	}
+	return lang.T("role.admin")
+}
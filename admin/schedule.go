@@ -0,0 +1,283 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
+	"yuklovchiBot/pkg/state"
+	"yuklovchiBot/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const schedulerInterval = 30 * time.Second
+
+// Admin vaqtni kiritishini qabul qiladigan formatlar
+var scheduleTimeLayouts = []string{"2006-01-02 15:04", "02.01.2006 15:04"}
+
+// Tayyorlanayotgan (hali saqlanmagan) rejalashtirilgan xabarlar
+var scheduleDrafts = make(map[int64]*models.ScheduledMessage)
+
+// HandleScheduledMessage rejalashtiriladigan xabarni qabul qilib, yuborish vaqtini so'raydi
+func HandleScheduledMessage(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	chatID := msg.Chat.ID
+
+	if !HasPermission(chatID, models.PermBroadcast, db) {
+		return
+	}
+
+	scheduleDrafts[chatID] = &models.ScheduledMessage{
+		AdminID:         chatID,
+		SourceChatID:    chatID,
+		SourceMessageID: msg.MessageID,
+	}
+	state.UserStates[chatID] = "waiting_for_schedule_time"
+
+	loc := Config.Location()
+	msgResponse := tgbotapi.NewMessage(chatID, i18n.For(chatID).T("schedule.ask_time",
+		loc.String(), time.Now().In(loc).Add(time.Hour).Format(scheduleTimeLayouts[0])))
+	sender.Send(botInstance, msgResponse)
+}
+
+// HandleScheduleTime kiritilgan vaqtni tekshiradi va takrorlanish turini so'raydi
+func HandleScheduleTime(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	chatID := msg.Chat.ID
+
+	if !HasPermission(chatID, models.PermBroadcast, db) {
+		return
+	}
+
+	draft, ok := scheduleDrafts[chatID]
+	if !ok {
+		return
+	}
+
+	lang := i18n.For(chatID)
+	runAt, err := parseScheduleTime(strings.TrimSpace(msg.Text))
+	if err != nil || !runAt.After(time.Now()) {
+		state.UserStates[chatID] = "waiting_for_schedule_time"
+		msgResponse := tgbotapi.NewMessage(chatID, lang.T("schedule.bad_time"))
+		sender.Send(botInstance, msgResponse)
+		return
+	}
+	draft.RunAt = runAt
+
+	inlineKeyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("schedule.once"), "schedule_repeat|"+models.RepeatOnce),
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("schedule.weekly"), "schedule_repeat|"+models.RepeatWeekly),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("common.cancel"), "schedule_repeat|cancel"),
+		),
+	)
+	msgResponse := tgbotapi.NewMessage(chatID, lang.T("schedule.ask_repeat", formatScheduleTime(runAt)))
+	msgResponse.ReplyMarkup = inlineKeyboard
+	sender.Send(botInstance, msgResponse)
+}
+
+// HandleScheduleRepeat takrorlanish turini tanlash tugmasini qayta ishlaydi va xabarni saqlaydi.
+// data: "schedule_repeat|<once|weekly|cancel>"
+func HandleScheduleRepeat(chatID int64, messageID int, data string, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !HasPermission(chatID, models.PermBroadcast, db) {
+		return
+	}
+
+	lang := i18n.For(chatID)
+	draft, ok := scheduleDrafts[chatID]
+	if !ok || draft.RunAt.IsZero() {
+		sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("schedule.expired")))
+		return
+	}
+	delete(scheduleDrafts, chatID)
+
+	repeat := strings.TrimPrefix(data, "schedule_repeat|")
+	if repeat != models.RepeatOnce && repeat != models.RepeatWeekly {
+		sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("schedule.cancelled")))
+		return
+	}
+	draft.Repeat = repeat
+
+	id, err := storage.AddScheduledMessage(db, *draft)
+	if err != nil {
+		log.Printf("Error adding scheduled message: %v", err)
+		sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("schedule.save_error")))
+		return
+	}
+
+	Audit(db, chatID, models.AuditScheduleCreate, strconv.FormatInt(id, 10), map[string]interface{}{
+		"run_at": draft.RunAt,
+		"repeat": repeat,
+	})
+
+	text := lang.T("schedule.saved", id, formatScheduleTime(draft.RunAt))
+	if repeat == models.RepeatWeekly {
+		text += lang.T("schedule.saved_weekly")
+	}
+	sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, text))
+}
+
+// DisplayScheduledMessages rejalashtirilgan xabarlar ro'yxatini bekor qilish tugmalari bilan ko'rsatadi.
+// messageID 0 bo'lmasa, mavjud xabar yangilanadi.
+func DisplayScheduledMessages(chatID int64, messageID int, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !HasPermission(chatID, models.PermBroadcast, db) {
+		return
+	}
+
+	lang := i18n.For(chatID)
+	messages, err := storage.GetUpcomingScheduledMessages(db)
+	if err != nil {
+		log.Printf("Error getting scheduled messages: %v", err)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("schedule.load_error")))
+		return
+	}
+
+	text := lang.T("schedule.empty")
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if len(messages) > 0 {
+		var sb strings.Builder
+		sb.WriteString(lang.T("schedule.title"))
+		for _, m := range messages {
+			sb.WriteString(fmt.Sprintf("\n#%d — %s", m.ID, formatScheduleTime(m.RunAt)))
+			if m.Repeat == models.RepeatWeekly {
+				sb.WriteString(lang.T("schedule.weekly_mark"))
+			}
+
+			button := tgbotapi.NewInlineKeyboardButtonData(
+				lang.T("schedule.cancel_button", m.ID),
+				"schedule_cancel|"+strconv.FormatInt(m.ID, 10),
+			)
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(button))
+		}
+		text = sb.String()
+	}
+
+	if messageID != 0 {
+		editMsg := tgbotapi.NewEditMessageText(chatID, messageID, text)
+		if len(rows) > 0 {
+			inlineKeyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+			editMsg.ReplyMarkup = &inlineKeyboard
+		}
+		sender.Send(botInstance, editMsg)
+		return
+	}
+
+	msgResponse := tgbotapi.NewMessage(chatID, text)
+	if len(rows) > 0 {
+		msgResponse.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	}
+	sender.Send(botInstance, msgResponse)
+}
+
+// CancelScheduledMessage "schedule_cancel|<id>" tugmasini qayta ishlaydi
+func CancelScheduledMessage(chatID int64, messageID int, data string, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !HasPermission(chatID, models.PermBroadcast, db) {
+		return
+	}
+
+	id, err := strconv.ParseInt(strings.TrimPrefix(data, "schedule_cancel|"), 10, 64)
+	if err != nil {
+		log.Printf("Error parsing scheduled message ID: %v", err)
+		return
+	}
+
+	if _, err := storage.CancelScheduledMessage(db, id); err != nil {
+		log.Printf("Error cancelling scheduled message %d: %v", id, err)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, i18n.For(chatID).T("schedule.cancel_error")))
+		return
+	}
+	Audit(db, chatID, models.AuditScheduleCancel, strconv.FormatInt(id, 10), nil)
+
+	DisplayScheduledMessages(chatID, messageID, db, botInstance)
+}
+
+// StartScheduler vaqti kelgan rejalashtirilgan xabarlarni broadcast sifatida ishga tushiradi.
+// Xabarlar bazada saqlangani uchun qayta ishga tushgandan keyin ham o'tkazib yuborilmaydi.
+func StartScheduler(ctx context.Context, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if n, err := storage.ResetStaleScheduledMessages(db); err != nil {
+		log.Printf("Error resetting stale scheduled messages: %v", err)
+	} else if n > 0 {
+		log.Printf("Reset %d scheduled messages left running by the previous process", n)
+	}
+
+	ticker := time.NewTicker(schedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		runDueScheduledMessages(db, botInstance)
+
+		select {
+		case <-ctx.Done():
+			log.Println("Stopping scheduler...")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func runDueScheduledMessages(db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	now := time.Now()
+	messages, err := storage.GetDueScheduledMessages(db, now)
+	if err != nil {
+		log.Printf("Error getting due scheduled messages: %v", err)
+		return
+	}
+
+	for _, m := range messages {
+		var nextRunAt time.Time
+		if m.Repeat == models.RepeatWeekly {
+			nextRunAt = m.RunAt.In(Config.Location())
+			for !nextRunAt.After(now) {
+				nextRunAt = nextRunAt.AddDate(0, 0, 7)
+			}
+		}
+
+		claimed, err := storage.ClaimScheduledMessage(db, m.ID, nextRunAt)
+		if err != nil {
+			log.Printf("Error claiming scheduled message %d: %v", m.ID, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		broadcastID, err := startBroadcast(models.Broadcast{
+			AdminID:         m.AdminID,
+			SourceChatID:    m.SourceChatID,
+			SourceMessageID: m.SourceMessageID,
+			Audience:        models.Audience{Kind: models.AudienceAll},
+		}, db, botInstance)
+		if err != nil {
+			// Xabar qayta urinilmaydi: bir martalik "failed" bo'ladi, haftalik keyingi haftaga o'tadi
+			log.Printf("Error starting scheduled message %d: %v", m.ID, err)
+		}
+
+		if err := storage.MarkScheduledMessageRun(db, m.ID, broadcastID); err != nil {
+			log.Printf("Error updating scheduled message %d: %v", m.ID, err)
+		}
+	}
+}
+
+func parseScheduleTime(text string) (time.Time, error) {
+	loc := Config.Location()
+
+	var err error
+	for _, layout := range scheduleTimeLayouts {
+		var t time.Time
+		if t, err = time.ParseInLocation(layout, text, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+func formatScheduleTime(t time.Time) string {
+	return t.In(Config.Location()).Format(scheduleTimeLayouts[0])
+}
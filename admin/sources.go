@@ -0,0 +1,79 @@
+package admin
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
+	"yuklovchiBot/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const sourcesLimit = 10
+
+// DisplaySources foydalanuvchilar qayerdan kelgani: eng faol taklif qiluvchilar va kampaniya teglari statistikasi
+func DisplaySources(chatID int64, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !HasPermission(chatID, models.PermStats, db) {
+		return
+	}
+
+	lang := i18n.For(chatID)
+	text, err := buildSources(db, botInstance.Self.UserName, lang)
+	if err != nil {
+		log.Printf("Error getting source statistics: %v", err)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("sources.load_error")))
+		return
+	}
+
+	msgResponse := tgbotapi.NewMessage(chatID, text)
+	msgResponse.DisableWebPagePreview = true
+	sender.Send(botInstance, msgResponse)
+}
+
+func buildSources(db *sql.DB, botUserName string, lang i18n.Lang) (string, error) {
+	totals, err := storage.GetSourceStats(db)
+	if err != nil {
+		return "", err
+	}
+	referrers, err := storage.GetTopReferrers(db, sourcesLimit)
+	if err != nil {
+		return "", err
+	}
+	campaigns, err := storage.GetCampaignStats(db, statsPeriodStart(statsPeriodWeek), sourcesLimit)
+	if err != nil {
+		return "", err
+	}
+
+	all := totals.Referred + totals.Campaign + totals.Organic
+
+	var sb strings.Builder
+	sb.WriteString(lang.T("sources.title"))
+	sb.WriteString(lang.T("sources.totals",
+		totals.Referred, percent(totals.Referred, all),
+		totals.Campaign, percent(totals.Campaign, all),
+		totals.Organic, percent(totals.Organic, all)))
+
+	sb.WriteString(lang.T("sources.referrers"))
+	if len(referrers) == 0 {
+		sb.WriteString(lang.T("sources.none"))
+	}
+	for i, r := range referrers {
+		sb.WriteString(fmt.Sprintf("%d. %d — %d / %d\n", i+1, r.UserID, r.Invited, r.Active))
+	}
+
+	sb.WriteString(lang.T("sources.campaigns"))
+	if len(campaigns) == 0 {
+		sb.WriteString(lang.T("sources.none"))
+	}
+	for _, c := range campaigns {
+		sb.WriteString(fmt.Sprintf("%s — %d / %d / %d / %d\n", c.Campaign, c.Users, c.Active, c.Downloaded, c.New))
+	}
+
+	sb.WriteString(lang.T("sources.link", botUserName))
+
+	return sb.String(), nil
+}
@@ -0,0 +1,303 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
+	"yuklovchiBot/pkg/state"
+	"yuklovchiBot/storage"
+	"yuklovchiBot/subscription"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const (
+	channelExpiryInterval = time.Minute
+	channelDateLayout     = "2006-01-02"
+)
+
+// Muddati yoki maqsadi kiritilayotgan kanal (models.Channel.Key())
+var channelSettingDrafts = make(map[int64]string)
+
+// DisplayChannelReports har bir majburiy kanal bo'yicha bot orqali obuna hisobotini ko'rsatadi.
+// messageID 0 bo'lmasa, mavjud xabar yangilanadi.
+func DisplayChannelReports(chatID int64, messageID int, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !HasPermission(chatID, models.PermChannels, db) {
+		return
+	}
+
+	lang := i18n.For(chatID)
+	reports, err := storage.GetChannelReports(db)
+	if err != nil {
+		log.Printf("Error getting channel reports: %v", err)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("sponsor.load_error")))
+		return
+	}
+
+	text := lang.T("sponsor.empty")
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if len(reports) > 0 {
+		var sb strings.Builder
+		sb.WriteString(lang.T("sponsor.title"))
+		for i, r := range reports {
+			sb.WriteString(fmt.Sprintf("\n%d. %s\n%s\n", i+1, r.Name(), channelReportText(r, lang)))
+
+			button := tgbotapi.NewInlineKeyboardButtonData("⚙️ "+truncate(r.Name(), 40), "chan|"+r.Key())
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(button))
+		}
+		text = sb.String()
+	}
+
+	if messageID != 0 {
+		editMsg := tgbotapi.NewEditMessageText(chatID, messageID, text)
+		if len(rows) > 0 {
+			inlineKeyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+			editMsg.ReplyMarkup = &inlineKeyboard
+		}
+		sender.Send(botInstance, editMsg)
+		return
+	}
+
+	msgResponse := tgbotapi.NewMessage(chatID, text)
+	if len(rows) > 0 {
+		msgResponse.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	}
+	sender.Send(botInstance, msgResponse)
+}
+
+// HandleChannelSettings kanal sozlamalari tugmalarini qayta ishlaydi.
+// data: "chan|<key>", "chan_period|<key>", "chan_target|<key>" yoki "chan_list"
+func HandleChannelSettings(chatID int64, messageID int, data string, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !HasPermission(chatID, models.PermChannels, db) {
+		return
+	}
+
+	lang := i18n.For(chatID)
+	action, key, _ := strings.Cut(data, "|")
+	switch action {
+	case "chan_list":
+		DisplayChannelReports(chatID, messageID, db, botInstance)
+	case "chan_period":
+		channelSettingDrafts[chatID] = key
+		state.UserStates[chatID] = "waiting_for_channel_period"
+		sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("sponsor.ask_period")))
+	case "chan_target":
+		channelSettingDrafts[chatID] = key
+		state.UserStates[chatID] = "waiting_for_channel_target"
+		sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("sponsor.ask_target")))
+	default:
+		showChannelSettings(chatID, messageID, key, db, botInstance)
+	}
+}
+
+func showChannelSettings(chatID int64, messageID int, key string, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	lang := i18n.For(chatID)
+	reports, err := storage.GetChannelReports(db)
+	if err != nil {
+		log.Printf("Error getting channel reports: %v", err)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("sponsor.load_error")))
+		return
+	}
+
+	for _, r := range reports {
+		if r.Key() != key {
+			continue
+		}
+
+		inlineKeyboard := tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				tgbotapi.NewInlineKeyboardButtonData(lang.T("sponsor.period_button"), "chan_period|"+key),
+				tgbotapi.NewInlineKeyboardButtonData(lang.T("sponsor.target_button"), "chan_target|"+key),
+			),
+			tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(lang.T("sponsor.back"), "chan_list")),
+		)
+		editMsg := tgbotapi.NewEditMessageText(chatID, messageID, fmt.Sprintf("%s\n%s", r.Name(), channelReportText(r, lang)))
+		editMsg.ReplyMarkup = &inlineKeyboard
+		sender.Send(botInstance, editMsg)
+		return
+	}
+
+	sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("sponsor.not_found")))
+}
+
+// HandleChannelPeriod kiritilgan homiylik muddatini saqlaydi
+func HandleChannelPeriod(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	chatID := msg.Chat.ID
+
+	key, ok := channelSettingDrafts[chatID]
+	delete(channelSettingDrafts, chatID)
+	if !ok || !HasPermission(chatID, models.PermChannels, db) {
+		return
+	}
+	lang := i18n.For(chatID)
+	startsAt, endsAt, err := parseChannelPeriod(msg.Text)
+	if err != nil {
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("sponsor.bad_period")))
+		return
+	}
+
+	if err := storage.SetChannelPeriod(db, key, startsAt, endsAt); err != nil {
+		log.Printf("Error updating channel period: %v", err)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("sponsor.period_error")))
+		return
+	}
+	subscription.Invalidate()
+	Audit(db, chatID, models.AuditChannelUpdate, key, map[string]interface{}{"starts_at": nullableTime(startsAt), "ends_at": nullableTime(endsAt)})
+
+	DisplayChannelReports(chatID, 0, db, botInstance)
+}
+
+// HandleChannelTarget kiritilgan obunachilar maqsadini saqlaydi
+func HandleChannelTarget(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	chatID := msg.Chat.ID
+
+	key, ok := channelSettingDrafts[chatID]
+	delete(channelSettingDrafts, chatID)
+	if !ok || !HasPermission(chatID, models.PermChannels, db) {
+		return
+	}
+	lang := i18n.For(chatID)
+	target, err := strconv.Atoi(strings.TrimSpace(msg.Text))
+	if err != nil || target < 0 {
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("sponsor.bad_target")))
+		return
+	}
+
+	if err := storage.SetChannelTarget(db, key, target); err != nil {
+		log.Printf("Error updating channel target: %v", err)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("sponsor.target_error")))
+		return
+	}
+	subscription.Invalidate()
+	Audit(db, chatID, models.AuditChannelUpdate, key, map[string]interface{}{"target": target})
+
+	DisplayChannelReports(chatID, 0, db, botInstance)
+}
+
+// StartChannelExpiry muddati tugagan yoki maqsadga yetgan homiy kanallarni o'chiradi
+// va yakuniy hisobotni kanallar huquqi bor adminlarga yuboradi
+func StartChannelExpiry(ctx context.Context, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	ticker := time.NewTicker(channelExpiryInterval)
+	defer ticker.Stop()
+
+	for {
+		expireChannels(db, botInstance)
+
+		select {
+		case <-ctx.Done():
+			log.Println("Stopping channel expiry...")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func expireChannels(db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	expired, err := storage.ExpireChannels(db)
+	if err != nil {
+		log.Printf("Error expiring channels: %v", err)
+		return
+	}
+	if len(expired) == 0 {
+		return
+	}
+	subscription.Invalidate()
+
+	adminIDs, err := storage.GetAdmins(db, models.RolesWith(models.PermChannels)...)
+	if err != nil {
+		log.Printf("Error getting admins: %v", err)
+	}
+
+	for _, r := range expired {
+		log.Printf("Homiy kanal o'chirildi: %s", r.Key())
+		Audit(db, models.AuditSystem, models.AuditChannelExpire, r.Key(), map[string]interface{}{
+			"shown":  r.Shown,
+			"passed": r.Passed,
+			"left":   r.Left,
+		})
+
+		for _, adminID := range adminIDs {
+			lang := i18n.For(adminID)
+			sender.Send(botInstance, tgbotapi.NewMessage(adminID, lang.T("sponsor.expired", r.Name(), channelReportText(r, lang))))
+		}
+	}
+}
+
+func channelReportText(r models.ChannelReport, lang i18n.Lang) string {
+	loc := Config.Location()
+
+	var sb strings.Builder
+	period := lang.T("sponsor.unlimited")
+	switch {
+	case !r.StartsAt.IsZero() && !r.EndsAt.IsZero():
+		period = fmt.Sprintf("%s — %s", r.StartsAt.In(loc).Format(channelDateLayout), r.EndsAt.In(loc).Add(-time.Nanosecond).Format(channelDateLayout))
+	case !r.StartsAt.IsZero():
+		period = lang.T("sponsor.from", r.StartsAt.In(loc).Format(channelDateLayout))
+	case !r.EndsAt.IsZero():
+		period = lang.T("sponsor.until", r.EndsAt.In(loc).Add(-time.Nanosecond).Format(channelDateLayout))
+	}
+	sb.WriteString(lang.T("sponsor.period", period))
+	if !r.Active(time.Now()) {
+		sb.WriteString(lang.T("sponsor.inactive"))
+	}
+	sb.WriteString("\n")
+
+	if r.Target > 0 {
+		sb.WriteString(lang.T("sponsor.target", r.Passed, r.Target))
+	}
+
+	conversion := 0.0
+	if r.Shown > 0 {
+		conversion = float64(r.Passed) / float64(r.Shown) * 100
+	}
+	sb.WriteString(lang.T("sponsor.stats", r.Shown, r.Passed, conversion, r.Left))
+	return sb.String()
+}
+
+// parseChannelPeriod "boshlanish tugash" sanalarini o'qiydi. "-" — o'sha chegara yo'q, "0" — ikkalasi ham yo'q.
+// Tugash sanasi kun oxirigacha kiradi.
+func parseChannelPeriod(text string) (time.Time, time.Time, error) {
+	fields := strings.Fields(text)
+	if len(fields) == 1 && fields[0] == "0" {
+		return time.Time{}, time.Time{}, nil
+	}
+	if len(fields) != 2 {
+		return time.Time{}, time.Time{}, fmt.Errorf("expected two dates, got %d", len(fields))
+	}
+
+	loc := Config.Location()
+	var dates [2]time.Time
+	for i, field := range fields {
+		if field == "-" {
+			continue
+		}
+		t, err := time.ParseInLocation(channelDateLayout, field, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		dates[i] = t
+	}
+
+	startsAt, endsAt := dates[0], dates[1]
+	if !endsAt.IsZero() {
+		endsAt = endsAt.AddDate(0, 0, 1)
+		if !startsAt.IsZero() && !endsAt.After(startsAt) {
+			return time.Time{}, time.Time{}, fmt.Errorf("period ends before it starts")
+		}
+	}
+	return startsAt, endsAt, nil
+}
+
+func nullableTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
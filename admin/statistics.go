@@ -0,0 +1,217 @@
+package admin
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
+	"yuklovchiBot/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const (
+	statsPeriodToday = "today"
+	statsPeriodWeek  = "7d"
+	statsPeriodMonth = "30d"
+	statsPeriodAll   = "all"
+)
+
+var statsPeriods = []string{statsPeriodToday, statsPeriodWeek, statsPeriodMonth, statsPeriodAll}
+
+var platformShortNames = map[string]string{
+	models.PlatformInstagram: "IG",
+	models.PlatformTikTok:    "TT",
+	models.PlatformYouTube:   "YT",
+}
+
+// HandleStatisticsPeriod statistika davrini almashtirish tugmasini qayta ishlaydi.
+// data: "stats|<today|7d|30d|all>"
+func HandleStatisticsPeriod(chatID int64, messageID int, data string, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !HasPermission(chatID, models.PermStats, db) {
+		return
+	}
+
+	sendStatistics(chatID, messageID, strings.TrimPrefix(data, "stats|"), db, botInstance)
+}
+
+// sendStatistics tanlangan davr uchun statistikani yuboradi, messageID 0 bo'lmasa xabarni yangilaydi
+func sendStatistics(chatID int64, messageID int, period string, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	lang := i18n.For(chatID)
+	since := statsPeriodStart(period)
+
+	text, err := buildStatistics(period, since, lang, db)
+	if err != nil {
+		log.Printf("Error getting statistics: %v", err)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("stats.load_error")))
+		return
+	}
+
+	keyboard := statsPeriodKeyboard(period, lang)
+	if messageID != 0 {
+		editMsg := tgbotapi.NewEditMessageText(chatID, messageID, text)
+		editMsg.ReplyMarkup = &keyboard
+		sender.Send(botInstance, editMsg)
+		return
+	}
+
+	msgResponse := tgbotapi.NewMessage(chatID, text)
+	msgResponse.ReplyMarkup = keyboard
+	sender.Send(botInstance, msgResponse)
+}
+
+func buildStatistics(period string, since time.Time, lang i18n.Lang, db *sql.DB) (string, error) {
+	users, err := storage.GetUserStats(db, since)
+	if err != nil {
+		return "", err
+	}
+	platforms, err := storage.GetPlatformStats(db, since)
+	if err != nil {
+		return "", err
+	}
+	topErrors, err := storage.GetTopDownloadErrors(db, since, 5)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(lang.T("stats.title", statsPeriodLabel(period, lang)))
+	sb.WriteString(lang.T("stats.users", users.Total, users.New, users.DAU, users.WAU, users.MAU, users.Blocked, users.Deactivated))
+
+	var total models.PlatformStats
+	var durationSum time.Duration
+	var durationCount int
+	sb.WriteString(lang.T("stats.downloads"))
+	for _, p := range platforms {
+		sb.WriteString(fmt.Sprintf("%s: %d (✅ %d, ❌ %d, %s)\n", p.Platform, p.Total, p.Success, p.Failed, percent(p.Success, p.Success+p.Failed)))
+		total.Total += p.Total
+		total.Success += p.Success
+		total.Failed += p.Failed
+		total.Cached += p.Cached
+		total.Bytes += p.Bytes
+		if fresh := p.Success - p.Cached; fresh > 0 {
+			durationSum += p.AvgDuration * time.Duration(fresh)
+			durationCount += fresh
+		}
+	}
+	if durationCount > 0 {
+		total.AvgDuration = durationSum / time.Duration(durationCount)
+	}
+	sb.WriteString(lang.T("stats.downloads_total", total.Total, percent(total.Success, total.Success+total.Failed),
+		total.AvgDuration.Round(100*time.Millisecond), formatBytes(total.Bytes), percent(total.Cached, total.Success)))
+
+	if period == statsPeriodWeek || period == statsPeriodMonth {
+		days, err := storage.GetDailyDownloads(db, since)
+		if err != nil {
+			return "", err
+		}
+		if len(days) > 0 {
+			sb.WriteString(lang.T("stats.daily"))
+			sb.WriteString(formatDailyDownloads(days))
+		}
+	}
+
+	if len(topErrors) > 0 {
+		sb.WriteString(lang.T("stats.top_errors"))
+		for _, e := range topErrors {
+			sb.WriteString(fmt.Sprintf("%s — %d\n", e.Reason, e.Count))
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// formatDailyDownloads har bir kun uchun bitta qator: "2025-03-01: IG 10 · TT 5"
+func formatDailyDownloads(days []models.DailyDownloads) string {
+	var sb strings.Builder
+	var current time.Time
+	var parts []string
+	flush := func() {
+		if len(parts) > 0 {
+			sb.WriteString(fmt.Sprintf("%s: %s\n", current.Format("2006-01-02"), strings.Join(parts, " · ")))
+		}
+		parts = nil
+	}
+
+	for _, d := range days {
+		if !d.Day.Equal(current) {
+			flush()
+			current = d.Day
+		}
+		name := platformShortNames[d.Platform]
+		if name == "" {
+			name = d.Platform
+		}
+		parts = append(parts, fmt.Sprintf("%s %d", name, d.Count))
+	}
+	flush()
+
+	return sb.String()
+}
+
+func statsPeriodKeyboard(active string, lang i18n.Lang) tgbotapi.InlineKeyboardMarkup {
+	var row []tgbotapi.InlineKeyboardButton
+	for _, period := range statsPeriods {
+		text := statsPeriodLabel(period, lang)
+		if period == active {
+			text = "• " + text
+		}
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(text, "stats|"+period))
+	}
+
+	var charts []tgbotapi.InlineKeyboardButton
+	for _, kind := range []string{chartUsers, chartDownloads, chartErrors} {
+		charts = append(charts, tgbotapi.NewInlineKeyboardButtonData("📈 "+chartLabel(kind, lang), "chart_open|"+kind+"|"+chartPeriodFor(active)))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(row, charts)
+}
+
+// statsPeriodStart davr boshlanishi (Toshkent vaqti bilan). "all" uchun nol vaqt qaytadi.
+func statsPeriodStart(period string) time.Time {
+	now := time.Now().In(Config.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch period {
+	case statsPeriodWeek:
+		return today.AddDate(0, 0, -6)
+	case statsPeriodMonth:
+		return today.AddDate(0, 0, -29)
+	case chartPeriodQuarter:
+		return today.AddDate(0, 0, -89)
+	case statsPeriodAll:
+		return time.Time{}
+	}
+	return today
+}
+
+func statsPeriodLabel(period string, lang i18n.Lang) string {
+	switch period {
+	case statsPeriodWeek, statsPeriodMonth, chartPeriodQuarter, statsPeriodAll:
+		return lang.T("period." + period)
+	}
+	return lang.T("period.today")
+}
+
+func percent(part, total int) string {
+	if total == 0 {
+		return "0%"
+	}
+	return fmt.Sprintf("%.1f%%", float64(part)*100/float64(total))
+}
+
+func formatBytes(b int64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := int64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(b)/float64(div), "KMGTPE"[exp])
+}
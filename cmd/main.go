@@ -8,10 +8,16 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // Slim konteynerlarda Asia/Tashkent zonasi uchun
+	"yuklovchiBot/admin"
 	"yuklovchiBot/config"
 	"yuklovchiBot/handle"
+	"yuklovchiBot/pkg/i18n"
 	"yuklovchiBot/pkg/logger"
+	"yuklovchiBot/pkg/sender"
+	"yuklovchiBot/pkg/telegram"
 	"yuklovchiBot/storage"
+	"yuklovchiBot/subscription"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 )
@@ -21,6 +27,21 @@ func main() {
 	log := logger.New(cfg.Environment, "IT-Yuklovchi-Bot")
 	botToken := cfg.BotToken
 
+	// Rollar kiritilgandan keyin adminlarni boshqarish va tiklash faqat owner'ga ruxsat etilgan,
+	// OWNER_ID bo'lmasa bu amallarni hech kim bajara olmaydi
+	if cfg.OwnerID == 0 {
+		log.Error("OWNER_ID is not set: admin management and restore would be unavailable")
+		return
+	}
+
+	if cfg.CacheChatID == 0 {
+		log.Warn("CACHE_CHAT_ID is not set: inline mode is disabled")
+	}
+
+	// Sozlamalar bir marta yuklanib, paketlarga uzatiladi
+	admin.Config = cfg
+	handle.Config = cfg
+
 	db, err := storage.New(context.Background(), cfg, log)
 	if err != nil {
 		log.Error("error while connecting database", logger.Error(err))
@@ -33,12 +54,59 @@ func main() {
 		return
 	}
 
+	// Config'dagi bot egasi har doim owner rolida bo'ladi
+	if err := storage.EnsureOwner(db, cfg.OwnerID); err != nil {
+		log.Error("error while saving bot owner", logger.Error(err))
+	}
+
+	// 403 xatolik olingan foydalanuvchilarni bazada nofaol deb belgilash
+	sender.OnUserInactive = func(userID int64, status string) {
+		if err := storage.SetUserStatus(db, userID, status); err != nil {
+			log.Error("error while updating user status", logger.Error(err))
+		}
+	}
+
+	// Interfeys tili: foydalanuvchi tanlagani, bo'lmasa Telegram language_code
+	i18n.Resolve = func(userID int64) string {
+		language, languageCode, err := storage.GetUserLanguage(db, userID)
+		if err != nil {
+			log.Error("error while getting user language", logger.Error(err))
+		}
+		if language != "" {
+			return language
+		}
+		return languageCode
+	}
+
+	// Obuna tekshiruvi natijalarini keshlash muddati
+	if cfg.SubscriptionCacheTTL > 0 {
+		subscription.TTL = cfg.SubscriptionCacheTTL
+	}
+
+	// Bir daqiqadagi yuklash so'rovlari chegarasi (shaxsiy chat va inline rejim)
+	handle.DownloadRateLimit = cfg.DownloadRateLimit
+
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
+	// Buyruqlar menyusi: har bir til uchun va adminlarga alohida
+	go admin.RegisterCommands(db, botInstance)
+
 	// Start Telegram bot updates
 	go startTelegramBot(ctx, db, botInstance)
 
+	// Yuborilmay qolgan broadcastlarni davom ettirish
+	go admin.StartBroadcastWorker(ctx, db, botInstance)
+
+	// Rejalashtirilgan habarlarni vaqti kelganda yuborish
+	go admin.StartScheduler(ctx, db, botInstance)
+
+	// Muddati tugagan yoki maqsadga yetgan homiy kanallarni o'chirish
+	go admin.StartChannelExpiry(ctx, db, botInstance)
+
+	// Jadval bo'yicha avtomatik backup
+	go handle.StartBackupScheduler(ctx, db, botInstance)
+
 	// Wait for shutdown signal
 	<-ctx.Done()
 	log.Info("Shutdown signal received")
@@ -53,7 +121,7 @@ func startTelegramBot(ctx context.Context, db *sql.DB, botInstance *tgbotapi.Bot
 			log.Println("Stopping Telegram bot...")
 			return
 		default:
-			updates, err := botInstance.GetUpdates(tgbotapi.NewUpdate(offset))
+			updates, err := telegram.GetUpdates(botInstance, offset)
 			if err != nil {
 				log.Printf("Error getting updates: %v", err)
 				time.Sleep(5 * time.Second)
@@ -5,6 +5,7 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/spf13/cast"
 	"os"
+	"time"
 )
 
 type Config struct {
@@ -20,7 +21,26 @@ type Config struct {
 	InstaApi  string
 	TikTokApi string
 
+	// OwnerID bot egasi, uni admin ro'yxatidan o'chirib bo'lmaydi. Majburiy: busiz bot ishga tushmaydi
+	OwnerID int64
+
 	LoggerLevel string
+
+	Timezone string
+
+	BackupDir       string
+	BackupRetention int
+	// BackupSchedule avtomatik backup uchun cron ifodasi (Timezone bo'yicha), "off" bo'lsa o'chiriladi
+	BackupSchedule string
+
+	// SubscriptionCacheTTL majburiy obuna tekshiruvi natijasi shuncha vaqt keshlanadi
+	SubscriptionCacheTTL time.Duration
+
+	// DownloadRateLimit bitta foydalanuvchi bir daqiqada yuborishi mumkin bo'lgan yuklash so'rovlari, 0 bo'lsa cheklanmaydi
+	DownloadRateLimit int
+	// CacheChatID inline rejimda yuklangan fayllar file_id olish uchun shu chatga yuboriladi.
+	// O'rnatilmasa inline rejim ishlamaydi
+	CacheChatID int64
 }
 
 func Load() Config {
@@ -40,12 +60,33 @@ func Load() Config {
 	cfg.BotToken = cast.ToString(getOrReturnDefault("BOT_TOKEN", "your token"))
 	cfg.InstaApi = cast.ToString(getOrReturnDefault("INSTA_API", "https://api.instagram.com"))
 	cfg.TikTokApi = cast.ToString(getOrReturnDefault("TIK_TOK_API", "https://api.tiktok.com"))
+	cfg.OwnerID = cast.ToInt64(getOrReturnDefault("OWNER_ID", 0))
 
 	cfg.LoggerLevel = cast.ToString(getOrReturnDefault("LOGGER_LEVEL", "debug"))
 
+	cfg.Timezone = cast.ToString(getOrReturnDefault("TIMEZONE", "Asia/Tashkent"))
+
+	cfg.BackupDir = cast.ToString(getOrReturnDefault("BACKUP_DIR", "./backups"))
+	cfg.BackupRetention = cast.ToInt(getOrReturnDefault("BACKUP_RETENTION", 7))
+	cfg.BackupSchedule = cast.ToString(getOrReturnDefault("BACKUP_SCHEDULE", "0 3 * * *"))
+
+	cfg.SubscriptionCacheTTL = cast.ToDuration(getOrReturnDefault("SUBSCRIPTION_CACHE_TTL", "10m"))
+
+	cfg.DownloadRateLimit = cast.ToInt(getOrReturnDefault("DOWNLOAD_RATE_LIMIT", 10))
+	cfg.CacheChatID = cast.ToInt64(getOrReturnDefault("CACHE_CHAT_ID", 0))
+
 	return cfg
 }
 
+// Location rejalashtirilgan ishlar uchun vaqt zonasi. Zona topilmasa Toshkent (UTC+5) olinadi.
+func (c Config) Location() *time.Location {
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return time.FixedZone("Asia/Tashkent", 5*60*60)
+	}
+	return loc
+}
+
 func getOrReturnDefault(key string, defaultValue interface{}) interface{} {
 	value := os.Getenv(key)
 	if value != "" {
@@ -2,74 +2,490 @@ package handle
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
 	"database/sql"
 	"fmt"
-	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"io"
 	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
+	"yuklovchiBot/admin"
+	"yuklovchiBot/config"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/cron"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
+	"yuklovchiBot/pkg/state"
 	"yuklovchiBot/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 )
 
-func HandleBackup(db *sql.DB, botInstance *tgbotapi.BotAPI) {
-	// Hozirgi sana
-	currentTime := time.Now().Format("2006-01-02")
-	backupDir := "./backups"
-	backupFile := fmt.Sprintf("%s/backup_%s.sql", backupDir, currentTime)
+const (
+	backupPrefix = "backup_"
+	// pg_dump natijasi
+	sqlBackupExt = ".sql.gz"
+	// pg_dump bo'lmaganda storage.DumpDatabase orqali olinadigan mantiqiy backup
+	logicalBackupExt = ".jsonl.gz"
 
-	// Backup katalogini yaratish
-	if _, err := os.Stat(backupDir); os.IsNotExist(err) {
-		if err := os.MkdirAll(backupDir, os.ModePerm); err != nil {
-			log.Printf("Backup katalogini yaratib bo'lmadi: %v", err)
-			return
-		}
-	}
+	// Telegram botlar uchun hujjat hajmi chegarasi 50 MB, biroz zaxira qoldiramiz
+	backupPartSize = 49 * 1024 * 1024
+)
 
-	// PostgreSQL backupni yaratish
-	cmd := exec.Command("pg_dump", "-U", "godb", "-d", "grscanbot", "-f", backupFile)
-	cmd.Env = append(os.Environ(), "PGPASSWORD=0208") // Parolni muhit o'zgaruvchisi sifatida o'rnatish
+// Tiklash uchun yuklangan, tasdiq kutayotgan dump fayllari
+var restoreFiles = make(map[int64]string)
 
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
+// HandleBackup admin "BackUp olish" tugmasini bosganda ishlaydi
+func HandleBackup(actorID int64, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	runBackup(actorID, db, botInstance, models.BackupManual)
+}
 
-	if err := cmd.Run(); err != nil {
-		log.Printf("Backup yaratishda xatolik: %v, %s", err, stderr.String())
+// StartBackupScheduler config'dagi cron jadvali bo'yicha avtomatik backup oladi
+func StartBackupScheduler(ctx context.Context, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	cfg := Config
+	if cfg.BackupSchedule == "" || cfg.BackupSchedule == "off" {
+		log.Println("Avtomatik backup o'chirilgan")
 		return
 	}
 
-	log.Printf("Backup muvaffaqiyatli yaratildi: %s", backupFile)
+	schedule, err := cron.Parse(cfg.BackupSchedule)
+	if err != nil {
+		log.Printf("Backup jadvalida xatolik: %v", err)
+		return
+	}
+
+	for {
+		next := schedule.Next(time.Now().In(cfg.Location()))
+		if next.IsZero() {
+			log.Printf("Backup jadvali hech qachon ishlamaydi: %s", cfg.BackupSchedule)
+			return
+		}
+		log.Printf("Keyingi avtomatik backup: %s", next.Format("2006-01-02 15:04"))
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			log.Println("Stopping backup scheduler...")
+			return
+		case <-timer.C:
+		}
 
-	// Adminlarning IDlarini olish
-	adminIDs, err := storage.GetAdmins(db)
+		runBackup(models.AuditSystem, db, botInstance, models.BackupScheduled)
+	}
+}
+
+// runBackup backup oladi, adminlarga yuboradi va natijani backups hamda admin_audit jadvallariga yozadi
+func runBackup(actorID int64, db *sql.DB, botInstance *tgbotapi.BotAPI, trigger string) {
+	cfg := Config
+	record := models.Backup{Trigger: trigger}
+
+	// Backup olish huquqi bor adminlarning IDlarini olish
+	adminIDs, err := storage.GetAdmins(db, models.RolesWith(models.PermBackups)...)
 	if err != nil {
 		log.Printf("Adminlarni olishda xatolik: %v", err)
+	}
+
+	started := time.Now()
+	backupFile, err := createBackup(cfg, db)
+	record.Duration = time.Since(started)
+	if err != nil {
+		log.Printf("Backup yaratishda xatolik: %v", err)
+		record.Status = models.BackupFailed
+		record.Error = err.Error()
+		saveBackupRecord(db, record)
+		admin.Audit(db, actorID, models.AuditBackupCreate, "", map[string]interface{}{"trigger": trigger, "status": record.Status})
+
+		for _, chatID := range adminIDs {
+			lang := i18n.For(chatID)
+			sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("backup.failed", lang.T("menu.backups"))))
+		}
 		return
 	}
+	log.Printf("Backup muvaffaqiyatli yaratildi: %s", backupFile)
+
+	record.Status = models.BackupSuccess
+	record.FileName = filepath.Base(backupFile)
+	record.Bytes = fileSize(backupFile)
+
+	if err := rotateBackups(cfg.BackupDir, cfg.BackupRetention); err != nil {
+		log.Printf("Eski backuplarni o'chirishda xatolik: %v", err)
+	}
 
+	var delivered int
 	for _, chatID := range adminIDs {
-		SendBackupToAdmin(chatID, backupFile, botInstance)
+		caption := i18n.For(chatID).T("backup.done", float64(record.Bytes)/(1024*1024), record.Duration.Round(100*time.Millisecond))
+		if SendBackupToAdmin(chatID, backupFile, caption, botInstance) {
+			delivered++
+		}
+	}
+
+	record.Destination = fmt.Sprintf("%s, Telegram: %d/%d admin", cfg.BackupDir, delivered, len(adminIDs))
+	saveBackupRecord(db, record)
+	admin.Audit(db, actorID, models.AuditBackupCreate, record.FileName, map[string]interface{}{
+		"trigger": trigger,
+		"status":  record.Status,
+		"bytes":   record.Bytes,
+	})
+}
+
+func saveBackupRecord(db *sql.DB, b models.Backup) {
+	if _, err := storage.AddBackup(db, b); err != nil {
+		log.Printf("Error saving backup record: %v", err)
 	}
 }
 
-// SendBackupToAdmin sends a backup file to a specific admin
-func SendBackupToAdmin(chatID int64, filePath string, botInstance *tgbotapi.BotAPI) {
-	file, err := os.Open(filePath)
+// createBackup config'dagi ulanish ma'lumotlari bilan pg_dump ishga tushiradi va natijani gzip qilib saqlaydi.
+// pg_dump o'rnatilmagan yoki xatolik bersa, database/sql orqali mantiqiy backup olinadi.
+func createBackup(cfg config.Config, db *sql.DB) (string, error) {
+	if err := os.MkdirAll(cfg.BackupDir, os.ModePerm); err != nil {
+		return "", fmt.Errorf("backup katalogini yaratib bo'lmadi: %w", err)
+	}
+
+	if _, err := exec.LookPath("pg_dump"); err != nil {
+		log.Printf("pg_dump topilmadi, mantiqiy backup olinadi")
+		return createLogicalBackup(cfg, db)
+	}
+
+	backupFile, err := writeBackupFile(cfg, sqlBackupExt, func(w io.Writer) error {
+		cmd := exec.Command("pg_dump",
+			"-h", cfg.PostgresHost,
+			"-p", cfg.PostgresPort,
+			"-U", cfg.PostgresUser,
+			"-d", cfg.PostgresDB,
+			"--no-owner", "--clean", "--if-exists",
+		)
+		cmd.Env = append(os.Environ(), "PGPASSWORD="+cfg.PostgresPassword)
+		cmd.Stdout = w
+
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("pg_dump: %v, %s", err, stderr.String())
+		}
+		return nil
+	})
 	if err != nil {
-		log.Printf("Backup faylni ochib bo'lmadi: %v", err)
-		return
+		log.Printf("pg_dump xatolik berdi, mantiqiy backup olinadi: %v", err)
+		return createLogicalBackup(cfg, db)
 	}
-	defer file.Close()
+	return backupFile, nil
+}
 
-	msg := tgbotapi.NewDocumentUpload(chatID, tgbotapi.FileReader{
-		Name:   filePath,
-		Reader: file,
-		Size:   -1,
+func createLogicalBackup(cfg config.Config, db *sql.DB) (string, error) {
+	return writeBackupFile(cfg, logicalBackupExt, func(w io.Writer) error {
+		count, err := storage.DumpDatabase(db, w)
+		if err == nil {
+			log.Printf("Mantiqiy backup: %d ta qator yozildi", count)
+		}
+		return err
 	})
+}
+
+// writeBackupFile dump funksiyasi yozganini gzip qilib vaqt belgili faylga saqlaydi
+func writeBackupFile(cfg config.Config, ext string, dump func(w io.Writer) error) (string, error) {
+	name := backupPrefix + time.Now().In(cfg.Location()).Format("2006-01-02_15-04-05") + ext
+	backupFile := filepath.Join(cfg.BackupDir, name)
+
+	// Yarim yozilgan fayl rotatsiya va yuborishga tushib qolmasligi uchun avval vaqtinchalik nomga yoziladi
+	tmpFile := backupFile + ".tmp"
+	out, err := os.Create(tmpFile)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmpFile)
+	defer out.Close()
+
+	gz := gzip.NewWriter(out)
+	if err := dump(gz); err != nil {
+		return "", err
+	}
+	if err := gz.Close(); err != nil {
+		return "", err
+	}
+	if err := out.Close(); err != nil {
+		return "", err
+	}
+
+	return backupFile, os.Rename(tmpFile, backupFile)
+}
 
-	if _, err := botInstance.Send(msg); err != nil {
-		log.Printf("Admin (%d) uchun backupni yuborishda xatolik: %v", chatID, err)
-	} else {
-		log.Printf("Admin (%d) uchun backup muvaffaqiyatli yuborildi.", chatID)
+// rotateBackups eng yangi keep ta backupni qoldirib, qolganlarini o'chiradi
+func rotateBackups(dir string, keep int) error {
+	if keep <= 0 {
+		return nil
+	}
+
+	files, err := listBackups(dir)
+	if err != nil {
+		return err
+	}
+
+	for len(files) > keep {
+		if err := os.Remove(files[0]); err != nil {
+			return err
+		}
+		log.Printf("Eski backup o'chirildi: %s", files[0])
+		files = files[1:]
+	}
+	return nil
+}
+
+// listBackups backup fayllarini eskisidan yangisiga qarab qaytaradi (nomida vaqt bor)
+func listBackups(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, backupPrefix+"*.gz"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// SendBackupToAdmin sends a backup file to a specific admin.
+// Telegram chegarasidan katta fayllar qismlarga bo'linib yuboriladi.
+func SendBackupToAdmin(chatID int64, filePath, caption string, botInstance *tgbotapi.BotAPI) bool {
+	parts, err := splitBackup(filePath)
+	if err != nil {
+		log.Printf("Backup faylni bo'lib bo'lmadi: %v", err)
+		return false
+	}
+	if len(parts) > 1 {
+		defer func() {
+			for _, part := range parts {
+				os.Remove(part)
+			}
+		}()
+	}
+
+	for i, part := range parts {
+		msg := tgbotapi.NewDocumentUpload(chatID, part)
+		msg.Caption = caption
+		if len(parts) > 1 {
+			msg.Caption = i18n.For(chatID).T("backup.part", caption, i+1, len(parts), filepath.Base(filePath), filepath.Base(filePath))
+		}
+
+		if _, err := sender.Send(botInstance, msg); err != nil {
+			log.Printf("Admin (%d) uchun backupni yuborishda xatolik: %v", chatID, err)
+			return false
+		}
+	}
+	log.Printf("Admin (%d) uchun backup muvaffaqiyatli yuborildi.", chatID)
+	return true
+}
+
+// splitBackup fayl backupPartSize dan katta bo'lsa uni ".partNN" qismlarga bo'ladi
+func splitBackup(filePath string) ([]string, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() <= backupPartSize {
+		return []string{filePath}, nil
+	}
+
+	in, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+
+	var parts []string
+	for i := 1; ; i++ {
+		partName := fmt.Sprintf("%s.part%02d", filePath, i)
+		out, err := os.Create(partName)
+		if err != nil {
+			return parts, err
+		}
+		parts = append(parts, partName)
+
+		n, err := io.CopyN(out, in, backupPartSize)
+		out.Close()
+		if err == io.EOF {
+			if n == 0 {
+				os.Remove(partName)
+				parts = parts[:len(parts)-1]
+			}
+			return parts, nil
+		}
+		if err != nil {
+			return parts, err
+		}
+	}
+}
+
+// HandleRestoreRequest "BackUp tiklash" bosilganda dump faylini so'raydi
+func HandleRestoreRequest(chatID int64, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !admin.HasPermission(chatID, models.PermRestore, db) {
+		return
+	}
+
+	state.UserStates[chatID] = "waiting_for_restore_file"
+	msgResponse := tgbotapi.NewMessage(chatID, i18n.For(chatID).T("restore.ask_file"))
+	sender.Send(botInstance, msgResponse)
+}
+
+// HandleRestoreFile yuklangan dumpni saqlab, tasdiqlashni so'raydi
+func HandleRestoreFile(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	chatID := msg.Chat.ID
+
+	if !admin.HasPermission(chatID, models.PermRestore, db) {
+		return
+	}
+
+	lang := i18n.For(chatID)
+	ext := ""
+	if msg.Document != nil {
+		ext = restoreFileExt(msg.Document.FileName)
+	}
+	if ext == "" {
+		state.UserStates[chatID] = "waiting_for_restore_file"
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("restore.bad_file")))
+		return
+	}
+
+	fileURL, err := botInstance.GetFileDirectURL(msg.Document.FileID)
+	if err != nil {
+		log.Printf("Backup faylini olishda xatolik: %v", err)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("restore.too_big")))
+		return
+	}
+
+	filePath, err := downloadFile(fileURL, filepath.Join(os.TempDir(), "restore_"), ext)
+	if err != nil {
+		log.Printf("Backup faylini yuklashda xatolik: %v", err)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("restore.download_error")))
+		return
+	}
+
+	if old, ok := restoreFiles[chatID]; ok {
+		os.Remove(old)
+	}
+	restoreFiles[chatID] = filePath
+
+	inlineKeyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("restore.confirm_button"), "restore_confirm"),
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("common.no"), "restore_cancel"),
+		),
+	)
+	msgResponse := tgbotapi.NewMessage(chatID, lang.T("restore.confirm", msg.Document.FileName))
+	msgResponse.ReplyMarkup = inlineKeyboard
+	sender.Send(botInstance, msgResponse)
+}
+
+// HandleRestoreConfirm tasdiqlangan dumpni bazaga tiklaydi. data: "restore_confirm" yoki "restore_cancel"
+func HandleRestoreConfirm(chatID int64, messageID int, data string, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if !admin.HasPermission(chatID, models.PermRestore, db) {
+		return
+	}
+
+	lang := i18n.For(chatID)
+	filePath, ok := restoreFiles[chatID]
+	delete(restoreFiles, chatID)
+	if !ok {
+		sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("restore.no_file")))
+		return
+	}
+
+	if data != "restore_confirm" {
+		os.Remove(filePath)
+		sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("restore.cancelled")))
+		return
+	}
+
+	sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("restore.running")))
+
+	go func() {
+		defer os.Remove(filePath)
+
+		cfg := Config
+		safetyBackup, err := createBackup(cfg, db)
+		if err != nil {
+			log.Printf("Tiklashdan oldingi backupda xatolik: %v", err)
+			sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("restore.safety_failed")))
+			return
+		}
+		log.Printf("Tiklashdan oldingi backup: %s", safetyBackup)
+
+		// Tiklash admin_audit jadvalini ham almashtiradi, shuning uchun yozuv tiklashdan keyin qo'shiladi
+		err = restoreBackup(cfg, db, filePath)
+		payload := map[string]interface{}{"safety_backup": filepath.Base(safetyBackup), "ok": err == nil}
+		admin.Audit(db, chatID, models.AuditBackupRestore, filepath.Base(filePath), payload)
+		if err != nil {
+			log.Printf("Bazani tiklashda xatolik: %v", err)
+			sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("restore.failed", filepath.Base(safetyBackup))))
+			return
+		}
+
+		log.Printf("Baza %s faylidan tiklandi (admin %d)", filePath, chatID)
+		sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("restore.done", filepath.Base(safetyBackup))))
+	}()
+}
+
+// restoreFileExt tiklash mumkin bo'lgan fayl kengaytmasini qaytaradi, boshqa fayllar uchun ""
+func restoreFileExt(fileName string) string {
+	for _, ext := range []string{".sql.gz", ".jsonl.gz", ".sql", ".jsonl"} {
+		if strings.HasSuffix(fileName, ext) {
+			return ext
+		}
+	}
+	return ""
+}
+
+// restoreBackup dumpni bitta tranzaksiyada tiklaydi, xatolik bo'lsa hech narsa o'zgarmaydi.
+// .jsonl arxivlar psql'siz, to'g'ridan-to'g'ri database/sql orqali tiklanadi.
+func restoreBackup(cfg config.Config, db *sql.DB, filePath string) error {
+	in, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	var input io.Reader = in
+	if strings.HasSuffix(filePath, ".gz") {
+		gz, err := gzip.NewReader(in)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		input = gz
+	}
+
+	if strings.HasSuffix(strings.TrimSuffix(filePath, ".gz"), ".jsonl") {
+		count, err := storage.RestoreDatabase(db, input)
+		if err == nil {
+			log.Printf("Mantiqiy backupdan %d ta qator tiklandi", count)
+		}
+		return err
+	}
+
+	if _, err := exec.LookPath("psql"); err != nil {
+		return fmt.Errorf("psql topilmadi, .sql backupni tiklab bo'lmaydi (.jsonl.gz backupdan foydalaning)")
+	}
+
+	cmd := exec.Command("psql",
+		"-h", cfg.PostgresHost,
+		"-p", cfg.PostgresPort,
+		"-U", cfg.PostgresUser,
+		"-d", cfg.PostgresDB,
+		"-v", "ON_ERROR_STOP=1",
+		"--single-transaction",
+		"-q",
+	)
+	cmd.Env = append(os.Environ(), "PGPASSWORD="+cfg.PostgresPassword)
+	cmd.Stdin = input
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%v, %s", err, stderr.String())
 	}
+	return nil
 }
@@ -0,0 +1,87 @@
+package handle
+
+import (
+	"database/sql"
+	"os"
+	"yuklovchiBot/admin"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
+	"yuklovchiBot/pkg/state"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+type commandHandler func(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI)
+
+// commands "/buyruq" ishlovchilari. Menyuda ko'rinadigan ro'yxat admin.RegisterCommands da.
+// /cancel bu yerda emas: u har qanday holatda ishlashi uchun handleMessage boshida tekshiriladi.
+var commands = map[string]commandHandler{
+	"start":    handleStartCommand,
+	"admin":    admin.HandleAdminCommand,
+	"help":     handleHelpCommand,
+	"settings": handleSettingsCommand,
+	"history": func(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+		sendHistory(msg.Chat.ID, 0, 0, db, botInstance)
+	},
+	"favourites": func(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+		sendFavourites(msg.Chat.ID, 0, db, botInstance)
+	},
+	"invite": handleInviteCommand,
+	"lang": func(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+		sendLanguagePicker(msg.Chat.ID, i18n.For(msg.Chat.ID), botInstance)
+	},
+}
+
+// handleCommand buyruqni ishlovchisiga yo'naltiradi, noma'lum buyruq uchun false qaytaradi
+func handleCommand(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) bool {
+	handler, ok := commands[msg.Command()]
+	if !ok {
+		return false
+	}
+	handler(msg, db, botInstance)
+	return true
+}
+
+func handleHelpCommand(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	chatID := msg.Chat.ID
+	sender.Send(botInstance, tgbotapi.NewMessage(chatID, i18n.For(chatID).T("help.text")))
+}
+
+func handleSettingsCommand(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	sendSettings(msg.Chat.ID, 0, db, botInstance)
+}
+
+// handleCancelCommand kutilayotgan har qanday holatni va yarim qolgan qoralamalarni bekor qiladi
+func handleCancelCommand(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	chatID := msg.Chat.ID
+	lang := i18n.For(chatID)
+
+	pending := state.Clear(chatID)
+	if admin.ClearDrafts(chatID) {
+		pending = true
+	}
+	if _, ok := collectionDrafts[chatID]; ok {
+		delete(collectionDrafts, chatID)
+		pending = true
+	}
+	if _, ok := pendingStart[chatID]; ok {
+		delete(pendingStart, chatID)
+		pending = true
+	}
+	if filePath, ok := restoreFiles[chatID]; ok {
+		os.Remove(filePath)
+		delete(restoreFiles, chatID)
+		pending = true
+	}
+
+	text := lang.T("cancel.nothing")
+	if pending {
+		text = lang.T("cancel.done")
+	}
+	msgResponse := tgbotapi.NewMessage(chatID, text)
+	// request_users tugmali klaviaturadan keyin admin menyusini qaytaramiz
+	if keyboard, ok := admin.MenuKeyboard(chatID, db); ok {
+		msgResponse.ReplyMarkup = keyboard
+	}
+	sender.Send(botInstance, msgResponse)
+}
@@ -0,0 +1,165 @@
+package handle
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
+	"yuklovchiBot/storage"
+	"yuklovchiBot/subscription"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// /start payload turlari (Telegram faqat A-Z, a-z, 0-9, _ va - belgilarini, 64 tagacha ruxsat beradi):
+//
+//	ref_<user_id>              — taklif havolasi, kim taklif qilgani users.referred_by ga yoziladi
+//	c_<teg>                    — kampaniya tegi, users.campaign ga yoziladi
+//	m_<download_id>_<imzo>     — ulashilgan fayl, keshdagi file_id darhol yuboriladi
+//	col_<id>                   — ulashilgan to'plam (favourites.go)
+//
+// Taklif va kampaniya faqat foydalanuvchining birinchi /start ida hisobga olinadi.
+const (
+	referralPrefix = "ref_"
+	campaignPrefix = "c_"
+	mediaPrefix    = "m_"
+)
+
+var campaignTagRe = regexp.MustCompile(`^[A-Za-z0-9_-]{1,62}$`)
+
+// Obuna tekshiruvidan o'tmagan foydalanuvchining payload'i, "check_subscription" muvaffaqiyatli bo'lganda bajariladi
+var pendingStart = make(map[int64]string)
+
+// startSource payload'dan taklif qilgan foydalanuvchi va kampaniya tegini ajratadi. O'zini taklif qilish hisobga olinmaydi.
+func startSource(payload string, userID int64) (referredBy int64, campaign string) {
+	switch {
+	case strings.HasPrefix(payload, referralPrefix):
+		id, err := strconv.ParseInt(strings.TrimPrefix(payload, referralPrefix), 10, 64)
+		if err == nil && id != userID {
+			referredBy = id
+		}
+	case strings.HasPrefix(payload, campaignPrefix):
+		if tag := strings.TrimPrefix(payload, campaignPrefix); campaignTagRe.MatchString(tag) {
+			campaign = strings.ToLower(tag)
+		}
+	}
+	return referredBy, campaign
+}
+
+// handleStartPayload fayl yoki to'plam havolasini bajaradi. Payload bunday havola bo'lmasa false qaytaradi,
+// shunda odatdagi salomlashish yuboriladi.
+func handleStartPayload(chatID int64, payload string, db *sql.DB, botInstance *tgbotapi.BotAPI) bool {
+	if !strings.HasPrefix(payload, mediaPrefix) && !strings.HasPrefix(payload, collectionPrefix) {
+		return false
+	}
+
+	if ok, missing := subscription.Check(chatID, db, botInstance); !ok {
+		pendingStart[chatID] = payload
+		sendSubscriptionGate(chatID, missing, db, botInstance)
+		return true
+	}
+	delete(pendingStart, chatID)
+
+	if !requireRateLimit(chatID, botInstance) {
+		return true
+	}
+
+	if strings.HasPrefix(payload, collectionPrefix) {
+		collectionID, _ := strconv.ParseInt(strings.TrimPrefix(payload, collectionPrefix), 10, 64)
+		sendSharedCollection(chatID, collectionID, 0, db, botInstance)
+		return true
+	}
+
+	sendSharedMedia(chatID, strings.TrimPrefix(payload, mediaPrefix), db, botInstance)
+	return true
+}
+
+// sendSharedMedia "<download_id>_<imzo>" havolasidagi faylni yuboradi. Imzo id'ni taxmin qilib
+// boshqalarning yuklashlarini ochishga yo'l qo'ymaydi. Egasi tarixni tozalagach havola ishlamaydi.
+func sendSharedMedia(chatID int64, ref string, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	lang := i18n.For(chatID)
+
+	idPart, sig, _ := strings.Cut(ref, "_")
+	downloadID, err := strconv.ParseInt(idPart, 10, 64)
+	if err != nil || !hmac.Equal([]byte(sig), []byte(mediaSignature(downloadID))) {
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("share.not_found")))
+		return
+	}
+
+	d, err := storage.GetDownload(db, downloadID)
+	if err != nil || d.FileID == "" || d.Hidden {
+		if err != nil && err != sql.ErrNoRows {
+			log.Printf("Error getting download %d: %v", downloadID, err)
+		}
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("share.not_found")))
+		return
+	}
+
+	if err := sendStoredMedia(chatID, d.MediaType, d.FileID, d.Title, botInstance); err != nil {
+		log.Printf("Ulashilgan faylni yuborishda xatolik: %v", err)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("share.not_found")))
+	}
+}
+
+// shareDownload tarixdagi yuklash uchun /start m_ havolasini yuboradi
+func shareDownload(chatID, downloadID int64, db *sql.DB, botInstance *tgbotapi.BotAPI) bool {
+	d, err := storage.GetDownload(db, downloadID)
+	if err != nil {
+		log.Printf("Error getting download %d: %v", downloadID, err)
+		return false
+	}
+	if d.UserID != chatID || d.FileID == "" || d.Hidden {
+		return false
+	}
+
+	sender.Send(botInstance, tgbotapi.NewMessage(chatID, i18n.For(chatID).T("share.link", mediaLink(botInstance, downloadID))))
+	return true
+}
+
+func mediaLink(botInstance *tgbotapi.BotAPI, downloadID int64) string {
+	return fmt.Sprintf("https://t.me/%s?start=%s%d_%s", botInstance.Self.UserName, mediaPrefix, downloadID, mediaSignature(downloadID))
+}
+
+// mediaSignature bot tokeni kaliti bilan olingan HMAC'ning qisqa ko'rinishi
+func mediaSignature(downloadID int64) string {
+	mac := hmac.New(sha256.New, []byte(Config.BotToken))
+	mac.Write([]byte(strconv.FormatInt(downloadID, 10)))
+	return hex.EncodeToString(mac.Sum(nil))[:12]
+}
+
+func inviteLink(botInstance *tgbotapi.BotAPI, userID int64) string {
+	return fmt.Sprintf("https://t.me/%s?start=%s%d", botInstance.Self.UserName, referralPrefix, userID)
+}
+
+// handleInviteCommand foydalanuvchining shaxsiy taklif havolasini va u orqali kelganlar sonini yuboradi
+func handleInviteCommand(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	chatID := msg.Chat.ID
+	lang := i18n.For(chatID)
+
+	invited, err := storage.CountReferrals(db, chatID)
+	if err != nil {
+		log.Printf("Error counting referrals: %v", err)
+	}
+	sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("ref.invite", inviteLink(botInstance, chatID), invited)))
+}
+
+// notifyReferrer taklif qilgan foydalanuvchiga yangi do'sti qo'shilganini xabar qiladi
+func notifyReferrer(referrerID int64, user *tgbotapi.User, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	invited, err := storage.CountReferrals(db, referrerID)
+	if err != nil {
+		log.Printf("Error counting referrals: %v", err)
+		return
+	}
+	// Taklif qilgan ID bazada bo'lmasa referred_by NULL yozilgan, xabar yuborilmaydi
+	if invited == 0 {
+		return
+	}
+	sender.Send(botInstance, tgbotapi.NewMessage(referrerID, i18n.For(referrerID).T("ref.joined", user.FirstName, invited)))
+}
@@ -0,0 +1,428 @@
+package handle
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
+	"yuklovchiBot/pkg/state"
+	"yuklovchiBot/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const (
+	// Keshdan yuborilgan videolar uchun audio tugmalarida fayl yo'li o'rniga "d:<download_id>" ishlatiladi
+	downloadRefPrefix = "d:"
+	// maxUploadSize Bot API orqali yuklash mumkin bo'lgan eng katta fayl
+	maxUploadSize = 50 * 1024 * 1024
+	// defaultYouTubeQuality sifat ro'yxatsiz tanlanadigan joylarda (inline, guruh) sozlama "ask" bo'lsa olinadi
+	defaultYouTubeQuality = "360"
+	// maxConcurrentDownloads bir vaqtda ishlaydigan yuklashlar soni (yt-dlp, Instagram va TikTok)
+	maxConcurrentDownloads = 3
+)
+
+// downloadSlots shaxsiy chat, inline va guruh rejimidagi barcha yuklashlarni maxConcurrentDownloads bilan cheklaydi
+var downloadSlots = make(chan struct{}, maxConcurrentDownloads)
+
+// acquireDownloadSlot bo'sh joy chiqquncha kutadi va joyni bo'shatadigan funksiyani qaytaradi:
+// defer acquireDownloadSlot()()
+func acquireDownloadSlot() func() {
+	downloadSlots <- struct{}{}
+	return func() { <-downloadSlots }
+}
+
+// downloadTracker bitta yuklash so'rovining natijasini downloads jadvaliga yozadi
+type downloadTracker struct {
+	db      *sql.DB
+	id      int64
+	started time.Time
+}
+
+func startDownload(db *sql.DB, userID int64, platform, sourceURL string) *downloadTracker {
+	id, err := storage.AddDownload(db, userID, platform, sourceURL)
+	if err != nil {
+		log.Printf("Error saving download: %v", err)
+	}
+	return &downloadTracker{db: db, id: id, started: time.Now()}
+}
+
+// success yuborilgan xabardan file_id'ni olib, natijani saqlaydi
+func (t *downloadTracker) success(sent tgbotapi.Message, title string, bytes int64, cached bool) {
+	mediaType, fileID := sentMedia(sent)
+	t.finish(mediaType, fileID, title, bytes, cached)
+}
+
+// finish muvaffaqiyatli natijani file_id bo'yicha yozadi (inline rejimda foydalanuvchiga xabar yuborilmaydi)
+func (t *downloadTracker) finish(mediaType, fileID, title string, bytes int64, cached bool) {
+	if t.id == 0 {
+		return
+	}
+
+	d := models.Download{Status: models.DownloadSuccess, Title: title, MediaType: mediaType, FileID: fileID}
+	if err := storage.FinishDownload(t.db, t.id, d, time.Since(t.started), bytes, cached, ""); err != nil {
+		log.Printf("Error updating download %d: %v", t.id, err)
+	}
+}
+
+// fail xatolik sababini qisqa kod ko'rinishida saqlaydi (statistikada guruhlash uchun)
+func (t *downloadTracker) fail(reason string) {
+	if t.id == 0 {
+		return
+	}
+
+	d := models.Download{Status: models.DownloadFailed}
+	if err := storage.FinishDownload(t.db, t.id, d, time.Since(t.started), 0, false, reason); err != nil {
+		log.Printf("Error updating download %d: %v", t.id, err)
+	}
+}
+
+func sentMedia(msg tgbotapi.Message) (string, string) {
+	switch {
+	case msg.Video != nil:
+		return models.MediaVideo, msg.Video.FileID
+	case msg.Audio != nil:
+		return models.MediaAudio, msg.Audio.FileID
+	case msg.Document != nil:
+		return models.MediaDocument, msg.Document.FileID
+	}
+	return "", ""
+}
+
+// cacheSentMedia yuborilgan faylning file_id'sini keyingi so'rovlar uchun saqlaydi
+func cacheSentMedia(db *sql.DB, key, platform string, sent tgbotapi.Message, title string, bytes int64) {
+	mediaType, fileID := sentMedia(sent)
+	if fileID == "" {
+		return
+	}
+
+	entry := models.MediaCache{Key: key, Platform: platform, MediaType: mediaType, FileID: fileID, Title: title, Bytes: bytes}
+	if err := storage.SaveMediaCache(db, entry); err != nil {
+		log.Printf("Error saving media cache: %v", err)
+	}
+}
+
+// sendCachedVideo havola avval yuklangan bo'lsa, videoni qayta yuklamasdan file_id orqali yuboradi.
+// key mediaCacheKey bilan olinadi, audioPlatform audio tugmalari uchun ("insta", "tiktok").
+func sendCachedVideo(chatID int64, key, audioPlatform string, settings models.UserSettings, tracker *downloadTracker, db *sql.DB, botInstance *tgbotapi.BotAPI) bool {
+	entry, ok, err := storage.GetMediaCache(db, key)
+	if err != nil {
+		log.Printf("Error getting media cache: %v", err)
+	}
+	want := models.MediaVideo
+	if settings.AsDocument {
+		want = models.MediaDocument
+	}
+	if !ok || entry.MediaType != want {
+		return false
+	}
+
+	sentMsg, err := deliverVideo(chatID, "", entry.FileID, audioPlatform, settings, tracker, db, botInstance)
+	if err != nil {
+		// file_id yaroqsiz bo'lib qolgan bo'lishi mumkin, videoni qaytadan yuklaymiz
+		log.Printf("Keshdagi videoni yuborishda xatolik: %v", err)
+		storage.DeleteMediaCache(db, key)
+		return false
+	}
+
+	tracker.success(sentMsg, entry.Title, entry.Bytes, true)
+	return true
+}
+
+// deliverVideo videoni foydalanuvchi sozlamalari bo'yicha yuboradi (video yoki fayl, izoh bilan yoki
+// izohsiz) va audio sozlamasini qo'llaydi. file — yangi yuklangan lokal fayl, fileID — keshdagi fayl.
+func deliverVideo(chatID int64, file, fileID, audioPlatform string, settings models.UserSettings, tracker *downloadTracker, db *sql.DB, botInstance *tgbotapi.BotAPI) (tgbotapi.Message, error) {
+	lang := i18n.For(chatID)
+
+	// Keshdan yuborilgan videoning lokal fayli yo'q, audio kerak bo'lsa u download ID orqali olinadi
+	ref := file
+	if fileID != "" {
+		ref = downloadRefPrefix + strconv.FormatInt(tracker.id, 10)
+	}
+
+	caption := ""
+	if settings.Caption {
+		caption = lang.T("download.caption")
+	}
+	var markup interface{}
+	if settings.AudioMode == models.AudioAsk {
+		caption = lang.T("download.audio_question")
+		if settings.Caption {
+			caption = lang.T("download.audio_prompt")
+		}
+		markup = createAudioOptionKeyboard(lang, audioPlatform, ref)
+	}
+
+	sentMsg, err := sender.Send(botInstance, videoMessage(chatID, file, fileID, settings.AsDocument, caption, markup))
+	if err != nil {
+		return sentMsg, err
+	}
+
+	switch settings.AudioMode {
+	case models.AudioAsk:
+		// Xabar ID'sini saqlaymiz (keyinchalik tugmalarni o‘chirish uchun)
+		state.SaveMessageID(chatID, sentMsg.MessageID)
+	case models.AudioAlways:
+		// Keshdagi video uchun yuklash yozuviga file_id hali yozilmagan, shuning uchun "d:<id>" emas,
+		// file_id'ning o'zidan foydalanamiz
+		if fileID == "" {
+			sendVideoAudio(chatID, audioPlatform, file, db, botInstance)
+		} else if videoFile, err := fetchStoredVideo(fileID, botInstance); err != nil {
+			log.Printf("Video faylni olishda xatolik: %v", err)
+			sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("download.audio_error")))
+		} else {
+			sendVideoAudio(chatID, audioPlatform, videoFile, db, botInstance)
+		}
+	default:
+		removeVideoFile(ref)
+	}
+	return sentMsg, nil
+}
+
+// videoMessage lokal faylni (file) yoki keshdagi file_id ni video yoki hujjat sifatida yuborish xabari
+func videoMessage(chatID int64, file, fileID string, asDocument bool, caption string, markup interface{}) tgbotapi.Chattable {
+	if asDocument {
+		msg := tgbotapi.NewDocumentUpload(chatID, file)
+		if fileID != "" {
+			msg = tgbotapi.NewDocumentShare(chatID, fileID)
+		}
+		msg.Caption = caption
+		msg.ReplyMarkup = markup
+		return msg
+	}
+
+	msg := tgbotapi.NewVideoUpload(chatID, file)
+	if fileID != "" {
+		msg = tgbotapi.NewVideoShare(chatID, fileID)
+	}
+	msg.Caption = caption
+	msg.ReplyMarkup = markup
+	return msg
+}
+
+// sendStoredMedia Telegram serveridagi faylni turi bo'yicha (audio, fayl yoki video) qayta yuboradi
+func sendStoredMedia(chatID int64, mediaType, fileID, caption string, botInstance *tgbotapi.BotAPI) error {
+	var media tgbotapi.Chattable
+	if mediaType == models.MediaAudio {
+		audioMsg := tgbotapi.NewAudioShare(chatID, fileID)
+		audioMsg.Caption = caption
+		media = audioMsg
+	} else {
+		media = videoMessage(chatID, "", fileID, mediaType == models.MediaDocument, caption, nil)
+	}
+
+	_, err := sender.Send(botInstance, media)
+	return err
+}
+
+// sendVideoAudio videodan audioni ajratib yuboradi va vaqtinchalik video faylni o'chiradi.
+// ref — lokal fayl yo'li yoki "d:<download_id>".
+func sendVideoAudio(chatID int64, audioPlatform, ref string, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	videoFile, err := resolveVideoFile(chatID, ref, db, botInstance)
+	if err != nil {
+		log.Printf("Video faylni olishda xatolik: %v", err)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, i18n.For(chatID).T("download.audio_error")))
+		return
+	}
+
+	if audioPlatform == "tiktok" {
+		downloadAndSendTikTokAudio(chatID, videoFile, botInstance)
+	} else {
+		downloadAndSendInstaAudio(chatID, videoFile, botInstance)
+	}
+	removeVideoFile(videoFile)
+}
+
+// userSettings foydalanuvchi yuklash sozlamalari, o'qib bo'lmasa standart qiymatlar
+func userSettings(chatID int64, db *sql.DB) models.UserSettings {
+	settings, err := storage.GetUserSettings(db, chatID)
+	if err != nil {
+		log.Printf("Error getting user settings: %v", err)
+		return models.DefaultUserSettings()
+	}
+	return settings
+}
+
+// mediaCacheKey fayl sifatida yuborilgan videolar alohida keshlanadi: keshdagi file_id
+// qaysi turda yuborilgan bo'lsa, shu turda qayta yuboriladi
+func mediaCacheKey(key string, settings models.UserSettings) string {
+	if settings.AsDocument {
+		return key + "|doc"
+	}
+	return key
+}
+
+// mediaUpload yangi yuklangan lokal faylni (file) yuborish xabarini quradi. mediaType — models.MediaVideo
+// yoki models.MediaAudio.
+type mediaUpload func(file, mediaType string) tgbotapi.Chattable
+
+// resolveMedia havola uchun Telegram serveridagi faylni qaytaradi (inline va guruh rejimi). Fayl keshda
+// bo'lmasa yuklanib, upload qurgan xabar bilan yuboriladi va file_id keshlanadi; bu holda uploaded true
+// bo'ladi, ya'ni fayl manzilga yetkazilgan. Kesh kalitlari shaxsiy chatdagi bilan bir xil, shuning uchun
+// bot orqali avval yuklangan videolar qayta yuklanmaydi.
+func resolveMedia(userID int64, link string, settings models.UserSettings, upload mediaUpload, db *sql.DB, botInstance *tgbotapi.BotAPI) (entry models.MediaCache, uploaded bool, err error) {
+	platform := linkPlatform(link)
+	tracker := startDownload(db, userID, platform, link)
+
+	key, title, mediaType, formatID := link, "", models.MediaVideo, ""
+	switch platform {
+	case models.PlatformTikTok:
+		if !settings.NoWatermark {
+			key += "|wm"
+		}
+	case models.PlatformYouTube:
+		meta, err := fetchYouTubeMetadata(link)
+		if err != nil {
+			tracker.fail("metadata")
+			return models.MediaCache{}, false, err
+		}
+
+		quality := settings.YouTubeQuality
+		if quality == models.QualityAsk {
+			quality = defaultYouTubeQuality
+		}
+		largestByRes, bestAudio := filterLargestFormats(meta)
+		if formatID = preferredYouTubeFormat(largestByRes, bestAudio, quality); formatID == "" {
+			tracker.fail("format")
+			return models.MediaCache{}, false, errors.New("mos format topilmadi")
+		}
+
+		key += "|" + formatID
+		title = meta.Title
+		if isAudioFormat(meta, formatID) {
+			mediaType = models.MediaAudio
+		}
+	}
+
+	// Havola avval yuklangan bo'lsa, tayyor file_id'ni qaytaramiz
+	entry, ok, err := storage.GetMediaCache(db, key)
+	if err != nil {
+		log.Printf("Error getting media cache: %v", err)
+	}
+	if ok && entry.MediaType == mediaType {
+		tracker.finish(entry.MediaType, entry.FileID, entry.Title, entry.Bytes, true)
+		return entry, false, nil
+	}
+
+	var file string
+	switch platform {
+	case models.PlatformInstagram:
+		var fetchErr *fetchError
+		if file, fetchErr = fetchInstaVideo(link); fetchErr != nil {
+			tracker.fail(fetchErr.reason)
+			return models.MediaCache{}, false, fetchErr
+		}
+	case models.PlatformTikTok:
+		if file, err = downloadTikTokVideo(link, settings.NoWatermark); err != nil {
+			tracker.fail("download")
+			return models.MediaCache{}, false, err
+		}
+	case models.PlatformYouTube:
+		if file, err = downloadSpecificFormat(link, formatID); err != nil {
+			tracker.fail("format_download")
+			return models.MediaCache{}, false, err
+		}
+	}
+	defer removeVideoFile(file)
+
+	size := fileSize(file)
+	if size > maxUploadSize {
+		tracker.fail("too_large")
+		return models.MediaCache{}, false, fmt.Errorf("fayl hajmi %d bayt", size)
+	}
+
+	sentMsg, err := sender.Send(botInstance, upload(file, mediaType))
+	if err != nil {
+		tracker.fail("send")
+		return models.MediaCache{}, false, err
+	}
+
+	tracker.success(sentMsg, title, size, false)
+	cacheSentMedia(db, key, platform, sentMsg, title, size)
+
+	entry = models.MediaCache{Key: key, Platform: platform, Title: title, Bytes: size}
+	entry.MediaType, entry.FileID = sentMedia(sentMsg)
+	return entry, true, nil
+}
+
+// resolveVideoFile audio ajratish uchun lokal video faylini qaytaradi. Keshdan yuborilgan
+// videolar ("d:<download_id>") Telegram serveridan vaqtinchalik faylga yuklab olinadi. ref callback
+// ma'lumotidan keladi, shuning uchun faqat chatID ning o'z yuklashlari va bot yaratgan vaqtinchalik
+// fayllar qabul qilinadi.
+func resolveVideoFile(chatID int64, ref string, db *sql.DB, botInstance *tgbotapi.BotAPI) (string, error) {
+	if !strings.HasPrefix(ref, downloadRefPrefix) {
+		if !isTempVideo(ref) {
+			return "", fmt.Errorf("not a temporary video: %s", ref)
+		}
+		return ref, nil
+	}
+
+	id, err := strconv.ParseInt(strings.TrimPrefix(ref, downloadRefPrefix), 10, 64)
+	if err != nil {
+		return "", err
+	}
+	d, err := storage.GetDownload(db, id)
+	if err != nil {
+		return "", err
+	}
+	if d.UserID != chatID || d.Hidden {
+		return "", fmt.Errorf("download %d is not available to %d", id, chatID)
+	}
+	if d.FileID == "" {
+		return "", fmt.Errorf("download %d has no file", id)
+	}
+	return fetchStoredVideo(d.FileID, botInstance)
+}
+
+// isTempVideo yo'l Instagram, TikTok yoki keshdan yuklangan vaqtinchalik video ekanini tekshiradi
+func isTempVideo(path string) bool {
+	dir, name := filepath.Split(path)
+	if filepath.Ext(name) != ".mp4" {
+		return false
+	}
+	switch {
+	case dir == "":
+		return strings.HasPrefix(name, "temp_insta_") || strings.HasPrefix(name, "temp_cached_")
+	case filepath.Clean(dir) == filepath.Clean(os.TempDir()):
+		return strings.HasPrefix(name, "tiktok_")
+	}
+	return false
+}
+
+// fetchStoredVideo Telegram serveridagi videoni vaqtinchalik faylga yuklab oladi
+func fetchStoredVideo(fileID string, botInstance *tgbotapi.BotAPI) (string, error) {
+	fileURL, err := botInstance.GetFileDirectURL(fileID)
+	if err != nil {
+		return "", err
+	}
+	return downloadFile(fileURL, "temp_cached_", ".mp4")
+}
+
+// removeVideoFile vaqtinchalik video faylni o'chiradi ("d:<id>" havolalar e'tiborsiz qoldiriladi)
+func removeVideoFile(videoFile string) {
+	if strings.HasPrefix(videoFile, downloadRefPrefix) {
+		return
+	}
+
+	if err := os.Remove(videoFile); err != nil {
+		log.Printf("Xatolik: Video faylni o‘chirishda xatolik: %v", err)
+	} else {
+		log.Printf("Fayl o‘chirildi: %s", videoFile)
+	}
+}
+
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
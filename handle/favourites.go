@@ -0,0 +1,409 @@
+package handle
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
+	"yuklovchiBot/pkg/state"
+	"yuklovchiBot/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const (
+	favouritesPageSize = 5
+	// maxCollections bitta foydalanuvchi to'plamlari (har biri menyuda alohida tugma)
+	maxCollections = 20
+	// sharedCollectionPageSize havola orqali ochilgan to'plamdan bir marta shuncha fayl yuboriladi,
+	// qolganlari "Yana" tugmasi bilan olinadi
+	sharedCollectionPageSize = 10
+	// collectionPrefix to'plam havolasidagi /start parametri: "col_<id>"
+	collectionPrefix = "col_"
+)
+
+// Yangi to'plam nomi kiritilayotganda unga o'tkaziladigan sevimli fayl ID'si (0 — faqat yaratish)
+var collectionDrafts = make(map[int64]int64)
+
+// sendFavourites sevimlilar bosh menyusi: barcha sevimlilar, to'plamlar va yangi to'plam tugmasi.
+// messageID 0 bo'lmasa xabar yangilanadi.
+func sendFavourites(chatID int64, messageID int, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	lang := i18n.For(chatID)
+
+	total, err := storage.CountFavourites(db, chatID)
+	if err != nil {
+		log.Printf("Error counting favourites: %v", err)
+	}
+	collections, err := storage.GetCollections(db, chatID)
+	if err != nil {
+		log.Printf("Error getting collections: %v", err)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("fav.error")))
+		return
+	}
+
+	text := lang.T("fav.title", total)
+	if total == 0 {
+		text = lang.T("fav.empty")
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if total > 0 {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("fav.all", total), "fav|0|0")))
+	}
+	for _, c := range collections {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("📁 %s (%d)", c.Name, c.Items), fmt.Sprintf("fav|%d|0", c.ID))))
+	}
+	if len(collections) < maxCollections {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("fav.new_collection"), "fav_new|0")))
+	}
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+
+	if messageID != 0 {
+		editMsg := tgbotapi.NewEditMessageText(chatID, messageID, text)
+		editMsg.ReplyMarkup = &keyboard
+		sender.Send(botInstance, editMsg)
+		return
+	}
+
+	msgResponse := tgbotapi.NewMessage(chatID, text)
+	msgResponse.ReplyMarkup = keyboard
+	sender.Send(botInstance, msgResponse)
+}
+
+// sendCollection to'plamdagi (collectionID 0 — barcha) sevimlilarni sahifalab ko'rsatadi
+func sendCollection(chatID, collectionID int64, messageID, page int, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	lang := i18n.For(chatID)
+
+	name := lang.T("fav.all_name")
+	if collectionID != 0 {
+		c, err := storage.GetCollection(db, collectionID)
+		if err != nil || c.UserID != chatID {
+			log.Printf("Error getting collection %d: %v", collectionID, err)
+			sendFavourites(chatID, messageID, db, botInstance)
+			return
+		}
+		name = c.Name
+	}
+
+	// Keyingi sahifa borligini bilish uchun bitta ortiq yozuv olinadi
+	favourites, err := storage.GetFavourites(db, chatID, collectionID, favouritesPageSize+1, page*favouritesPageSize)
+	if err != nil {
+		log.Printf("Error getting favourites: %v", err)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("fav.error")))
+		return
+	}
+	hasNext := len(favourites) > favouritesPageSize
+	if hasNext {
+		favourites = favourites[:favouritesPageSize]
+	}
+
+	var sb strings.Builder
+	sb.WriteString(lang.T("fav.collection_title", name, page+1))
+	sb.WriteString("\n\n")
+	if len(favourites) == 0 {
+		sb.WriteString(lang.T("fav.collection_empty"))
+	}
+	for i, f := range favourites {
+		title := f.Title
+		if title == "" {
+			title = f.SourceURL
+		}
+		sb.WriteString(fmt.Sprintf("%d. %s\n%s\n\n", i+1, platformLabel(f.Platform), truncateText(title, 80)))
+	}
+
+	keyboard := collectionKeyboard(lang, favourites, collectionID, page, hasNext)
+	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, sb.String())
+	editMsg.ReplyMarkup = &keyboard
+	sender.Send(botInstance, editMsg)
+}
+
+// collectionKeyboard har bir fayl uchun qayta yuborish, to'plamga o'tkazish va o'chirish tugmalari
+func collectionKeyboard(lang i18n.Lang, favourites []models.Favourite, collectionID int64, page int, hasNext bool) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+
+	var resend, move, remove []tgbotapi.InlineKeyboardButton
+	for i, f := range favourites {
+		n := i + 1
+		resend = append(resend, tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("🔁 %d", n), fmt.Sprintf("fav_send|%d", f.ID)))
+		move = append(move, tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("📁 %d", n), fmt.Sprintf("fav_move|%d", f.ID)))
+		remove = append(remove, tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("✖️ %d", n), fmt.Sprintf("fav_del|%d|%d|%d", f.ID, collectionID, page)))
+	}
+	if len(favourites) > 0 {
+		rows = append(rows, resend, move, remove)
+	}
+
+	var nav []tgbotapi.InlineKeyboardButton
+	if page > 0 {
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(lang.T("history.prev"), fmt.Sprintf("fav|%d|%d", collectionID, page-1)))
+	}
+	if hasNext {
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(lang.T("history.next"), fmt.Sprintf("fav|%d|%d", collectionID, page+1)))
+	}
+	if len(nav) > 0 {
+		rows = append(rows, nav)
+	}
+
+	if collectionID != 0 {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("fav.share"), fmt.Sprintf("fav_share|%d", collectionID)),
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("fav.delete_collection"), fmt.Sprintf("fav_coldel|%d", collectionID)),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(lang.T("fav.back"), "fav_home")))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// handleFavouriteCallback sevimlilar tugmalari:
+// "fav_add|<download_id>", "fav_home", "fav|<to'plam>|<sahifa>", "fav_send|<id>", "fav_move|<id>",
+// "fav_to|<id>|<to'plam>", "fav_del|<id>|<to'plam>|<sahifa>", "fav_new|<id>", "fav_share|<to'plam>",
+// "fav_coldel|<to'plam>", "fav_coldel_ok|<to'plam>", "fav_more|<to'plam>|<sahifa>"
+func handleFavouriteCallback(callbackQuery *tgbotapi.CallbackQuery, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	chatID := callbackQuery.Message.Chat.ID
+	messageID := callbackQuery.Message.MessageID
+	lang := i18n.For(chatID)
+
+	parts := strings.Split(callbackQuery.Data, "|")
+	arg := func(i int) int64 {
+		if i >= len(parts) {
+			return 0
+		}
+		n, _ := strconv.ParseInt(parts[i], 10, 64)
+		return n
+	}
+
+	answer := ""
+	switch parts[0] {
+	case "fav_add":
+		answer = addFavourite(chatID, arg(1), db)
+
+	case "fav_home":
+		sendFavourites(chatID, messageID, db, botInstance)
+
+	case "fav":
+		sendCollection(chatID, arg(1), messageID, int(arg(2)), db, botInstance)
+
+	case "fav_send":
+		f, err := storage.GetFavourite(db, arg(1))
+		if err != nil || f.UserID != chatID {
+			answer = lang.T("history.not_found")
+			break
+		}
+		if err := sendStoredMedia(chatID, f.MediaType, f.FileID, f.Title, botInstance); err != nil {
+			log.Printf("Sevimli faylni yuborishda xatolik: %v", err)
+			answer = lang.T("history.not_found")
+		}
+
+	case "fav_move":
+		askFavouriteCollection(chatID, messageID, arg(1), db, botInstance)
+
+	case "fav_to":
+		if err := storage.MoveFavourite(db, chatID, arg(1), arg(2)); err != nil {
+			log.Printf("Error moving favourite: %v", err)
+		}
+		answer = lang.T("fav.moved")
+		sendFavourites(chatID, messageID, db, botInstance)
+
+	case "fav_del":
+		if err := storage.DeleteFavourite(db, chatID, arg(1)); err != nil {
+			log.Printf("Error deleting favourite: %v", err)
+		}
+		sendCollection(chatID, arg(2), messageID, int(arg(3)), db, botInstance)
+
+	case "fav_new":
+		collectionDrafts[chatID] = arg(1)
+		state.UserStates[chatID] = "waiting_for_collection_name"
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("fav.name_prompt")))
+
+	case "fav_share":
+		shareCollection(chatID, arg(1), db, botInstance)
+
+	case "fav_coldel":
+		keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("fav.delete_yes"), fmt.Sprintf("fav_coldel_ok|%d", arg(1))),
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("history.clear_no"), fmt.Sprintf("fav|%d|0", arg(1))),
+		))
+		editMsg := tgbotapi.NewEditMessageText(chatID, messageID, lang.T("fav.delete_confirm"))
+		editMsg.ReplyMarkup = &keyboard
+		sender.Send(botInstance, editMsg)
+
+	case "fav_coldel_ok":
+		if err := storage.DeleteCollection(db, chatID, arg(1)); err != nil {
+			log.Printf("Error deleting collection: %v", err)
+		}
+		sendFavourites(chatID, messageID, db, botInstance)
+
+	case "fav_more":
+		if !requireRateLimit(chatID, botInstance) {
+			break
+		}
+		sender.Send(botInstance, tgbotapi.NewDeleteMessage(chatID, messageID))
+		sendSharedCollection(chatID, arg(1), int(arg(2)), db, botInstance)
+
+	default:
+		log.Printf("Unknown favourites callback: %s", callbackQuery.Data)
+	}
+
+	botInstance.AnswerCallbackQuery(tgbotapi.NewCallback(callbackQuery.ID, answer))
+}
+
+// addFavourite tarixdagi yuklashni sevimlilarga qo'shadi va foydalanuvchiga ko'rsatiladigan javobni qaytaradi
+func addFavourite(chatID, downloadID int64, db *sql.DB) string {
+	lang := i18n.For(chatID)
+
+	d, err := storage.GetDownload(db, downloadID)
+	if err != nil || d.UserID != chatID || d.FileID == "" || d.Hidden {
+		return lang.T("history.not_found")
+	}
+
+	added, err := storage.AddFavourite(db, chatID, d)
+	if err != nil {
+		log.Printf("Error adding favourite: %v", err)
+		return lang.T("fav.error")
+	}
+	if !added {
+		return lang.T("fav.already")
+	}
+	return lang.T("fav.added")
+}
+
+// askFavouriteCollection faylni qaysi to'plamga o'tkazishni so'raydi
+func askFavouriteCollection(chatID int64, messageID int, favouriteID int64, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	lang := i18n.For(chatID)
+
+	collections, err := storage.GetCollections(db, chatID)
+	if err != nil {
+		log.Printf("Error getting collections: %v", err)
+		return
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, c := range collections {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("📁 "+c.Name, fmt.Sprintf("fav_to|%d|%d", favouriteID, c.ID))))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData(lang.T("fav.no_collection"), fmt.Sprintf("fav_to|%d|0", favouriteID))))
+	if len(collections) < maxCollections {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("fav.new_collection"), fmt.Sprintf("fav_new|%d", favouriteID))))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(lang.T("fav.back"), "fav_home")))
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(rows...)
+	editMsg := tgbotapi.NewEditMessageText(chatID, messageID, lang.T("fav.choose_collection"))
+	editMsg.ReplyMarkup = &keyboard
+	sender.Send(botInstance, editMsg)
+}
+
+// handleCollectionName yangi to'plam nomini qabul qiladi. To'plam fayl tanlash oynasidan yaratilgan
+// bo'lsa, fayl darhol unga o'tkaziladi.
+func handleCollectionName(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	chatID := msg.Chat.ID
+	lang := i18n.For(chatID)
+
+	favouriteID := collectionDrafts[chatID]
+	delete(collectionDrafts, chatID)
+
+	name := strings.TrimSpace(msg.Text)
+	if name == "" || utf8.RuneCountInString(name) > 64 {
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("fav.name_invalid")))
+		return
+	}
+
+	collections, err := storage.GetCollections(db, chatID)
+	if err != nil {
+		log.Printf("Error getting collections: %v", err)
+		return
+	}
+	if len(collections) >= maxCollections {
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("fav.limit", maxCollections)))
+		return
+	}
+
+	collectionID, err := storage.AddCollection(db, chatID, name)
+	if err != nil {
+		log.Printf("Error adding collection: %v", err)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("fav.error")))
+		return
+	}
+	if favouriteID != 0 {
+		if err := storage.MoveFavourite(db, chatID, favouriteID, collectionID); err != nil {
+			log.Printf("Error moving favourite: %v", err)
+		}
+	}
+
+	sendFavourites(chatID, 0, db, botInstance)
+}
+
+// shareCollection to'plamni ulashilgan deb belgilaydi va /start col_<id> havolasini yuboradi
+func shareCollection(chatID, collectionID int64, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	lang := i18n.For(chatID)
+
+	c, err := storage.GetCollection(db, collectionID)
+	if err != nil || c.UserID != chatID {
+		log.Printf("Error getting collection %d: %v", collectionID, err)
+		return
+	}
+	if err := storage.ShareCollection(db, chatID, collectionID); err != nil {
+		log.Printf("Error sharing collection: %v", err)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("fav.error")))
+		return
+	}
+
+	link := fmt.Sprintf("https://t.me/%s?start=%s%d", botInstance.Self.UserName, collectionPrefix, collectionID)
+	sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("fav.share_link", c.Name, link)))
+}
+
+// sendSharedCollection havola orqali ochilgan to'plam fayllarining bir sahifasini yuboradi, keyingisi
+// bo'lsa "Yana" tugmasi qo'shiladi. Ulashilmagan to'plamni faqat egasi ocha oladi.
+func sendSharedCollection(chatID, collectionID int64, page int, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	lang := i18n.For(chatID)
+
+	c, err := storage.GetCollection(db, collectionID)
+	if err != nil || (!c.Shared && c.UserID != chatID) {
+		if err != nil && err != sql.ErrNoRows {
+			log.Printf("Error getting collection %d: %v", collectionID, err)
+		}
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("fav.col_not_found")))
+		return
+	}
+
+	offset := page * sharedCollectionPageSize
+	favourites, err := storage.GetFavourites(db, c.UserID, c.ID, sharedCollectionPageSize+1, offset)
+	if err != nil {
+		log.Printf("Error getting favourites: %v", err)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("fav.error")))
+		return
+	}
+	hasNext := len(favourites) > sharedCollectionPageSize
+	if hasNext {
+		favourites = favourites[:sharedCollectionPageSize]
+	}
+
+	if page == 0 {
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("fav.col_shared", c.Name, c.Items)))
+	}
+	for _, f := range favourites {
+		if err := sendStoredMedia(chatID, f.MediaType, f.FileID, f.Title, botInstance); err != nil {
+			log.Printf("To'plamdagi faylni yuborishda xatolik: %v", err)
+		}
+	}
+
+	if hasNext {
+		sent := offset + len(favourites)
+		msgResponse := tgbotapi.NewMessage(chatID, lang.T("fav.col_progress", sent, c.Items))
+		msgResponse.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("fav.col_more"), fmt.Sprintf("fav_more|%d|%d", c.ID, page+1)),
+		))
+		sender.Send(botInstance, msgResponse)
+	}
+}
@@ -0,0 +1,334 @@
+package handle
+
+import (
+	"database/sql"
+	"fmt"
+	"html"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
+	"yuklovchiBot/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// groupAnonymousBotID anonim guruh adminlari xabarlari shu bot nomidan keladi
+const groupAnonymousBotID = 1087968824
+
+// handleGroupMessage guruhdagi xabarlar. Havolalar guruh sozlamalari bo'yicha yuklanadi, majburiy obuna
+// guruhlarda so'ralmaydi. Barcha xabarlarni ko'rish uchun BotFather'da privacy mode o'chirilgan yoki
+// bot guruh admini bo'lishi kerak.
+func handleGroupMessage(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	chatID := msg.Chat.ID
+
+	switch {
+	case msg.MigrateToChatID != 0:
+		// Guruh supergroupga aylandi, sozlamalar yangi ID'ga o'tadi
+		if err := storage.MigrateGroup(db, chatID, msg.MigrateToChatID); err != nil {
+			log.Printf("Error migrating group %d: %v", chatID, err)
+		}
+		return
+	case msg.LeftChatMember != nil && msg.LeftChatMember.ID == botInstance.Self.ID:
+		if err := storage.SetGroupInactive(db, chatID); err != nil {
+			log.Printf("Error updating group %d: %v", chatID, err)
+		}
+		return
+	case botAdded(msg, botInstance):
+		handleGroupJoin(msg, db, botInstance)
+		return
+	case msg.IsCommand():
+		handleGroupCommand(msg, db, botInstance)
+		return
+	}
+
+	link := findLink(msg.Text)
+	if link == "" {
+		link = findLink(msg.Caption)
+	}
+	if link == "" || msg.From == nil {
+		return
+	}
+
+	settings := groupSettings(msg.Chat, db)
+	if !settings.Enabled(linkPlatform(link)) {
+		return
+	}
+
+	// Limit havolani yuborgan foydalanuvchiga qo'llanadi (shaxsiy chatdagi bilan umumiy)
+	if ok, wait := allowDownload(int64(msg.From.ID)); !ok {
+		if !settings.Quiet {
+			lang := i18n.Parse(settings.Language)
+			replyToGroup(msg, lang.T("download.rate_limited", waitSeconds(wait)), botInstance)
+		}
+		return
+	}
+
+	// Yuklash uzoq davom etadi, boshqa update'lar kutib qolmasligi uchun alohida ishlaydi
+	go downloadForGroup(msg, link, settings, db, botInstance)
+}
+
+// downloadForGroup havoladagi mediani guruhga yuboradi. Guruhda foydalanuvchi sozlamalari emas,
+// standart qiymatlar ishlatiladi. Keshda bo'lmagan fayl to'g'ridan-to'g'ri guruhga yuklanadi va
+// shu xabarning file_id'si keshlanadi.
+func downloadForGroup(msg *tgbotapi.Message, link string, settings models.GroupSettings, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	chatID := msg.Chat.ID
+	lang := i18n.Parse(settings.Language)
+
+	if !settings.Quiet {
+		sendChatAction(chatID, tgbotapi.ChatUploadVideo, botInstance)
+	}
+
+	// Havola o'chirilsa, kim yuborganini izohda ko'rsatamiz; o'chirilgan xabarga javob berib bo'lmaydi
+	caption := ""
+	replyTo := 0
+	if settings.DeleteLink {
+		name := html.EscapeString(msg.From.FirstName)
+		caption = lang.T("group.caption", fmt.Sprintf(`<a href="tg://user?id=%d">%s</a>`, msg.From.ID, name))
+	} else if settings.Reply {
+		replyTo = msg.MessageID
+	}
+
+	upload := func(file, mediaType string) tgbotapi.Chattable {
+		return groupMedia(chatID, file, "", mediaType, caption, replyTo)
+	}
+	entry, uploaded, err := resolveMedia(int64(msg.From.ID), link, models.DefaultUserSettings(), upload, db, botInstance)
+	if err != nil {
+		log.Printf("Guruh uchun yuklashda xatolik: %v", err)
+		if !settings.Quiet {
+			replyToGroup(msg, lang.T("group.error"), botInstance)
+		}
+		return
+	}
+
+	if !uploaded {
+		if _, err := sender.Send(botInstance, groupMedia(chatID, "", entry.FileID, entry.MediaType, caption, replyTo)); err != nil {
+			log.Printf("Guruhga media yuborishda xatolik: %v", err)
+			return
+		}
+	}
+
+	if settings.DeleteLink {
+		if _, err := sender.Send(botInstance, tgbotapi.NewDeleteMessage(chatID, msg.MessageID)); err != nil {
+			log.Printf("Havolali xabarni o'chirishda xatolik: %v", err)
+		}
+	}
+}
+
+// groupMedia lokal faylni (file) yoki keshdagi file_id ni guruhga audio yoki video sifatida yuborish xabari
+func groupMedia(chatID int64, file, fileID, mediaType, caption string, replyTo int) tgbotapi.Chattable {
+	if mediaType == models.MediaAudio {
+		audioMsg := tgbotapi.NewAudioUpload(chatID, file)
+		if fileID != "" {
+			audioMsg = tgbotapi.NewAudioShare(chatID, fileID)
+		}
+		audioMsg.Caption = caption
+		audioMsg.ParseMode = "HTML"
+		audioMsg.ReplyToMessageID = replyTo
+		return audioMsg
+	}
+
+	videoMsg := tgbotapi.NewVideoUpload(chatID, file)
+	if fileID != "" {
+		videoMsg = tgbotapi.NewVideoShare(chatID, fileID)
+	}
+	videoMsg.Caption = caption
+	videoMsg.ParseMode = "HTML"
+	videoMsg.ReplyToMessageID = replyTo
+	return videoMsg
+}
+
+// handleGroupJoin bot guruhga qo'shilganda sozlamalarni saqlaydi va guruhga qisqa yo'riqnoma yozadi.
+// Guruh tili botni qo'shgan foydalanuvchi tilidan olinadi.
+func handleGroupJoin(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	settings, found, err := storage.GetGroupSettings(db, msg.Chat.ID)
+	if err != nil {
+		log.Printf("Error getting group settings: %v", err)
+	}
+	settings.Title = msg.Chat.Title
+	if !found && msg.From != nil {
+		settings.Language = string(i18n.For(int64(msg.From.ID)))
+	}
+	if err := storage.SaveGroupSettings(db, settings); err != nil {
+		log.Printf("Error saving group settings: %v", err)
+	}
+
+	lang := i18n.Parse(settings.Language)
+	sender.Send(botInstance, tgbotapi.NewMessage(msg.Chat.ID, lang.T("group.welcome")))
+}
+
+func handleGroupCommand(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	// Guruhda boshqa botlarga yozilgan buyruqlar e'tiborsiz qoldiriladi
+	command := msg.CommandWithAt()
+	if at := strings.Index(command, "@"); at >= 0 && !strings.EqualFold(command[at+1:], botInstance.Self.UserName) {
+		return
+	}
+
+	settings := groupSettings(msg.Chat, db)
+	lang := i18n.Parse(settings.Language)
+
+	switch msg.Command() {
+	case "settings":
+		if msg.From == nil || !isGroupAdmin(msg.Chat.ID, int64(msg.From.ID), botInstance) {
+			replyToGroup(msg, lang.T("group.admin_only"), botInstance)
+			return
+		}
+		sendGroupSettings(settings, 0, botInstance)
+	case "start", "help":
+		replyToGroup(msg, lang.T("group.welcome"), botInstance)
+	}
+}
+
+// sendGroupSettings guruh sozlamalari menyusi. messageID 0 bo'lmasa, mavjud xabar yangilanadi.
+func sendGroupSettings(settings models.GroupSettings, messageID int, botInstance *tgbotapi.BotAPI) {
+	lang := i18n.Parse(settings.Language)
+
+	button := func(text, data string) []tgbotapi.InlineKeyboardButton {
+		return tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(text, "group|"+data))
+	}
+	inlineKeyboard := tgbotapi.NewInlineKeyboardMarkup(
+		button(lang.T("settings.lang", lang.Name()), "lang"),
+		button(lang.T("group.platform", "Instagram", onOff(lang, settings.Instagram)), models.PlatformInstagram),
+		button(lang.T("group.platform", "TikTok", onOff(lang, settings.TikTok)), models.PlatformTikTok),
+		button(lang.T("group.platform", "YouTube", onOff(lang, settings.YouTube)), models.PlatformYouTube),
+		button(lang.T("group.delete_link", onOff(lang, settings.DeleteLink)), "delete"),
+		button(lang.T("group.reply", onOff(lang, settings.Reply)), "reply"),
+		button(lang.T("group.quiet", onOff(lang, settings.Quiet)), "quiet"),
+	)
+
+	if messageID != 0 {
+		editMsg := tgbotapi.NewEditMessageText(settings.ChatID, messageID, lang.T("group.settings"))
+		editMsg.ReplyMarkup = &inlineKeyboard
+		sender.Send(botInstance, editMsg)
+		return
+	}
+
+	msgResponse := tgbotapi.NewMessage(settings.ChatID, lang.T("group.settings"))
+	msgResponse.ReplyMarkup = inlineKeyboard
+	sender.Send(botInstance, msgResponse)
+}
+
+// handleGroupSettingsCallback guruh sozlamalari tugmalari, faqat guruh adminlari o'zgartira oladi.
+// data: "group|<lang|instagram|tiktok|youtube|delete|reply|quiet>"
+func handleGroupSettingsCallback(callbackQuery *tgbotapi.CallbackQuery, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	chat := callbackQuery.Message.Chat
+	settings := groupSettings(chat, db)
+	lang := i18n.Parse(settings.Language)
+
+	if !isGroupAdmin(chat.ID, int64(callbackQuery.From.ID), botInstance) {
+		botInstance.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callbackQuery.ID, lang.T("group.admin_only")))
+		return
+	}
+
+	switch option := strings.TrimPrefix(callbackQuery.Data, "group|"); option {
+	case "lang":
+		settings.Language = string(nextLanguage(lang))
+	case models.PlatformInstagram:
+		settings.Instagram = !settings.Instagram
+	case models.PlatformTikTok:
+		settings.TikTok = !settings.TikTok
+	case models.PlatformYouTube:
+		settings.YouTube = !settings.YouTube
+	case "delete":
+		settings.DeleteLink = !settings.DeleteLink
+	case "reply":
+		settings.Reply = !settings.Reply
+	case "quiet":
+		settings.Quiet = !settings.Quiet
+	default:
+		log.Printf("Unknown group settings option: %s", option)
+		return
+	}
+
+	if err := storage.SaveGroupSettings(db, settings); err != nil {
+		log.Printf("Error saving group settings: %v", err)
+		return
+	}
+	botInstance.AnswerCallbackQuery(tgbotapi.NewCallback(callbackQuery.ID, ""))
+	sendGroupSettings(settings, callbackQuery.Message.MessageID, botInstance)
+}
+
+// groupSettings guruh sozlamalari. Bot bu funksiyadan oldin qo'shilgan guruhlar birinchi xabarda saqlanadi.
+func groupSettings(chat *tgbotapi.Chat, db *sql.DB) models.GroupSettings {
+	settings, found, err := storage.GetGroupSettings(db, chat.ID)
+	if err != nil {
+		log.Printf("Error getting group settings: %v", err)
+		return settings
+	}
+	if !found {
+		settings.Title = chat.Title
+		if err := storage.SaveGroupSettings(db, settings); err != nil {
+			log.Printf("Error saving group settings: %v", err)
+		}
+	}
+	return settings
+}
+
+// isGroupAdmin foydalanuvchi guruh yaratuvchisi yoki admini ekanini tekshiradi
+func isGroupAdmin(chatID, userID int64, botInstance *tgbotapi.BotAPI) bool {
+	if userID == groupAnonymousBotID {
+		return true
+	}
+
+	member, err := botInstance.GetChatMember(tgbotapi.ChatConfigWithUser{ChatID: chatID, UserID: int(userID)})
+	if err != nil {
+		log.Printf("Error getting chat member %d in %d: %v", userID, chatID, err)
+		return false
+	}
+	return member.IsCreator() || member.IsAdministrator()
+}
+
+// botAdded xabar botning guruhga qo'shilgani haqidami
+func botAdded(msg *tgbotapi.Message, botInstance *tgbotapi.BotAPI) bool {
+	if msg.GroupChatCreated || msg.SuperGroupChatCreated {
+		return true
+	}
+	if msg.NewChatMembers == nil {
+		return false
+	}
+	for _, member := range *msg.NewChatMembers {
+		if member.ID == botInstance.Self.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// findLink matndagi birinchi yuklab bo'ladigan havola. Guruhda oddiy so'zlar ("tiktok") havola
+// hisoblanmasligi uchun faqat to'liq https havolalar olinadi.
+func findLink(text string) string {
+	for _, field := range strings.Fields(text) {
+		if strings.HasPrefix(field, "https://") && linkPlatform(field) != "" {
+			return field
+		}
+	}
+	return ""
+}
+
+func replyToGroup(msg *tgbotapi.Message, text string, botInstance *tgbotapi.BotAPI) {
+	msgResponse := tgbotapi.NewMessage(msg.Chat.ID, text)
+	msgResponse.ReplyToMessageID = msg.MessageID
+	sender.Send(botInstance, msgResponse)
+}
+
+// sendChatAction "video yuborilmoqda" kabi holatni ko'rsatadi. Javob xabar emas, shuning uchun sender.Request orqali.
+func sendChatAction(chatID int64, action string, botInstance *tgbotapi.BotAPI) {
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("action", action)
+	if _, err := sender.Request(botInstance, "sendChatAction", params); err != nil {
+		log.Printf("Error sending chat action: %v", err)
+	}
+}
+
+func nextLanguage(current i18n.Lang) i18n.Lang {
+	for i, l := range i18n.Languages {
+		if l == current {
+			return i18n.Languages[(i+1)%len(i18n.Languages)]
+		}
+	}
+	return i18n.Default
+}
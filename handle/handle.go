@@ -5,31 +5,74 @@ import (
 	"fmt"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
 	"log"
-	"os"
 	"strings"
 	"yuklovchiBot/admin"
+	"yuklovchiBot/config"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
 	"yuklovchiBot/pkg/state"
+	"yuklovchiBot/pkg/telegram"
 	"yuklovchiBot/storage"
+	"yuklovchiBot/subscription"
 )
 
-func HandleUpdate(update tgbotapi.Update, db *sql.DB, botInstance *tgbotapi.BotAPI) {
-	if update.Message != nil {
+// Config main'da bir marta yuklanadigan sozlamalar
+var Config config.Config
+
+func HandleUpdate(update telegram.Update, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if update.ChatJoinRequest != nil {
+		handleChatJoinRequest(update.ChatJoinRequest, db, botInstance)
+	} else if update.Message != nil && update.UsersShared != nil {
+		handleUsersShared(update.Message.Chat.ID, update.UsersShared, db, botInstance)
+	} else if update.Message != nil {
 
 		handleMessage(update.Message, db, botInstance)
 	} else if update.CallbackQuery != nil {
 		// Callback query'ni qayta ishlash
 		handleCallbackQuery(update.CallbackQuery, db, botInstance)
+	} else if update.InlineQuery != nil {
+		// Inline so'rovda yuklash uzoq davom etadi, boshqa update'lar kutib qolmasligi uchun alohida ishlaydi
+		go handleInlineQuery(update.InlineQuery, db, botInstance)
 	} else {
 		log.Printf("Unsupported update type: %T", update)
 	}
 }
 
+// handleUsersShared request_users tugmasi javobini request_id bo'yicha yo'naltiradi
+func handleUsersShared(chatID int64, shared *telegram.UsersShared, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	switch shared.RequestID {
+	case admin.RequestIDAdmin:
+		delete(state.UserStates, chatID)
+		admin.HandleAdminShared(chatID, shared, db, botInstance)
+	default:
+		log.Printf("Unknown users_shared request ID: %d", shared.RequestID)
+	}
+}
+
 func handleMessage(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
 	chatID := msg.Chat.ID
 	text := msg.Text
 
 	log.Printf("Received message: %s", text)
 
+	// Guruhlarda faqat havolalar va guruh buyruqlari qayta ishlanadi
+	if msg.Chat.IsGroup() || msg.Chat.IsSuperGroup() {
+		handleGroupMessage(msg, db, botInstance)
+		return
+	}
+
+	if msg.Chat.IsPrivate() && msg.From != nil {
+		if err := storage.TouchUser(db, chatID, msg.From.LanguageCode); err != nil {
+			log.Printf("Error updating user activity: %v", err)
+		}
+	}
+
+	if msg.IsCommand() && msg.Command() == "cancel" {
+		handleCancelCommand(msg, db, botInstance)
+		return
+	}
+
 	if userState, exists := state.UserStates[chatID]; exists {
 		log.Printf("User state: %s", userState)
 		switch userState {
@@ -49,20 +92,53 @@ func handleMessage(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotA
 			admin.HandleAdminRemove(msg, db, botInstance)
 			delete(state.UserStates, chatID)
 			return
+		case "waiting_for_broadcast_variant":
+			admin.HandleBroadcastVariant(msg, db, botInstance)
+			delete(state.UserStates, chatID)
+			return
+		case "waiting_for_broadcast_button":
+			delete(state.UserStates, chatID)
+			admin.HandleBroadcastButton(msg, db, botInstance)
+			return
+		case "waiting_for_scheduled_message":
+			delete(state.UserStates, chatID)
+			admin.HandleScheduledMessage(msg, db, botInstance)
+			return
+		case "waiting_for_schedule_time":
+			delete(state.UserStates, chatID)
+			admin.HandleScheduleTime(msg, db, botInstance)
+			return
+		case "waiting_for_export_range":
+			delete(state.UserStates, chatID)
+			admin.HandleExportRange(msg, db, botInstance)
+			return
+		case "waiting_for_audit_actor":
+			delete(state.UserStates, chatID)
+			admin.HandleAuditActor(msg, db, botInstance)
+			return
+		case "waiting_for_channel_period":
+			delete(state.UserStates, chatID)
+			admin.HandleChannelPeriod(msg, db, botInstance)
+			return
+		case "waiting_for_channel_target":
+			delete(state.UserStates, chatID)
+			admin.HandleChannelTarget(msg, db, botInstance)
+			return
+		case "waiting_for_restore_file":
+			delete(state.UserStates, chatID)
+			HandleRestoreFile(msg, db, botInstance)
+			return
+		case "waiting_for_collection_name":
+			delete(state.UserStates, chatID)
+			handleCollectionName(msg, db, botInstance)
+			return
 		}
 	}
 
-	if text == "/start" {
-		handleStartCommand(msg, db, botInstance)
-		err := storage.AddUserToDatabase(db, int(msg.Chat.ID))
-		if err != nil {
-			log.Printf("Error adding user to database: %v", err)
-		}
-	} else if text == "/admin" {
-		admin.HandleAdminCommand(msg, db, botInstance)
-	} else {
-		handleDefaultMessage(msg, db, botInstance)
+	if msg.IsCommand() && handleCommand(msg, db, botInstance) {
+		return
 	}
+	handleDefaultMessage(msg, db, botInstance)
 }
 
 func handleCallbackQuery(callbackQuery *tgbotapi.CallbackQuery, db *sql.DB, botInstance *tgbotapi.BotAPI) {
@@ -70,119 +146,166 @@ func handleCallbackQuery(callbackQuery *tgbotapi.CallbackQuery, db *sql.DB, botI
 	messageID := callbackQuery.Message.MessageID
 	data := callbackQuery.Data
 
-	channels, err := storage.GetChannelsFromDatabase(db)
-	if err != nil {
-		log.Printf("Error getting channels from database: %v", err)
-		return
-	}
-
 	switch {
 	// 1) Foydalanuvchi obunani tekshirish
 	case callbackQuery.Data == "check_subscription":
-		if isUserSubscribedToChannels(chatID, channels, botInstance) {
+		if ok, missing := subscription.Check(chatID, db, botInstance); ok {
 			deleteMsg := tgbotapi.NewDeleteMessage(chatID, messageID)
-			_, err := botInstance.Send(deleteMsg)
+			_, err := sender.Send(botInstance, deleteMsg)
 			if err != nil {
 				log.Printf("Error sending newmessage: %v", err)
 				return
 			}
 
-			welcomeMessage := fmt.Sprintf("👋 Assalomu alaykum [%s](tg://user?id=%d) botimizga xush kelibsiz.", callbackQuery.From.FirstName, callbackQuery.From.ID)
+			welcomeMessage := i18n.For(chatID).T("start.welcome_back", callbackQuery.From.FirstName, callbackQuery.From.ID)
 			msg := tgbotapi.NewMessage(chatID, welcomeMessage)
 			msg.ParseMode = "Markdown"
-			_, err = botInstance.Send(msg)
+			_, err = sender.Send(botInstance, msg)
 			if err != nil {
 				log.Printf("Error sending photo: %v", err)
 				return
 			}
+
+			if payload, ok := pendingStart[chatID]; ok {
+				handleStartPayload(chatID, payload, db, botInstance)
+			}
 		} else {
-			msg := tgbotapi.NewMessage(chatID, "Iltimos, kanallarga azo bo'ling.")
-			inlineKeyboard := createSubscriptionKeyboard(channels)
-			msg.ReplyMarkup = inlineKeyboard
-			botInstance.Send(msg)
+			sendSubscriptionGate(chatID, missing, db, botInstance)
 		}
 
+	case strings.HasPrefix(data, "lang|"):
+		handleLanguageChoice(callbackQuery, db, botInstance)
+
+	case strings.HasPrefix(data, "settings|"):
+		handleSettingsCallback(chatID, messageID, data, db, botInstance)
+
+	case strings.HasPrefix(data, "group|"):
+		handleGroupSettingsCallback(callbackQuery, db, botInstance)
+
+	case strings.HasPrefix(data, "history"):
+		handleHistoryCallback(callbackQuery, db, botInstance)
+
+	case strings.HasPrefix(data, "fav"):
+		handleFavouriteCallback(callbackQuery, db, botInstance)
+
 	// 2) Kanalni o‘chirishga doir callback
 	case strings.HasPrefix(callbackQuery.Data, "delete_channel_"):
 		channel := strings.TrimPrefix(callbackQuery.Data, "delete_channel_")
 		admin.AskForChannelDeletionConfirmation(chatID, messageID, channel, botInstance)
 		deleteMsg := tgbotapi.NewDeleteMessage(chatID, messageID)
-		botInstance.Send(deleteMsg)
+		sender.Send(botInstance, deleteMsg)
 
 	case strings.HasPrefix(callbackQuery.Data, "confirm_delete_channel_"):
 		channel := strings.TrimPrefix(callbackQuery.Data, "confirm_delete_channel_")
 		admin.DeleteChannel(chatID, messageID, channel, db, botInstance)
 		deleteMsg := tgbotapi.NewDeleteMessage(chatID, messageID)
-		botInstance.Send(deleteMsg)
+		sender.Send(botInstance, deleteMsg)
 
 	case callbackQuery.Data == "cancel_delete_channel":
 		admin.CancelChannelDeletion(chatID, messageID, botInstance)
 
 	// 3) **Yangi qo‘shilgan: Instagram audio yuklash callback**
 	case strings.HasPrefix(data, "download_insta_audio|"):
+		if !requireSubscription(chatID, db, botInstance) {
+			return
+		}
 		parts := strings.SplitN(data, "|", 2)
 		if len(parts) == 2 {
-			videoFile := parts[1]
-			downloadAndSendInstaAudio(chatID, videoFile, botInstance)
-
-			err := os.Remove(videoFile)
-			if err != nil {
-				log.Printf("Xatolik: Video faylni o‘chirishda xatolik: %v", err)
-			} else {
-				log.Printf("Fayl o‘chirildi: %s", videoFile)
-			}
+			sendVideoAudio(chatID, "insta", parts[1], db, botInstance)
 		}
-		RemoveInlineKeyboardAndUpdateCaption(chatID, botInstance)
+		RemoveInlineKeyboardAndUpdateCaption(chatID, db, botInstance)
 
 	// 🎯 Agar foydalanuvchi "Yo‘q" bosgan bo‘lsa, videoni o‘chirib tashlaymiz
 	case strings.HasPrefix(data, "skip_insta_audio|"):
 		parts := strings.SplitN(data, "|", 2)
-		if len(parts) == 2 {
-			videoFile := parts[1]
-
+		if len(parts) == 2 && isTempVideo(parts[1]) {
 			// 📌 Videoni o‘chiramiz (faqat serverdan)
-			err := os.Remove(videoFile)
-			if err != nil {
-				log.Printf("Xatolik: Video faylni o‘chirishda xatolik: %v", err)
-			} else {
-				log.Printf("Fayl o‘chirildi: %s", videoFile)
-			}
+			removeVideoFile(parts[1])
 		}
-		RemoveInlineKeyboardAndUpdateCaption(chatID, botInstance) // ✅ Tugmalarni o‘chirish va captionni yangilash
+		RemoveInlineKeyboardAndUpdateCaption(chatID, db, botInstance) // ✅ Tugmalarni o‘chirish va captionni yangilash
 
 	// 4) Xuddi shu uslubda TikTok audio yuklash callback’lari ham qo‘shishingiz mumkin
 	case strings.HasPrefix(data, "download_tiktok_audio|"):
+		if !requireSubscription(chatID, db, botInstance) {
+			return
+		}
 		parts := strings.SplitN(data, "|", 2)
 		if len(parts) == 2 {
-			videoFile := parts[1]
-			downloadAndSendTikTokAudio(chatID, videoFile, botInstance)
-
 			// ✅ Audio yuborilgandan keyin videoni o‘chirib tashlaymiz
-			err := os.Remove(videoFile)
-			if err != nil {
-				log.Printf("Xatolik: Video faylni o‘chirishda xatolik: %v", err)
-			} else {
-				log.Printf("Fayl o‘chirildi: %s", videoFile)
-			}
+			sendVideoAudio(chatID, "tiktok", parts[1], db, botInstance)
 		}
-		RemoveInlineKeyboardAndUpdateCaption(chatID, botInstance)
+		RemoveInlineKeyboardAndUpdateCaption(chatID, db, botInstance)
 
 	// 🎯 Agar foydalanuvchi "Yo‘q" bosgan bo‘lsa, videoni **lokaldan o‘chirib tashlaymiz**
 	case strings.HasPrefix(data, "skip_tiktok_audio|"):
 		parts := strings.SplitN(data, "|", 2)
-		if len(parts) == 2 {
-			videoFile := parts[1]
-			err := os.Remove(videoFile)
-			if err != nil {
-				log.Printf("Xatolik: Video faylni o‘chirishda xatolik: %v", err)
-			} else {
-				log.Printf("Fayl o‘chirildi: %s", videoFile)
-			}
+		if len(parts) == 2 && isTempVideo(parts[1]) {
+			removeVideoFile(parts[1])
 		}
-		RemoveInlineKeyboardAndUpdateCaption(chatID, botInstance)
+		RemoveInlineKeyboardAndUpdateCaption(chatID, db, botInstance)
+
+	case strings.HasPrefix(data, "stats|"):
+		admin.HandleStatisticsPeriod(chatID, messageID, data, db, botInstance)
+
+	case strings.HasPrefix(data, "chart|"), strings.HasPrefix(data, "chart_open|"):
+		admin.HandleChart(chatID, messageID, data, db, botInstance)
+
+	case strings.HasPrefix(data, "export|"):
+		admin.HandleExportCallback(chatID, messageID, data, db, botInstance)
+
+	case data == "admins":
+		admin.DisplayAdmins(chatID, messageID, db, botInstance)
+
+	case data == "admin_new":
+		admin.AskNewAdmin(chatID, db, botInstance)
+
+	case strings.HasPrefix(data, "admin_del|"):
+		admin.AskAdminRemoval(chatID, messageID, data, db, botInstance)
+
+	case strings.HasPrefix(data, "admin_del_ok|"):
+		admin.ConfirmAdminRemoval(chatID, messageID, data, db, botInstance)
+
+	case strings.HasPrefix(data, "channel_invite|"):
+		admin.HandleChannelInviteType(chatID, messageID, data, db, botInstance)
+
+	case data == "chan_list", strings.HasPrefix(data, "chan|"), strings.HasPrefix(data, "chan_period|"), strings.HasPrefix(data, "chan_target|"):
+		admin.HandleChannelSettings(chatID, messageID, data, db, botInstance)
+
+	case strings.HasPrefix(data, "admin_role|"):
+		admin.HandleAdminRole(chatID, messageID, data, db, botInstance)
+
+	case strings.HasPrefix(data, "audit|"):
+		admin.DisplayAuditLog(chatID, messageID, data, db, botInstance)
+
+	case strings.HasPrefix(data, "audit_actor|"):
+		admin.AskAuditActor(chatID, messageID, data, db, botInstance)
+
+	case data == "restore_confirm", data == "restore_cancel":
+		HandleRestoreConfirm(chatID, messageID, data, db, botInstance)
+
+	case strings.HasPrefix(data, "bc_aud|"):
+		admin.HandleBroadcastAudience(chatID, messageID, data, db, botInstance)
+
+	case strings.HasPrefix(data, "bc_opt|"):
+		admin.HandleBroadcastOption(chatID, messageID, data, db, botInstance)
+
+	case strings.HasPrefix(data, "bc_click|"):
+		admin.RecordBroadcastClick(callbackQuery, db, botInstance)
+
+	case strings.HasPrefix(data, "broadcast_"):
+		admin.HandleBroadcastControl(chatID, data, db, botInstance)
+
+	case strings.HasPrefix(data, "schedule_repeat|"):
+		admin.HandleScheduleRepeat(chatID, messageID, data, db, botInstance)
+
+	case strings.HasPrefix(data, "schedule_cancel|"):
+		admin.CancelScheduledMessage(chatID, messageID, data, db, botInstance)
 
 	case strings.HasPrefix(data, "youtube_download|"):
-		HandleYouTubeDownloadCallback(chatID, messageID, data, botInstance)
+		if !requireSubscription(chatID, db, botInstance) {
+			return
+		}
+		HandleYouTubeDownloadCallback(chatID, messageID, data, db, botInstance)
 
 	default:
 		log.Printf("Unknown callback data: %s", callbackQuery.Data)
@@ -191,36 +314,51 @@ func handleCallbackQuery(callbackQuery *tgbotapi.CallbackQuery, db *sql.DB, botI
 func handleStartCommand(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbotapi.BotAPI) {
 	chatID := msg.Chat.ID
 	userID := msg.From.ID
-	firstName := msg.From.FirstName
+
+	payload := strings.TrimSpace(msg.CommandArguments())
+	referredBy, campaign := startSource(payload, int64(userID))
 
 	log.Printf("Adding user to database: %d ", userID)
-	err := storage.AddUserToDatabase(db, userID)
+	created, err := storage.AddUserToDatabase(db, userID, referredBy, campaign)
 	if err != nil {
 		log.Printf("Error adding user to database: %v", err)
 		return
 	}
+	if created && referredBy != 0 {
+		notifyReferrer(referredBy, msg.From, db, botInstance)
+	}
+
+	// Ulashilgan fayl yoki to'plam havolasi: /start m_<id>_<imzo>, /start col_<id>
+	if handleStartPayload(chatID, payload, db, botInstance) {
+		return
+	}
 
-	channels, err := storage.GetChannelsFromDatabase(db)
+	// Birinchi /start da avval til tanlanadi, salomlashish tanlovdan keyin yuboriladi
+	language, _, err := storage.GetUserLanguage(db, chatID)
 	if err != nil {
-		log.Printf("Error getting channels from database: %v", err)
+		log.Printf("Error getting user language: %v", err)
+	} else if language == "" {
+		sendLanguagePicker(chatID, i18n.Parse(msg.From.LanguageCode), botInstance)
 		return
 	}
 
-	if isUserSubscribedToChannels(chatID, channels, botInstance) {
-		welcomeMessage := fmt.Sprintf("👋 Assalomu alaykum [%s](tg://user?id=%d), botimizga xush kelibsiz.\n\nMen sizga Instagram va TikTokdan videolarni yuklashda yordam beruvchi botman.\n\n Iltimos menga video havolasini yuboring.", firstName, userID)
+	sendWelcome(chatID, msg.From, db, botInstance)
+}
+
+// sendWelcome obuna bo'lgan foydalanuvchini kutib oladi, aks holda obuna talabini yuboradi
+func sendWelcome(chatID int64, user *tgbotapi.User, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	if ok, missing := subscription.Check(chatID, db, botInstance); ok {
+		welcomeMessage := i18n.For(chatID).T("start.welcome", user.FirstName, user.ID)
 
 		msg := tgbotapi.NewMessage(chatID, welcomeMessage)
 		msg.ParseMode = "Markdown"
-		_, err := botInstance.Send(msg)
+		_, err := sender.Send(botInstance, msg)
 		if err != nil {
 			log.Printf("Error sending welcome message: %v", err)
 			return
 		}
 	} else {
-		msg := tgbotapi.NewMessage(chatID, "Iltimos, kanallarga azo bo'ling.")
-		inlineKeyboard := createSubscriptionKeyboard(channels)
-		msg.ReplyMarkup = inlineKeyboard
-		botInstance.Send(msg)
+		sendSubscriptionGate(chatID, missing, db, botInstance)
 	}
 }
 
@@ -228,94 +366,105 @@ func handleDefaultMessage(msg *tgbotapi.Message, db *sql.DB, botInstance *tgbota
 	chatID := msg.Chat.ID
 	text := msg.Text
 
+	if isDownloadLink(text) && (!requireSubscription(chatID, db, botInstance) || !requireRateLimit(chatID, botInstance)) {
+		return
+	}
+
 	if strings.HasPrefix(text, "https://www.instagram.com/") || strings.HasPrefix(text, "instagram") {
-		loadingMsg, err := botInstance.Send(tgbotapi.NewMessage(chatID, "⌛️"))
+		loadingMsg, err := sender.Send(botInstance, tgbotapi.NewMessage(chatID, "⌛️"))
 		if err != nil {
 			log.Printf("Loading xabarini yuborishda xatolik: %v", err)
 		}
 
-		downloadAndSendInstaVideo(chatID, text, botInstance, loadingMsg.MessageID)
+		downloadAndSendInstaVideo(chatID, text, db, botInstance, loadingMsg.MessageID)
 		return
 	}
 
 	if strings.HasPrefix(text, "https://www.tiktok.com/") || strings.HasPrefix(text, "tiktok") {
 
-		loadingMsg, err := botInstance.Send(tgbotapi.NewMessage(chatID, "⌛️"))
+		loadingMsg, err := sender.Send(botInstance, tgbotapi.NewMessage(chatID, "⌛️"))
 		if err != nil {
 			log.Printf("Loading xabarini yuborishda xatolik: %v", err)
 		}
 
-		downloadAndSendTikTokVideo(chatID, text, botInstance, loadingMsg.MessageID)
+		downloadAndSendTikTokVideo(chatID, text, db, botInstance, loadingMsg.MessageID)
 		return
 	}
 
-	switch text {
-	case "Kanal qo'shish":
+	// YouTube havolasi sozlamadagi sifat bo'yicha darhol yuklanadi yoki formatlar ro'yxati ko'rsatiladi
+	if strings.HasPrefix(text, "https://www.youtube.com/") || strings.HasPrefix(text, "https://youtube.com/") || strings.HasPrefix(text, "https://youtu.be/") {
+		if err := HandleYouTubeLink(chatID, text, db, botInstance); err != nil {
+			log.Printf("YouTube havolasini qayta ishlashda xatolik: %v", err)
+			startDownload(db, chatID, models.PlatformYouTube, text).fail("metadata")
+			sender.Send(botInstance, tgbotapi.NewMessage(chatID, i18n.For(chatID).T("youtube.meta_error")))
+		}
+		return
+	}
+
+	// Admin menyusi tugmalari istalgan tilda bosilishi mumkin
+	switch i18n.Match(text, "menu.") {
+	case "menu.channel_add":
 		state.UserStates[chatID] = "waiting_for_channel_link"
-		msgResponse := tgbotapi.NewMessage(chatID, "Kanal linkini yuboring (masalan, https://t.me/your_channel):")
-		botInstance.Send(msgResponse)
-	case "Admin qo'shish":
-		state.UserStates[chatID] = "waiting_for_admin_id"
-		msgResponse := tgbotapi.NewMessage(chatID, "Iltimos, yangi admin ID sini yuboring:")
-		botInstance.Send(msgResponse)
-	case "Admin o'chirish":
+		msgResponse := tgbotapi.NewMessage(chatID, i18n.For(chatID).T("admin.channel_prompt"))
+		sender.Send(botInstance, msgResponse)
+	case "menu.admins":
+		admin.DisplayAdmins(chatID, 0, db, botInstance)
+	case "menu.admin_add":
+		admin.AskNewAdmin(chatID, db, botInstance)
+	case "menu.admin_remove":
 		state.UserStates[chatID] = "waiting_for_admin_id_remove"
-		msgResponse := tgbotapi.NewMessage(chatID, "Iltimos, admin ID sini o'chirish uchun yuboring:")
-		botInstance.Send(msgResponse)
-	case "Kanal o'chirish":
+		msgResponse := tgbotapi.NewMessage(chatID, i18n.For(chatID).T("admin.remove_prompt"))
+		sender.Send(botInstance, msgResponse)
+	case "menu.channel_delete":
 		admin.DisplayChannelsForDeletion(chatID, db, botInstance)
-	case "Statistika":
+	case "menu.stats":
 		admin.HandleStatistics(msg, db, botInstance)
-	case "Habar yuborish":
-		state.UserStates[chatID] = "waiting_for_broadcast_message"
-		msgResponse := tgbotapi.NewMessage(chatID, "Iltimos, yubormoqchi bo'lgan habaringizni kiriting (Bekor qilish uchun /cancel):")
-		botInstance.Send(msgResponse)
-	case "BackUp olish":
-		if storage.IsAdmin(int(chatID), db) {
-			go HandleBackup(db, botInstance)
+	case "menu.broadcast":
+		admin.AskBroadcastAudience(chatID, db, botInstance)
+	case "menu.schedule":
+		state.UserStates[chatID] = "waiting_for_scheduled_message"
+		msgResponse := tgbotapi.NewMessage(chatID, i18n.For(chatID).T("admin.schedule_prompt"))
+		sender.Send(botInstance, msgResponse)
+	case "menu.scheduled":
+		admin.DisplayScheduledMessages(chatID, 0, db, botInstance)
+	case "menu.export":
+		admin.HandleExport(chatID, db, botInstance)
+	case "menu.backup":
+		if admin.HasPermission(chatID, models.PermBackups, db) {
+			go HandleBackup(chatID, db, botInstance)
 		}
+	case "menu.backups":
+		admin.DisplayBackups(chatID, db, botInstance)
+	case "menu.restore":
+		HandleRestoreRequest(chatID, db, botInstance)
+	case "menu.channels":
+		admin.DisplayChannelReports(chatID, 0, db, botInstance)
+	case "menu.audit":
+		admin.DisplayAuditLog(chatID, 0, "", db, botInstance)
+	case "menu.sources":
+		admin.DisplaySources(chatID, db, botInstance)
 	}
 }
 
-func isUserSubscribedToChannels(chatID int64, channels []string, botInstance *tgbotapi.BotAPI) bool {
-	for _, channel := range channels {
-		log.Printf("Checking subscription to channel: %s", channel)
-		chat, err := botInstance.GetChat(tgbotapi.ChatConfig{SuperGroupUsername: "@" + channel})
-		if err != nil {
-			log.Printf("Error getting chat info for channel %s: %v", channel, err)
-			return false
-		}
-
-		member, err := botInstance.GetChatMember(tgbotapi.ChatConfigWithUser{
-			ChatID: chat.ID,
-			UserID: int(chatID),
-		})
-		if err != nil {
-			log.Printf("Error getting chat member info for channel %s: %v", channel, err)
-			return false
-		}
-		if member.Status == "left" || member.Status == "kicked" {
-			log.Printf("User %d is not subscribed to channel %s", chatID, channel)
-			return false
-		}
-	}
-	return true
+// isDownloadLink handleDefaultMessage yuklab beradigan havolalar
+func isDownloadLink(text string) bool {
+	return linkPlatform(text) != ""
 }
 
-func createSubscriptionKeyboard(channels []string) tgbotapi.InlineKeyboardMarkup {
-	var rows [][]tgbotapi.InlineKeyboardButton
-	for _, channel := range channels {
-		channelName := strings.TrimPrefix(channel, "https://t.me/")
-		button := tgbotapi.NewInlineKeyboardButtonURL("Kanalga azo bo'lish", "https://t.me/"+channelName)
-		rows = append(rows, tgbotapi.NewInlineKeyboardRow(button))
+// linkPlatform havola qaysi platformaga tegishli ekanini aniqlaydi, yuklab bo'lmaydigan matn uchun ""
+func linkPlatform(text string) string {
+	switch {
+	case strings.HasPrefix(text, "https://www.instagram.com/"), strings.HasPrefix(text, "instagram"):
+		return models.PlatformInstagram
+	case strings.HasPrefix(text, "https://www.tiktok.com/"), strings.HasPrefix(text, "tiktok"):
+		return models.PlatformTikTok
+	case strings.HasPrefix(text, "https://www.youtube.com/"), strings.HasPrefix(text, "https://youtube.com/"), strings.HasPrefix(text, "https://youtu.be/"):
+		return models.PlatformYouTube
 	}
-	checkButton := tgbotapi.NewInlineKeyboardButtonData("Azo bo'ldim", "check_subscription")
-	rows = append(rows, tgbotapi.NewInlineKeyboardRow(checkButton))
-
-	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+	return ""
 }
 
-func RemoveInlineKeyboardAndUpdateCaption(chatID int64, botInstance *tgbotapi.BotAPI) {
+func RemoveInlineKeyboardAndUpdateCaption(chatID int64, db *sql.DB, botInstance *tgbotapi.BotAPI) {
 	// Xabar ID'sini olish
 	messageID, exists := state.GetMessageID(chatID)
 	if !exists {
@@ -323,24 +472,28 @@ func RemoveInlineKeyboardAndUpdateCaption(chatID int64, botInstance *tgbotapi.Bo
 		return
 	}
 
-	// 📌 Xabar captionini faqat "Siz so‘ragan video." qilib yangilash
-	editMsg := tgbotapi.NewEditMessageCaption(chatID, messageID, "Siz so‘ragan video.")
+	// 📌 Xabar captionini faqat "Siz so‘ragan video." qilib yangilash (izoh o'chirilgan bo'lsa bo'sh)
+	caption := ""
+	if userSettings(chatID, db).Caption {
+		caption = i18n.For(chatID).T("download.caption")
+	}
+	editMsg := tgbotapi.NewEditMessageCaption(chatID, messageID, caption)
 	editMsg.ParseMode = "Markdown"
 	editMsg.ReplyMarkup = nil // 📌 Inline tugmalarni olib tashlaymiz
 
-	_, err := botInstance.Send(editMsg)
+	_, err := sender.Send(botInstance, editMsg)
 	if err != nil {
 		log.Printf("Xabarni yangilashda xatolik: %v", err)
 	}
 }
 
 // 🎯 "Ha" va "Yo‘q" tugmalarini yaratish
-func createAudioOptionKeyboard(platform, videoPath string) tgbotapi.InlineKeyboardMarkup {
+func createAudioOptionKeyboard(lang i18n.Lang, platform, videoPath string) tgbotapi.InlineKeyboardMarkup {
 	haData := fmt.Sprintf("download_%s_audio|%s", platform, videoPath)
 	yoqData := fmt.Sprintf("skip_%s_audio|%s", platform, videoPath)
 
-	haButton := tgbotapi.NewInlineKeyboardButtonData("Ha", haData)
-	yoqButton := tgbotapi.NewInlineKeyboardButtonData("Yo‘q", yoqData)
+	haButton := tgbotapi.NewInlineKeyboardButtonData(lang.T("download.yes"), haData)
+	yoqButton := tgbotapi.NewInlineKeyboardButtonData(lang.T("download.no"), yoqData)
 
 	row := tgbotapi.NewInlineKeyboardRow(haButton, yoqButton)
 	return tgbotapi.NewInlineKeyboardMarkup(row)
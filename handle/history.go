@@ -0,0 +1,193 @@
+package handle
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
+	"yuklovchiBot/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const historyPageSize = 5
+
+var platformLabels = map[string]string{
+	models.PlatformInstagram: "📸 Instagram",
+	models.PlatformTikTok:    "🎵 TikTok",
+	models.PlatformYouTube:   "▶️ YouTube",
+}
+
+// sendHistory foydalanuvchi yuklashlari tarixini sahifalab ko'rsatadi. messageID 0 bo'lmasa xabar yangilanadi.
+func sendHistory(chatID int64, messageID, page int, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	lang := i18n.For(chatID)
+
+	// Keyingi sahifa borligini bilish uchun bitta ortiq yozuv olinadi
+	downloads, err := storage.GetUserHistory(db, chatID, historyPageSize+1, page*historyPageSize)
+	if err != nil {
+		log.Printf("Error getting download history: %v", err)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("history.error")))
+		return
+	}
+	hasNext := len(downloads) > historyPageSize
+	if hasNext {
+		downloads = downloads[:historyPageSize]
+	}
+
+	text := historyText(lang, downloads, page)
+	keyboard := historyKeyboard(lang, downloads, page, hasNext)
+
+	if messageID != 0 {
+		editMsg := tgbotapi.NewEditMessageText(chatID, messageID, text)
+		if len(keyboard.InlineKeyboard) > 0 {
+			editMsg.ReplyMarkup = &keyboard
+		}
+		sender.Send(botInstance, editMsg)
+		return
+	}
+
+	msgResponse := tgbotapi.NewMessage(chatID, text)
+	if len(keyboard.InlineKeyboard) > 0 {
+		msgResponse.ReplyMarkup = keyboard
+	}
+	sender.Send(botInstance, msgResponse)
+}
+
+// handleHistoryCallback tarix tugmalari:
+// "history|<sahifa>", "history_send|<download_id>", "history_share|<download_id>", "history_clear", "history_clear_ok"
+func handleHistoryCallback(callbackQuery *tgbotapi.CallbackQuery, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	chatID := callbackQuery.Message.Chat.ID
+	messageID := callbackQuery.Message.MessageID
+	data := callbackQuery.Data
+	lang := i18n.For(chatID)
+
+	switch {
+	case strings.HasPrefix(data, "history|"):
+		page, _ := strconv.Atoi(strings.TrimPrefix(data, "history|"))
+		sendHistory(chatID, messageID, page, db, botInstance)
+
+	case strings.HasPrefix(data, "history_send|"):
+		id, _ := strconv.ParseInt(strings.TrimPrefix(data, "history_send|"), 10, 64)
+		if !resendDownload(chatID, id, db, botInstance) {
+			botInstance.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callbackQuery.ID, lang.T("history.not_found")))
+			return
+		}
+
+	case strings.HasPrefix(data, "history_share|"):
+		id, _ := strconv.ParseInt(strings.TrimPrefix(data, "history_share|"), 10, 64)
+		if !shareDownload(chatID, id, db, botInstance) {
+			botInstance.AnswerCallbackQuery(tgbotapi.NewCallbackWithAlert(callbackQuery.ID, lang.T("history.not_found")))
+			return
+		}
+
+	case data == "history_clear":
+		keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("history.clear_yes"), "history_clear_ok"),
+			tgbotapi.NewInlineKeyboardButtonData(lang.T("history.clear_no"), "history|0"),
+		))
+		editMsg := tgbotapi.NewEditMessageText(chatID, messageID, lang.T("history.clear_confirm"))
+		editMsg.ReplyMarkup = &keyboard
+		sender.Send(botInstance, editMsg)
+
+	case data == "history_clear_ok":
+		if err := storage.HideUserHistory(db, chatID); err != nil {
+			log.Printf("Error clearing download history: %v", err)
+			sender.Send(botInstance, tgbotapi.NewMessage(chatID, lang.T("history.error")))
+			return
+		}
+		sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, messageID, lang.T("history.cleared")))
+	}
+
+	botInstance.AnswerCallbackQuery(tgbotapi.NewCallback(callbackQuery.ID, ""))
+}
+
+// resendDownload tarixdagi faylni file_id orqali qayta yuboradi. Yozuv boshqa foydalanuvchiniki,
+// tarix tozalanganda yashirilgan bo'lsa yoki fayl yuborilmasa false qaytaradi.
+func resendDownload(chatID, downloadID int64, db *sql.DB, botInstance *tgbotapi.BotAPI) bool {
+	d, err := storage.GetDownload(db, downloadID)
+	if err != nil {
+		log.Printf("Error getting download %d: %v", downloadID, err)
+		return false
+	}
+	if d.UserID != chatID || d.FileID == "" || d.Hidden {
+		return false
+	}
+
+	if err := sendStoredMedia(chatID, d.MediaType, d.FileID, d.Title, botInstance); err != nil {
+		log.Printf("Tarixdagi faylni yuborishda xatolik: %v", err)
+		return false
+	}
+	return true
+}
+
+func historyText(lang i18n.Lang, downloads []models.Download, page int) string {
+	if len(downloads) == 0 && page == 0 {
+		return lang.T("history.empty")
+	}
+
+	loc := Config.Location()
+
+	var sb strings.Builder
+	sb.WriteString(lang.T("history.title", page+1))
+	sb.WriteString("\n\n")
+	for i, d := range downloads {
+		title := d.Title
+		if title == "" {
+			title = d.SourceURL
+		}
+		sb.WriteString(fmt.Sprintf("%d. %s · %s\n%s\n\n", i+1, platformLabel(d.Platform),
+			d.CreatedAt.In(loc).Format("2006-01-02 15:04"), truncateText(title, 80)))
+	}
+	return sb.String()
+}
+
+// historyKeyboard har bir yozuv uchun raqamli qayta yuborish, sevimlilarga qo'shish va ulashish tugmalari,
+// sahifalar va tozalash tugmasi
+func historyKeyboard(lang i18n.Lang, downloads []models.Download, page int, hasNext bool) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+
+	var resend, star, share []tgbotapi.InlineKeyboardButton
+	for i, d := range downloads {
+		resend = append(resend, tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("🔁 %d", i+1), fmt.Sprintf("history_send|%d", d.ID)))
+		star = append(star, tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("⭐ %d", i+1), fmt.Sprintf("fav_add|%d", d.ID)))
+		share = append(share, tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("🔗 %d", i+1), fmt.Sprintf("history_share|%d", d.ID)))
+	}
+	if len(resend) > 0 {
+		rows = append(rows, resend, star, share)
+	}
+
+	var nav []tgbotapi.InlineKeyboardButton
+	if page > 0 {
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(lang.T("history.prev"), fmt.Sprintf("history|%d", page-1)))
+	}
+	if hasNext {
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData(lang.T("history.next"), fmt.Sprintf("history|%d", page+1)))
+	}
+	if len(nav) > 0 {
+		rows = append(rows, nav)
+	}
+
+	if len(downloads) > 0 {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(lang.T("history.clear"), "history_clear")))
+	}
+	return tgbotapi.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+func platformLabel(platform string) string {
+	if label, ok := platformLabels[platform]; ok {
+		return label
+	}
+	return platform
+}
+
+func truncateText(s string, max int) string {
+	r := []rune(strings.TrimSpace(s))
+	if len(r) <= max {
+		return string(r)
+	}
+	return string(r[:max]) + "…"
+}
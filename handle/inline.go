@@ -0,0 +1,222 @@
+package handle
+
+import (
+	"crypto/sha1"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/telegram"
+	"yuklovchiBot/storage"
+	"yuklovchiBot/subscription"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const (
+	// inlineCacheTime tayyor natija Telegram tomonida shuncha soniya keshlanadi
+	inlineCacheTime = 300
+	// inlineAnswerTimeout shu vaqtda tayyor bo'lmagan fayl uchun "tayyorlanmoqda" tugmasi bilan javob
+	// beriladi, yuklash fonda davom etadi (Telegram inline javobni uzoq kutmaydi)
+	inlineAnswerTimeout = 5 * time.Second
+	// maxInlineQueries bir vaqtda qayta ishlanadigan inline so'rovlar, qolganlari "band" javobini oladi
+	maxInlineQueries = 50
+	// maxInlineJobs fonda bir vaqtda tayyorlanadigan inline fayllar
+	maxInlineJobs = 5
+)
+
+// inlineLinkRe inline rejimda qabul qilinadigan to'liq havolalar. Havola harfma-harf yozilganda chala
+// qismlari yuklashni boshlamasligi va limitni sarflamasligi uchun video ID'si to'liq bo'lishi kerak.
+var inlineLinkRe = regexp.MustCompile(`^https://(?:` +
+	`www\.instagram\.com/(?:p|reel|reels|tv)/[A-Za-z0-9_-]{10,}|` +
+	`www\.tiktok\.com/@[A-Za-z0-9_.]+/video/\d{15,}|` +
+	`(?:www\.)?youtube\.com/(?:watch\?v=|shorts/)[A-Za-z0-9_-]{11}|` +
+	`youtu\.be/[A-Za-z0-9_-]{11}` +
+	`)(?:[/?&#]\S*)?$`)
+
+var inlineQuerySlots = make(chan struct{}, maxInlineQueries)
+
+// inlineJob fonda tayyorlanayotgan fayl. Bir xil havola (va sozlama) uchun bittadan ortiq yuklanmaydi,
+// keyingi so'rovlar shu natijani kutadi.
+type inlineJob struct {
+	done  chan struct{}
+	entry models.MediaCache
+	err   error
+}
+
+var (
+	inlineMu   sync.Mutex
+	inlineJobs = make(map[string]*inlineJob)
+)
+
+// handleInlineQuery "@bot <havola>" so'rovini qayta ishlaydi. Obuna va limit shaxsiy chatdagidek
+// tekshiriladi; fayl keshda bo'lmasa fonda yuklanib, file_id olish uchun kesh chatiga yuboriladi.
+// Ishlashi uchun BotFather'da inline rejim yoqilgan va CACHE_CHAT_ID o'rnatilgan bo'lishi kerak.
+func handleInlineQuery(query *tgbotapi.InlineQuery, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	userID := int64(query.From.ID)
+	link := strings.TrimSpace(query.Query)
+
+	if err := storage.TouchUser(db, userID, query.From.LanguageCode); err != nil {
+		log.Printf("Error updating user activity: %v", err)
+	}
+	lang := i18n.For(userID)
+
+	if Config.CacheChatID == 0 {
+		answerInline(botInstance, query.ID, nil, lang.T("inline.unavailable"))
+		return
+	}
+
+	if !isInlineLink(link) {
+		answerInline(botInstance, query.ID, nil, lang.T("inline.hint"))
+		return
+	}
+
+	select {
+	case inlineQuerySlots <- struct{}{}:
+		defer func() { <-inlineQuerySlots }()
+	default:
+		answerInline(botInstance, query.ID, nil, lang.T("inline.busy"))
+		return
+	}
+
+	if ok, missing := subscription.Check(userID, db, botInstance); !ok {
+		subscription.GateShown(userID, missing, db)
+		answerInline(botInstance, query.ID, nil, lang.T("inline.subscribe"))
+		return
+	}
+
+	settings := userSettings(userID, db)
+	key := fmt.Sprintf("%s|%t|%s", link, settings.NoWatermark, settings.YouTubeQuality)
+
+	// Shu havola allaqachon tayyorlanayotgan bo'lsa, limit qayta sarflanmaydi
+	job := runningInlineJob(key)
+	if job == nil {
+		if ok, wait := allowDownload(userID); !ok {
+			answerInline(botInstance, query.ID, nil, lang.T("inline.rate_limited", waitSeconds(wait)))
+			return
+		}
+		job = startInlineJob(key, func() (models.MediaCache, error) {
+			entry, _, err := resolveMedia(userID, link, settings, uploadToCacheChat(link), db, botInstance)
+			return entry, err
+		})
+		if job == nil {
+			answerInline(botInstance, query.ID, nil, lang.T("inline.busy"))
+			return
+		}
+	}
+
+	select {
+	case <-job.done:
+	case <-time.After(inlineAnswerTimeout):
+		answerInline(botInstance, query.ID, nil, lang.T("inline.preparing"))
+		return
+	}
+
+	entry, err := job.entry, job.err
+	if err != nil {
+		log.Printf("Inline so'rovni qayta ishlashda xatolik: %v", err)
+		answerInline(botInstance, query.ID, nil, lang.T("inline.error"))
+		return
+	}
+
+	caption := ""
+	if settings.Caption {
+		caption = lang.T("inline.caption", botInstance.Self.UserName)
+	}
+	answerInline(botInstance, query.ID, []interface{}{inlineResult(lang, entry, caption)}, "")
+}
+
+// isInlineLink matn inline rejimda yuklab beriladigan to'liq havolami
+func isInlineLink(text string) bool {
+	return linkPlatform(text) != "" && inlineLinkRe.MatchString(text)
+}
+
+func runningInlineJob(key string) *inlineJob {
+	inlineMu.Lock()
+	defer inlineMu.Unlock()
+	return inlineJobs[key]
+}
+
+// startInlineJob resolve'ni fonda ishga tushiradi. Shu kalit bilan ish allaqachon bo'lsa o'shani qaytaradi,
+// maxInlineJobs to'lgan bo'lsa nil.
+func startInlineJob(key string, resolve func() (models.MediaCache, error)) *inlineJob {
+	inlineMu.Lock()
+	defer inlineMu.Unlock()
+
+	if job, ok := inlineJobs[key]; ok {
+		return job
+	}
+	if len(inlineJobs) >= maxInlineJobs {
+		return nil
+	}
+
+	job := &inlineJob{done: make(chan struct{})}
+	inlineJobs[key] = job
+	go func() {
+		job.entry, job.err = resolve()
+		close(job.done)
+
+		inlineMu.Lock()
+		delete(inlineJobs, key)
+		inlineMu.Unlock()
+	}()
+	return job
+}
+
+// uploadToCacheChat faylni file_id olish uchun kesh chatiga yuboradi, izohda havola turadi
+func uploadToCacheChat(link string) mediaUpload {
+	return func(file, mediaType string) tgbotapi.Chattable {
+		if mediaType == models.MediaAudio {
+			audioMsg := tgbotapi.NewAudioUpload(Config.CacheChatID, file)
+			audioMsg.Caption = link
+			return audioMsg
+		}
+		return videoMessage(Config.CacheChatID, file, "", false, link, nil)
+	}
+}
+
+// answerInline natijalarni yuboradi. Natija bo'lmasa buttonText bilan botga o'tish tugmasi
+// ko'rsatiladi va javob keshlanmaydi (obuna yoki limit keyingi so'rovda qayta tekshiriladi).
+func answerInline(botInstance *tgbotapi.BotAPI, queryID string, results []interface{}, buttonText string) {
+	var button *telegram.InlineQueryResultsButton
+	if buttonText != "" {
+		button = &telegram.InlineQueryResultsButton{Text: buttonText, StartParameter: "inline"}
+	}
+	cacheTime := 0
+	if len(results) > 0 {
+		cacheTime = inlineCacheTime
+	}
+
+	if err := telegram.AnswerInlineQuery(botInstance, queryID, results, button, cacheTime); err != nil {
+		log.Printf("Error answering inline query: %v", err)
+	}
+}
+
+// inlineResult keshdagi faylni inline natija ko'rinishiga keltiradi
+func inlineResult(lang i18n.Lang, entry models.MediaCache, caption string) interface{} {
+	sum := sha1.Sum([]byte(entry.Key))
+	id := hex.EncodeToString(sum[:])
+
+	if entry.MediaType == models.MediaAudio {
+		return telegram.InlineQueryResultCachedAudio{Type: "audio", ID: id, AudioFileID: entry.FileID, Caption: caption}
+	}
+
+	title := entry.Title
+	if title == "" {
+		title = lang.T("inline.title")
+	}
+	return telegram.InlineQueryResultCachedVideo{
+		Type:        "video",
+		ID:          id,
+		VideoFileID: entry.FileID,
+		Title:       title,
+		Description: lang.T("inline.description"),
+		Caption:     caption,
+	}
+}
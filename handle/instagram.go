@@ -1,6 +1,7 @@
 package handle
 
 import (
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
@@ -10,8 +11,9 @@ import (
 	"os"
 	"os/exec"
 	"time"
-	"yuklovchiBot/config"
-	"yuklovchiBot/pkg/state"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
 )
 
 // API'dan qaytgan video javob formati
@@ -24,12 +26,12 @@ type VideoResponse struct {
 }
 
 // 📌 1️⃣ Videoni yuklab, keyin foydalanuvchiga yuborish
-func downloadAndSendInstaVideo(chatID int64, videoURL string, botInstance *tgbotapi.BotAPI, loadingMsgID int) {
+func downloadAndSendInstaVideo(chatID int64, videoURL string, db *sql.DB, botInstance *tgbotapi.BotAPI, loadingMsgID int) {
 
 	loadingDeleted := false
 	deleteLoading := func() {
 		if !loadingDeleted && loadingMsgID != 0 {
-			_, err := botInstance.Send(tgbotapi.NewDeleteMessage(chatID, loadingMsgID))
+			_, err := sender.Send(botInstance, tgbotapi.NewDeleteMessage(chatID, loadingMsgID))
 			if err != nil {
 				log.Printf("Loading xabarini o'chirishda xatolik: %v", err)
 			}
@@ -37,63 +39,88 @@ func downloadAndSendInstaVideo(chatID int64, videoURL string, botInstance *tgbot
 		}
 	}
 
-	instaApi := config.Load().InstaApi
+	tracker := startDownload(db, chatID, models.PlatformInstagram, videoURL)
+	settings := userSettings(chatID, db)
+	cacheKey := mediaCacheKey(videoURL, settings)
+
+	// Havola avval yuklangan bo'lsa, keshdan yuboramiz
+	if sendCachedVideo(chatID, cacheKey, "insta", settings, tracker, db, botInstance) {
+		deleteLoading()
+		return
+	}
+
+	// 📌 2️⃣ Videoni **lokalga** yuklab olamiz
+	videoFile, fetchErr := fetchInstaVideo(videoURL)
+	if fetchErr != nil {
+		tracker.fail(fetchErr.reason)
+		deleteLoading()
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, i18n.For(chatID).T(fetchErr.key)))
+		return
+	}
+
+	deleteLoading()
+
+	// 📌 3️⃣ Videoni foydalanuvchiga sozlamalari bo'yicha yuborish
+	size := fileSize(videoFile)
+	sentMsg, err := deliverVideo(chatID, videoFile, "", "insta", settings, tracker, db, botInstance)
+	if err != nil {
+		log.Printf("Video yuborishda xatolik: %v", err)
+		tracker.fail("send")
+		removeVideoFile(videoFile)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, i18n.For(chatID).T("download.error_detail", err)))
+		return
+	}
+	tracker.success(sentMsg, "", size, false)
+	cacheSentMedia(db, cacheKey, models.PlatformInstagram, sentMsg, "", size)
+}
+
+// fetchError yuklashdagi xatolik: reason statistikadagi qisqa sabab kodi, key foydalanuvchiga
+// ko'rsatiladigan matn kaliti
+type fetchError struct {
+	reason string
+	key    string
+}
+
+func (e *fetchError) Error() string {
+	return e.reason
+}
+
+// fetchInstaVideo API orqali Instagram videoni lokal faylga yuklab oladi
+func fetchInstaVideo(videoURL string) (string, *fetchError) {
+	instaApi := Config.InstaApi
+	defer acquireDownloadSlot()()
 
 	// API'ga so‘rov yuborish
 	apiURL := fmt.Sprintf("%s%s", instaApi, videoURL)
 	resp, err := http.Get(apiURL)
 	if err != nil {
-		deleteLoading()
-		botInstance.Send(tgbotapi.NewMessage(chatID, "❌ Video yuklab olishda xatolik yuz berdi."))
-		return
+		log.Printf("Instagram API so'rovida xatolik: %v", err)
+		return "", &fetchError{"api_request", "download.error"}
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		deleteLoading()
-		botInstance.Send(tgbotapi.NewMessage(chatID, "❌ Video yuklab olinmadi. Iltimos, boshqa linkni sinab ko'ring."))
-		return
+		return "", &fetchError{"api_status", "download.not_found"}
 	}
 
 	// API javobini JSON formatida o‘qish
 	var videoResp VideoResponse
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		deleteLoading()
-		botInstance.Send(tgbotapi.NewMessage(chatID, "❌ API javobini o‘qishda xatolik yuz berdi."))
-		return
+		return "", &fetchError{"api_response", "download.api_error"}
 	}
 
 	err = json.Unmarshal(body, &videoResp)
 	if err != nil || videoResp.Status != "success" {
-		deleteLoading()
-		botInstance.Send(tgbotapi.NewMessage(chatID, "❌ Video yuklab olishda muammo bor."))
-		return
+		return "", &fetchError{"api_response", "download.problem"}
 	}
 
-	// 📌 2️⃣ Videoni **lokalga** yuklab olamiz
 	videoFile, err := downloadFile(videoResp.Data.VideoURL, "temp_insta_", ".mp4")
 	if err != nil {
-		deleteLoading()
-		botInstance.Send(tgbotapi.NewMessage(chatID, "❌ Video yuklab olishda xatolik."))
-		return
-	}
-
-	deleteLoading()
-
-	// 📌 3️⃣ Videoni foydalanuvchiga yuborish
-	videoMsg := tgbotapi.NewVideoUpload(chatID, videoFile)
-	videoMsg.Caption = "Siz so‘ragan video.\n\nAudiosini yuklashni istaysizmi?"
-	videoMsg.ReplyMarkup = createAudioOptionKeyboard("insta", videoFile)
-
-	sentMsg, err := botInstance.Send(videoMsg)
-	if err != nil {
-		log.Printf("Video yuborishda xatolik: %v", err)
+		log.Printf("Instagram videoni yuklashda xatolik: %v", err)
+		return "", &fetchError{"file_download", "download.file_error"}
 	}
-
-	// Xabar ID'sini saqlaymiz (keyinchalik tugmani o‘chirish uchun)
-	messageID := sentMsg.MessageID
-	state.SaveMessageID(chatID, messageID)
+	return videoFile, nil
 }
 
 // 📌 7️⃣ Yuklab olish funksiyasi
@@ -122,12 +149,19 @@ func downloadFile(fileURL, prefix, ext string) (string, error) {
 	return fileName, nil
 }
 
-// 📌 8️⃣ ffmpeg yordamida audioni ajratish (audio.mp3 nomi bilan)
+// 📌 8️⃣ ffmpeg yordamida audioni alohida vaqtinchalik .mp3 faylga ajratish
 func extractAudio(videoFile string) (string, error) {
-	audioFile := "audio.mp3" // 🎯 Faqat "audio.mp3" nomi bilan ajratamiz
+	out, err := os.CreateTemp("", "audio_*.mp3")
+	if err != nil {
+		return "", err
+	}
+	audioFile := out.Name()
+	out.Close()
 
+	// Fayl oldindan yaratilgani uchun ffmpeg uni -y bilan qayta yozadi
 	cmd := exec.Command("ffmpeg", "-i", videoFile, "-vn", "-acodec", "libmp3lame", "-y", audioFile)
 	if err := cmd.Run(); err != nil {
+		os.Remove(audioFile)
 		return "", err
 	}
 	return audioFile, nil
@@ -138,14 +172,14 @@ func downloadAndSendInstaAudio(chatID int64, videoFile string, botInstance *tgbo
 	// ffmpeg yordamida audio ajratamiz
 	audioFile, err := extractAudio(videoFile)
 	if err != nil {
-		botInstance.Send(tgbotapi.NewMessage(chatID, "❌ Audio ajratishda xatolik yuz berdi."))
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, i18n.For(chatID).T("download.audio_error")))
 		return
 	}
 
 	// Audio faylni foydalanuvchiga yuborish
 	audioMsg := tgbotapi.NewAudioUpload(chatID, audioFile)
-	audioMsg.Caption = "Mana videoning audio fayli:"
-	if _, err := botInstance.Send(audioMsg); err != nil {
+	audioMsg.Caption = i18n.For(chatID).T("download.audio_caption")
+	if _, err := sender.Send(botInstance, audioMsg); err != nil {
 		log.Printf("Audio yuborishda xatolik: %v", err)
 	}
 	defer os.Remove(audioFile) // 🎯 Audio faylni yuborgach o‘chirib tashlaymiz
@@ -0,0 +1,50 @@
+package handle
+
+import (
+	"database/sql"
+	"log"
+	"strings"
+	"yuklovchiBot/admin"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
+	"yuklovchiBot/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// sendLanguagePicker til tanlash tugmalarini yuboradi (/lang va birinchi /start)
+func sendLanguagePicker(chatID int64, lang i18n.Lang, botInstance *tgbotapi.BotAPI) {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, l := range i18n.Languages {
+		button := tgbotapi.NewInlineKeyboardButtonData(l.Name(), "lang|"+string(l))
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(button))
+	}
+
+	msg := tgbotapi.NewMessage(chatID, lang.T("lang.choose"))
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	sender.Send(botInstance, msg)
+}
+
+// handleLanguageChoice tanlangan tilni saqlaydi. Til birinchi marta tanlangan bo'lsa,
+// /start davom ettiriladi. data: "lang|<til>"
+func handleLanguageChoice(callbackQuery *tgbotapi.CallbackQuery, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	chatID := callbackQuery.Message.Chat.ID
+	lang := i18n.Parse(strings.TrimPrefix(callbackQuery.Data, "lang|"))
+
+	previous, _, err := storage.GetUserLanguage(db, chatID)
+	if err != nil {
+		log.Printf("Error getting user language: %v", err)
+	}
+	if err := storage.SetUserLanguage(db, chatID, string(lang)); err != nil {
+		log.Printf("Error saving user language: %v", err)
+	}
+	i18n.Set(chatID, lang)
+	// Admin buyruqlari menyusi ham yangi tilda bo'lishi uchun
+	go admin.SyncCommands(chatID, db, botInstance)
+
+	sender.Send(botInstance, tgbotapi.NewEditMessageText(chatID, callbackQuery.Message.MessageID, lang.T("lang.changed", lang.Name())))
+
+	if previous == "" && err == nil {
+		sendWelcome(chatID, callbackQuery.From, db, botInstance)
+	}
+}
@@ -0,0 +1,68 @@
+package handle
+
+import (
+	"sync"
+	"time"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// DownloadRateLimit bitta foydalanuvchi bir daqiqada shuncha yuklash so'rovi yuborishi mumkin,
+// 0 bo'lsa cheklov yo'q (main'da config'dan o'rnatiladi). Shaxsiy chat va inline rejimga birdek qo'llanadi.
+var DownloadRateLimit = 10
+
+const rateWindow = time.Minute
+
+var (
+	rateMu   sync.Mutex
+	rateHits = make(map[int64][]time.Time)
+)
+
+// allowDownload so'rovni hisobga oladi. Limit tugagan bo'lsa false va keyingi so'rovgacha qolgan vaqtni qaytaradi.
+func allowDownload(userID int64) (bool, time.Duration) {
+	if DownloadRateLimit <= 0 {
+		return true, 0
+	}
+
+	rateMu.Lock()
+	defer rateMu.Unlock()
+
+	now := time.Now()
+	hits := rateHits[userID][:0]
+	for _, t := range rateHits[userID] {
+		if now.Sub(t) < rateWindow {
+			hits = append(hits, t)
+		}
+	}
+
+	if len(hits) >= DownloadRateLimit {
+		rateHits[userID] = hits
+		return false, rateWindow - now.Sub(hits[0])
+	}
+
+	// Kesh cheksiz o'smasligi uchun eski yozuvlarni tozalaymiz
+	if len(rateHits) > 100000 {
+		rateHits = make(map[int64][]time.Time)
+	}
+	rateHits[userID] = append(hits, now)
+	return true, 0
+}
+
+// requireRateLimit limit tugagan bo'lsa foydalanuvchiga qancha kutishni aytadi va false qaytaradi
+func requireRateLimit(chatID int64, botInstance *tgbotapi.BotAPI) bool {
+	ok, wait := allowDownload(chatID)
+	if ok {
+		return true
+	}
+
+	msg := tgbotapi.NewMessage(chatID, i18n.For(chatID).T("download.rate_limited", waitSeconds(wait)))
+	sender.Send(botInstance, msg)
+	return false
+}
+
+// waitSeconds kutish vaqtini foydalanuvchiga ko'rsatish uchun yuqoriga yaxlitlaydi
+func waitSeconds(wait time.Duration) int {
+	return int((wait + time.Second - 1) / time.Second)
+}
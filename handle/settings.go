@@ -0,0 +1,105 @@
+package handle
+
+import (
+	"database/sql"
+	"log"
+	"strings"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
+	"yuklovchiBot/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// sendSettings foydalanuvchi sozlamalari menyusi. messageID 0 bo'lmasa, mavjud xabar yangilanadi.
+func sendSettings(chatID int64, messageID int, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	lang := i18n.For(chatID)
+	settings := userSettings(chatID, db)
+
+	quality := lang.T("settings.quality." + settings.YouTubeQuality)
+	if settings.YouTubeQuality != models.QualityAsk && settings.YouTubeQuality != models.QualityAudio {
+		quality = settings.YouTubeQuality + "p"
+	}
+	sendAs := lang.T("settings.send_as.video")
+	if settings.AsDocument {
+		sendAs = lang.T("settings.send_as.document")
+	}
+
+	button := func(text, data string) []tgbotapi.InlineKeyboardButton {
+		return tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(text, "settings|"+data))
+	}
+	inlineKeyboard := tgbotapi.NewInlineKeyboardMarkup(
+		button(lang.T("settings.lang", lang.Name()), "lang"),
+		button(lang.T("settings.audio", lang.T("settings.audio."+settings.AudioMode)), "audio"),
+		button(lang.T("settings.quality", quality), "quality"),
+		button(lang.T("settings.send_as", sendAs), "document"),
+		button(lang.T("settings.caption", onOff(lang, settings.Caption)), "caption"),
+		button(lang.T("settings.watermark", onOff(lang, settings.NoWatermark)), "watermark"),
+	)
+
+	if messageID != 0 {
+		editMsg := tgbotapi.NewEditMessageText(chatID, messageID, lang.T("settings.title"))
+		editMsg.ReplyMarkup = &inlineKeyboard
+		sender.Send(botInstance, editMsg)
+		return
+	}
+
+	msgResponse := tgbotapi.NewMessage(chatID, lang.T("settings.title"))
+	msgResponse.ReplyMarkup = inlineKeyboard
+	sender.Send(botInstance, msgResponse)
+}
+
+// handleSettingsCallback sozlamalar tugmalari: har bosilganda qiymat navbatdagisiga o'tadi.
+// data: "settings|<lang|audio|quality|document|caption|watermark>"
+func handleSettingsCallback(chatID int64, messageID int, data string, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	option := strings.TrimPrefix(data, "settings|")
+	if option == "lang" {
+		sendLanguagePicker(chatID, i18n.For(chatID), botInstance)
+		return
+	}
+
+	settings, err := storage.GetUserSettings(db, chatID)
+	if err != nil {
+		log.Printf("Error getting user settings: %v", err)
+		return
+	}
+
+	switch option {
+	case "audio":
+		settings.AudioMode = nextOption(models.AudioModes, settings.AudioMode)
+	case "quality":
+		settings.YouTubeQuality = nextOption(models.YouTubeQualities, settings.YouTubeQuality)
+	case "document":
+		settings.AsDocument = !settings.AsDocument
+	case "caption":
+		settings.Caption = !settings.Caption
+	case "watermark":
+		settings.NoWatermark = !settings.NoWatermark
+	default:
+		log.Printf("Unknown settings option: %s", option)
+		return
+	}
+
+	if err := storage.SaveUserSettings(db, chatID, settings); err != nil {
+		log.Printf("Error saving user settings: %v", err)
+		return
+	}
+	sendSettings(chatID, messageID, db, botInstance)
+}
+
+func nextOption(options []string, current string) string {
+	for i, option := range options {
+		if option == current {
+			return options[(i+1)%len(options)]
+		}
+	}
+	return options[0]
+}
+
+func onOff(lang i18n.Lang, on bool) string {
+	if on {
+		return lang.T("settings.on")
+	}
+	return lang.T("settings.off")
+}
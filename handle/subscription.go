@@ -0,0 +1,70 @@
+package handle
+
+import (
+	"database/sql"
+	"log"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
+	"yuklovchiBot/pkg/telegram"
+	"yuklovchiBot/storage"
+	"yuklovchiBot/subscription"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// requireSubscription har bir yuklashdan oldin chaqiriladi. Foydalanuvchi obuna bo'lmagan
+// bo'lsa, qolgan kanallar ro'yxatini yuboradi va false qaytaradi.
+func requireSubscription(chatID int64, db *sql.DB, botInstance *tgbotapi.BotAPI) bool {
+	ok, missing := subscription.Check(chatID, db, botInstance)
+	if ok {
+		return true
+	}
+
+	sendSubscriptionGate(chatID, missing, db, botInstance)
+	return false
+}
+
+// sendSubscriptionGate obuna bo'linmagan kanallar tugmalarini yuboradi va ko'rsatilganini hisobotga yozadi
+func sendSubscriptionGate(chatID int64, missing []models.Channel, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	subscription.GateShown(chatID, missing, db)
+
+	lang := i18n.For(chatID)
+	msg := tgbotapi.NewMessage(chatID, lang.T("sub.gate"))
+	msg.ReplyMarkup = createSubscriptionKeyboard(lang, missing)
+	sender.Send(botInstance, msg)
+}
+
+func createSubscriptionKeyboard(lang i18n.Lang, channels []models.Channel) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, channel := range channels {
+		if channel.URL() == "" {
+			continue
+		}
+		button := tgbotapi.NewInlineKeyboardButtonURL("➕ "+channel.Name(), channel.URL())
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(button))
+	}
+	checkButton := tgbotapi.NewInlineKeyboardButtonData(lang.T("sub.check"), "check_subscription")
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(checkButton))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// handleChatJoinRequest majburiy obuna kanaliga yuborilgan so'rovni saqlaydi, shu bilan foydalanuvchi obuna bo'lgan hisoblanadi
+func handleChatJoinRequest(req *telegram.ChatJoinRequest, db *sql.DB, botInstance *tgbotapi.BotAPI) {
+	tracked, err := storage.AddJoinRequest(db, req.Chat.ID, int64(req.From.ID))
+	if err != nil {
+		log.Printf("Error saving join request: %v", err)
+		return
+	}
+	if !tracked {
+		return
+	}
+	subscription.Remember(int64(req.From.ID), req.Chat.ID)
+	if req.UserChatID == 0 {
+		return
+	}
+
+	msg := tgbotapi.NewMessage(req.UserChatID, i18n.For(int64(req.From.ID)).T("sub.join_request", req.Chat.Title))
+	sender.Send(botInstance, msg)
+}
@@ -1,6 +1,7 @@
 package handle
 
 import (
+	"database/sql"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -11,13 +12,16 @@ import (
 	"os"
 	"regexp"
 	"time"
-	"yuklovchiBot/pkg/state"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
 )
 
 // TikTok API javob strukturasini e'lon qilamiz
 type TikTokResponse struct {
 	Data struct {
-		Play string `json:"play"`
+		Play   string `json:"play"`
+		WmPlay string `json:"wmplay"`
 	} `json:"data"`
 }
 
@@ -31,14 +35,15 @@ func extractVideoID(url string) string {
 	return "video"
 }
 
-// downloadTikTokVideo - berilgan TikTok video linki bo'yicha videoni yuklab olib,
-// "videos" papkasiga saqlaydi va fayl yo'lini qaytaradi.
-func downloadTikTokVideo(videoLink string) (string, error) {
+// downloadTikTokVideo - berilgan TikTok video linki bo'yicha videoni yuklab olib, har bir yuklash uchun
+// alohida vaqtinchalik faylga saqlaydi va fayl yo'lini qaytaradi. noWatermark false bo'lsa, suv belgili video olinadi.
+func downloadTikTokVideo(videoLink string, noWatermark bool) (string, error) {
 	// Video ID ni ajratish
 	videoID := extractVideoID(videoLink)
 	if videoID == "video" {
 		return "", fmt.Errorf("invalid video URL")
 	}
+	defer acquireDownloadSlot()()
 
 	// API URL'ni olish uchun Base64 kodlangan satrni dekodlash
 	base64URL := "aHR0cHM6Ly90aWt3bS5jb20vYXBpLw=="
@@ -73,30 +78,27 @@ func downloadTikTokVideo(videoLink string) (string, error) {
 
 	// Video URL orqali video faylini yuklab olish
 	videoDownloadURL := videoResp.Data.Play
+	if !noWatermark && videoResp.Data.WmPlay != "" {
+		videoDownloadURL = videoResp.Data.WmPlay
+	}
 	downloadResp, err := client.Get(videoDownloadURL)
 	if err != nil {
 		return "", fmt.Errorf("error downloading video: %w", err)
 	}
 	defer downloadResp.Body.Close()
 
-	// "videos" papkasini mavjudligini tekshirish va agar mavjud bo'lmasa yaratish
-	if _, err := os.Stat("videos"); os.IsNotExist(err) {
-		if err = os.Mkdir("videos", os.ModePerm); err != nil {
-			return "", fmt.Errorf("error creating videos folder: %w", err)
-		}
-	}
-
-	// Video faylini saqlash uchun fayl nomini yaratish (masalan, videoID.mp4)
-	filePath := fmt.Sprintf("videos/%s.mp4", videoID)
-	outFile, err := os.Create(filePath)
+	// Bir xil havola parallel yuklanganda fayllar bir-birini bosib ketmasligi uchun nom noyob bo'ladi
+	outFile, err := os.CreateTemp("", "tiktok_*.mp4")
 	if err != nil {
 		return "", fmt.Errorf("error creating video file: %w", err)
 	}
 	defer outFile.Close()
+	filePath := outFile.Name()
 
 	// Video faylini diskka yozish
 	_, err = io.Copy(outFile, downloadResp.Body)
 	if err != nil {
+		os.Remove(filePath)
 		return "", fmt.Errorf("error saving video file: %w", err)
 	}
 
@@ -104,12 +106,12 @@ func downloadTikTokVideo(videoLink string) (string, error) {
 }
 
 // 📌 TikTok videoni yuklab olish va foydalanuvchiga yuborish
-func downloadAndSendTikTokVideo(chatID int64, videoURL string, botInstance *tgbotapi.BotAPI, loadingMsgID int) {
+func downloadAndSendTikTokVideo(chatID int64, videoURL string, db *sql.DB, botInstance *tgbotapi.BotAPI, loadingMsgID int) {
 
 	loadingDeleted := false
 	deleteLoading := func() {
 		if !loadingDeleted && loadingMsgID != 0 {
-			_, err := botInstance.Send(tgbotapi.NewDeleteMessage(chatID, loadingMsgID))
+			_, err := sender.Send(botInstance, tgbotapi.NewDeleteMessage(chatID, loadingMsgID))
 			if err != nil {
 				log.Printf("Loading xabarini o'chirishda xatolik: %v", err)
 			}
@@ -117,31 +119,44 @@ func downloadAndSendTikTokVideo(chatID int64, videoURL string, botInstance *tgbo
 		}
 	}
 
+	tracker := startDownload(db, chatID, models.PlatformTikTok, videoURL)
+	settings := userSettings(chatID, db)
+	cacheKey := videoURL
+	if !settings.NoWatermark {
+		cacheKey += "|wm"
+	}
+	cacheKey = mediaCacheKey(cacheKey, settings)
+
+	// Havola avval yuklangan bo'lsa, keshdan yuboramiz
+	if sendCachedVideo(chatID, cacheKey, "tiktok", settings, tracker, db, botInstance) {
+		deleteLoading()
+		return
+	}
+
 	// TikTok video faylini yuklab olib, lokal yo'lni olamiz.
-	filePath, err := downloadTikTokVideo(videoURL)
+	filePath, err := downloadTikTokVideo(videoURL, settings.NoWatermark)
 	if err != nil {
+		tracker.fail("download")
 		deleteLoading()
-		botInstance.Send(tgbotapi.NewMessage(chatID, fmt.Sprintf("❌ Video yuklab olishda xatolik yuz berdi: %s", err)))
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, i18n.For(chatID).T("download.error_detail", err)))
 		return
 	}
 
 	deleteLoading()
 
-	// Video yuborish uchun Telegram video xabarini yaratamiz.
-	videoMsg := tgbotapi.NewVideoUpload(chatID, filePath)
-	videoMsg.Caption = "Siz so‘ragan video.\n\nAudiosini yuklashni istaysizmi?"
-	// createAudioOptionKeyboard - foydalanuvchiga audio yuklash opsiyasini taklif qiluvchi tugmalarni yaratadi.
-	videoMsg.ReplyMarkup = createAudioOptionKeyboard("tiktok", filePath)
-
-	sentMsg, err := botInstance.Send(videoMsg)
+	// Videoni foydalanuvchi sozlamalari bo'yicha yuboramiz
+	size := fileSize(filePath)
+	sentMsg, err := deliverVideo(chatID, filePath, "", "tiktok", settings, tracker, db, botInstance)
 	if err != nil {
 		log.Printf("Video yuborishda xatolik: %v", err)
+		tracker.fail("send")
+		removeVideoFile(filePath)
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, i18n.For(chatID).T("download.error_detail", err)))
 		return
 	}
 
-	// Xabar ID'sini saqlaymiz (keyinchalik tugmalarni o‘chirish uchun)
-	state.SaveMessageID(chatID, sentMsg.MessageID)
-
+	tracker.success(sentMsg, "", size, false)
+	cacheSentMedia(db, cacheKey, models.PlatformTikTok, sentMsg, "", size)
 }
 
 // 📌 TikTok videodan audio ajratish va foydalanuvchiga yuborish
@@ -149,14 +164,14 @@ func downloadAndSendTikTokAudio(chatID int64, videoFile string, botInstance *tgb
 	audioFile, err := extractAudio(videoFile)
 	if err != nil {
 
-		botInstance.Send(tgbotapi.NewMessage(chatID, "❌ Audio ajratishda xatolik yuz berdi."))
+		sender.Send(botInstance, tgbotapi.NewMessage(chatID, i18n.For(chatID).T("download.audio_error")))
 		return
 	}
 
 	// Audio faylni foydalanuvchiga yuborish
 	audioMsg := tgbotapi.NewAudioUpload(chatID, audioFile)
-	audioMsg.Caption = "Mana videoning audio fayli:"
-	if _, err := botInstance.Send(audioMsg); err != nil {
+	audioMsg.Caption = i18n.For(chatID).T("download.audio_caption")
+	if _, err := sender.Send(botInstance, audioMsg); err != nil {
 		log.Printf("Audio yuborishda xatolik: %v", err)
 	}
 	defer os.Remove(audioFile)
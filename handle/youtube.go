@@ -1,15 +1,21 @@
 package handle
 
 import (
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+	"yuklovchiBot/models"
+	"yuklovchiBot/pkg/i18n"
+	"yuklovchiBot/pkg/sender"
 	"yuklovchiBot/pkg/state"
+	"yuklovchiBot/storage"
 )
 
 type YouTubeMetadata struct {
@@ -34,21 +40,14 @@ var YouTubeVideoLinkCache = make(map[int64]string)
 var YouTubeVideoInfo = make(map[int64]YouTubeMetadata)
 
 // Foydalanuvchi YouTube link yuborganda chaqiriladigan asosiy funksiya
-func HandleYouTubeLink(chatID int64, videoURL string, bot *tgbotapi.BotAPI) error {
+func HandleYouTubeLink(chatID int64, videoURL string, db *sql.DB, bot *tgbotapi.BotAPI) error {
 	// 0) Linkni cache’da saqlaymiz
 	YouTubeVideoLinkCache[chatID] = videoURL
 
 	// 1) `yt-dlp --dump-json` orqali metadata olish
-	cmd := exec.Command("yt-dlp", "--dump-json", videoURL)
-	output, err := cmd.Output()
+	meta, err := fetchYouTubeMetadata(videoURL)
 	if err != nil {
-		return fmt.Errorf("yt-dlp bilan metadata olishda xatolik: %v", err)
-	}
-
-	// 2) JSON’ni parse qilish
-	var meta YouTubeMetadata
-	if err := json.Unmarshal(output, &meta); err != nil {
-		return fmt.Errorf("JSON parse xatosi: %v", err)
+		return err
 	}
 	// Keshga saqlaymiz
 	YouTubeVideoInfo[chatID] = meta
@@ -56,18 +55,27 @@ func HandleYouTubeLink(chatID int64, videoURL string, bot *tgbotapi.BotAPI) erro
 	// 3) 360p, 480p, 720p, 1080p rezlar orasidan eng kattasi + eng yaxshi audio
 	largestByRes, bestAudio := filterLargestFormats(meta)
 
+	// Sozlamalarda sifat tanlangan bo'lsa, ro'yxatsiz darhol yuklaymiz
+	if quality := userSettings(chatID, db).YouTubeQuality; quality != models.QualityAsk {
+		if formatID := preferredYouTubeFormat(largestByRes, bestAudio, quality); formatID != "" {
+			downloadYouTubeFormat(chatID, formatID, db, bot)
+			return nil
+		}
+	}
+
 	// 4) InlineKeyboard tayyorlash
-	kb := buildInlineKeyboardForLargestFormats(largestByRes, bestAudio)
+	lang := i18n.For(chatID)
+	kb := buildInlineKeyboardForLargestFormats(lang, largestByRes, bestAudio)
 
 	// 5) Xabarni yuborish
 	durStr := formatDuration(meta.Duration)
-	caption := fmt.Sprintf("*%s*\nDuration: %s\nChoose format to download:", meta.Title, durStr)
+	caption := lang.T("youtube.caption", meta.Title, durStr)
 
 	msg := tgbotapi.NewMessage(chatID, caption)
 	msg.ParseMode = "Markdown"
 	msg.ReplyMarkup = kb
 
-	sentMsg, err := bot.Send(msg)
+	sentMsg, err := sender.Send(bot, msg)
 	if err != nil {
 		return err
 	}
@@ -77,6 +85,21 @@ func HandleYouTubeLink(chatID int64, videoURL string, bot *tgbotapi.BotAPI) erro
 	return nil
 }
 
+// fetchYouTubeMetadata `yt-dlp --dump-json` orqali video nomi, davomiyligi va formatlarini oladi
+func fetchYouTubeMetadata(videoURL string) (YouTubeMetadata, error) {
+	var meta YouTubeMetadata
+	cmd := exec.Command("yt-dlp", "--dump-json", videoURL)
+	output, err := cmd.Output()
+	if err != nil {
+		return meta, fmt.Errorf("yt-dlp bilan metadata olishda xatolik: %v", err)
+	}
+
+	if err := json.Unmarshal(output, &meta); err != nil {
+		return meta, fmt.Errorf("JSON parse xatosi: %v", err)
+	}
+	return meta, nil
+}
+
 // filterLargestFormats: har bir (360, 480, 720, 1080)p uchun eng katta faylni va eng yaxshi audio’ni tanlaydi
 func filterLargestFormats(meta YouTubeMetadata) (map[int]YouTubeFormat, *YouTubeFormat) {
 	desiredResolutions := []int{360, 480, 720, 1080}
@@ -120,7 +143,7 @@ func filterLargestFormats(meta YouTubeMetadata) (map[int]YouTubeFormat, *YouTube
 }
 
 // buildInlineKeyboardForLargestFormats: topilgan formatlar uchun tugmalar yaratadi
-func buildInlineKeyboardForLargestFormats(largestByRes map[int]YouTubeFormat, bestAudio *YouTubeFormat) tgbotapi.InlineKeyboardMarkup {
+func buildInlineKeyboardForLargestFormats(lang i18n.Lang, largestByRes map[int]YouTubeFormat, bestAudio *YouTubeFormat) tgbotapi.InlineKeyboardMarkup {
 	sortedRes := []int{360, 480, 720, 1080}
 	var rows [][]tgbotapi.InlineKeyboardButton
 
@@ -140,7 +163,7 @@ func buildInlineKeyboardForLargestFormats(largestByRes map[int]YouTubeFormat, be
 	// Audio
 	if bestAudio != nil {
 		sizeMB := bestAudio.Filesize / 1024 / 1024
-		btnText := fmt.Sprintf("Audio - %.1fMB", sizeMB)
+		btnText := lang.T("youtube.audio_button", sizeMB)
 		callbackData := fmt.Sprintf("youtube_download|%s", bestAudio.FormatID)
 		audioButton := tgbotapi.NewInlineKeyboardButtonData(btnText, callbackData)
 		rows = append(rows, tgbotapi.NewInlineKeyboardRow(audioButton))
@@ -163,82 +186,168 @@ func formatDuration(d float64) string {
 // ------------------------------------------------------
 
 // CallbackQuery dan keladigan ma’lumotni (youtube_download|<format_id>) qayta ishlash
-func HandleYouTubeDownloadCallback(chatID int64, messageID int, data string, bot *tgbotapi.BotAPI) {
+func HandleYouTubeDownloadCallback(chatID int64, messageID int, data string, db *sql.DB, bot *tgbotapi.BotAPI) {
 	// data: "youtube_download|<format_id>"
 	parts := strings.SplitN(data, "|", 2)
 	if len(parts) != 2 {
 		log.Println("Noto'g'ri callback data: ", data)
 		return
 	}
-	chosenFormatID := parts[1]
 
+	if downloadYouTubeFormat(chatID, parts[1], db, bot) {
+		RemoveInlineKeyboardAndUpdateCaption(chatID, db, bot)
+	}
+}
+
+// downloadYouTubeFormat tanlangan formatni foydalanuvchi sozlamalari bo'yicha yuboradi.
+// Yuborishgacha xatolik bo'lsa false qaytaradi (format tugmalari qayta urinish uchun qoladi).
+func downloadYouTubeFormat(chatID int64, chosenFormatID string, db *sql.DB, bot *tgbotapi.BotAPI) bool {
 	// 1) Original link + metadata ni keshdan olamiz
 	link, ok := YouTubeVideoLinkCache[chatID]
 	if !ok {
 		log.Printf("ChatID %d uchun link topilmadi", chatID)
-		return
+		return false
 	}
 	meta, ok := YouTubeVideoInfo[chatID]
 	if !ok {
 		log.Printf("ChatID %d uchun metadata topilmadi", chatID)
-		return
+		return false
+	}
+
+	settings := userSettings(chatID, db)
+	caption := ""
+	if settings.Caption {
+		caption = meta.Title
+	}
+
+	tracker := startDownload(db, chatID, models.PlatformYouTube, link)
+	cacheKey := mediaCacheKey(link+"|"+chosenFormatID, settings)
+
+	// Shu format avval yuklangan bo'lsa, file_id orqali yuboramiz
+	if entry, ok, _ := storage.GetMediaCache(db, cacheKey); ok {
+		var sentMsg tgbotapi.Message
+		var err error
+		if entry.MediaType == models.MediaAudio {
+			audioMsg := tgbotapi.NewAudioShare(chatID, entry.FileID)
+			audioMsg.Caption = caption
+			sentMsg, err = sender.Send(bot, audioMsg)
+		} else {
+			sentMsg, err = sender.Send(bot, videoMessage(chatID, "", entry.FileID, entry.MediaType == models.MediaDocument, caption, nil))
+		}
+		if err == nil {
+			tracker.success(sentMsg, meta.Title, entry.Bytes, true)
+			return true
+		}
+		log.Printf("Keshdagi faylni yuborishda xatolik: %v", err)
+		storage.DeleteMediaCache(db, cacheKey)
 	}
 
 	// 2) Tanlangan formatni lokalga yuklab olamiz
 	downloadedFile, err := downloadSpecificFormat(link, chosenFormatID)
 	if err != nil {
 		log.Printf("Format yuklashda xatolik: %v", err)
-		bot.Send(tgbotapi.NewMessage(chatID, "Tanlangan formatni yuklashda xatolik yuz berdi."))
-		return
+		tracker.fail("format_download")
+		sender.Send(bot, tgbotapi.NewMessage(chatID, i18n.For(chatID).T("youtube.format_error")))
+		return false
 	}
+	defer removeVideoFile(downloadedFile)
 
 	// 3) 2GB dan oshmaganligini tekshirish
-	fileInfo, err := os.Stat(downloadedFile)
-	if err == nil {
-		if fileInfo.Size() > 50*1024*1024 {
-			bot.Send(tgbotapi.NewMessage(chatID, "Kechirasiz, fayl hajmi 50mb dan oshdi. Jo'nata olmayman."))
-			// Faylni o'chirishni xohlasangiz:
-			os.Remove(downloadedFile)
-			return
-		}
+	size := fileSize(downloadedFile)
+	if size > maxUploadSize {
+		tracker.fail("too_large")
+		sender.Send(bot, tgbotapi.NewMessage(chatID, i18n.For(chatID).T("youtube.too_large")))
+		return false
 	}
 
 	// 4) Audio yoki Video ekanligini aniqlash
-	isAudio := false
-	for _, f := range meta.Formats {
-		if f.FormatID == chosenFormatID && f.Vcodec == "none" {
-			isAudio = true
-			break
-		}
-	}
+	isAudio := isAudioFormat(meta, chosenFormatID)
 
 	// 5) Yuborish
+	var sentMsg tgbotapi.Message
 	if isAudio {
 		audioMsg := tgbotapi.NewAudioUpload(chatID, downloadedFile)
-		audioMsg.Caption = meta.Title
-		if _, err := bot.Send(audioMsg); err != nil {
+		audioMsg.Caption = caption
+		if sentMsg, err = sender.Send(bot, audioMsg); err != nil {
 			log.Printf("Audio yuborishda xatolik: %v", err)
 		}
 	} else {
-		videoMsg := tgbotapi.NewVideoUpload(chatID, downloadedFile)
-		videoMsg.Caption = meta.Title
-		if _, err := bot.Send(videoMsg); err != nil {
+		if sentMsg, err = sender.Send(bot, videoMessage(chatID, downloadedFile, "", settings.AsDocument, caption, nil)); err != nil {
 			log.Printf("Video yuborishda xatolik: %v", err)
 		}
 	}
 
-	RemoveInlineKeyboardAndUpdateCaption(chatID, bot)
+	// 6) Natijani statistikaga va keshga yozamiz
+	if err != nil {
+		tracker.fail("send")
+	} else {
+		tracker.success(sentMsg, meta.Title, size, false)
+		cacheSentMedia(db, cacheKey, models.PlatformYouTube, sentMsg, meta.Title, size)
+	}
+	return true
+}
+
+// preferredYouTubeFormat sozlamadagi sifatga mos formatni tanlaydi: shu balandlikdan oshmaydigan
+// eng kattasi, bunday bo'lmasa eng kichigi. Mos format topilmasa "" qaytaradi.
+func preferredYouTubeFormat(largestByRes map[int]YouTubeFormat, bestAudio *YouTubeFormat, quality string) string {
+	if quality == models.QualityAudio {
+		if bestAudio == nil {
+			return ""
+		}
+		return bestAudio.FormatID
+	}
+
+	want, err := strconv.Atoi(quality)
+	if err != nil {
+		return ""
+	}
 
-	// 7) Faylni o'chirishni istasangiz
-	// os.Remove(downloadedFile)
+	best := 0
+	for res := range largestByRes {
+		if res <= want && res > best {
+			best = res
+		}
+	}
+	if best == 0 {
+		for res := range largestByRes {
+			if best == 0 || res < best {
+				best = res
+			}
+		}
+	}
+	if best == 0 {
+		return ""
+	}
+	return largestByRes[best].FormatID
 }
 
-// downloadSpecificFormat: `yt-dlp` bilan tanlangan formatni yuklab, lokalga saqlaydi
+// isAudioFormat format faqat audio (videosiz) ekanini aniqlaydi
+func isAudioFormat(meta YouTubeMetadata, formatID string) bool {
+	for _, f := range meta.Formats {
+		if f.FormatID == formatID && f.Vcodec == "none" {
+			return true
+		}
+	}
+	return false
+}
+
+// downloadSpecificFormat: `yt-dlp` bilan tanlangan formatni har bir yuklash uchun alohida vaqtinchalik
+// faylga saqlaydi. Faylni chaqiruvchi o'chiradi.
 func downloadSpecificFormat(videoURL, formatID string) (string, error) {
-	outName := fmt.Sprintf("youtube_%s.mp4", formatID) // xohlasangiz random nom yarating
-	cmd := exec.Command("yt-dlp", "-f", formatID, "-o", outName, videoURL)
+	defer acquireDownloadSlot()()
+
+	out, err := os.CreateTemp("", "youtube_*.mp4")
+	if err != nil {
+		return "", err
+	}
+	outName := out.Name()
+	out.Close()
+
+	// Fayl oldindan yaratilgani uchun yt-dlp uni "allaqachon yuklangan" deb o'tkazib yubormasligi kerak
+	cmd := exec.Command("yt-dlp", "-f", formatID, "--force-overwrites", "-o", outName, videoURL)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
+		os.Remove(outName)
 		return "", fmt.Errorf("'%s' formatni yuklashda xatolik: %v - %s", formatID, err, string(output))
 	}
 	return outName, nil
@@ -0,0 +1,3 @@
+DROP TABLE broadcast_recipients;
+
+DROP TABLE broadcasts;
@@ -0,0 +1,21 @@
+CREATE TABLE broadcasts (
+    id BIGSERIAL PRIMARY KEY,
+    admin_id BIGINT NOT NULL,
+    source_chat_id BIGINT NOT NULL,
+    source_message_id INT NOT NULL,
+    progress_message_id INT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'running',
+    created_at TIMESTAMP DEFAULT NOW(),
+    finished_at TIMESTAMP
+);
+
+CREATE TABLE broadcast_recipients (
+    broadcast_id BIGINT NOT NULL REFERENCES broadcasts (id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    error TEXT,
+    sent_at TIMESTAMP,
+    PRIMARY KEY (broadcast_id, user_id)
+);
+
+CREATE INDEX broadcast_recipients_pending_idx ON broadcast_recipients (broadcast_id) WHERE status = 'pending';
@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN status_updated_at;
+
+ALTER TABLE users DROP COLUMN status;
@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'active';
+
+ALTER TABLE users ADD COLUMN status_updated_at TIMESTAMP;
@@ -0,0 +1,13 @@
+CREATE TABLE scheduled_messages (
+    id BIGSERIAL PRIMARY KEY,
+    admin_id BIGINT NOT NULL,
+    source_chat_id BIGINT NOT NULL,
+    source_message_id INT NOT NULL,
+    run_at TIMESTAMPTZ NOT NULL,
+    repeat VARCHAR(20) NOT NULL DEFAULT 'once',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    last_broadcast_id BIGINT REFERENCES broadcasts (id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX scheduled_messages_due_idx ON scheduled_messages (run_at) WHERE status = 'pending';
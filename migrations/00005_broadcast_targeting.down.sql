@@ -0,0 +1,17 @@
+DROP TABLE broadcast_clicks;
+
+ALTER TABLE broadcast_recipients DROP COLUMN variant;
+
+ALTER TABLE broadcasts DROP COLUMN button_url;
+
+ALTER TABLE broadcasts DROP COLUMN button_text;
+
+ALTER TABLE broadcasts DROP COLUMN variant_b_message_id;
+
+ALTER TABLE broadcasts DROP COLUMN audience;
+
+DROP TABLE downloads;
+
+ALTER TABLE users DROP COLUMN last_active_at;
+
+ALTER TABLE users DROP COLUMN language_code;
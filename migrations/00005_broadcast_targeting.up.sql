@@ -0,0 +1,30 @@
+ALTER TABLE users ADD COLUMN language_code VARCHAR(10);
+
+ALTER TABLE users ADD COLUMN last_active_at TIMESTAMP;
+
+CREATE TABLE downloads (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    platform VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX downloads_user_platform_idx ON downloads (user_id, platform);
+
+ALTER TABLE broadcasts ADD COLUMN audience VARCHAR(50) NOT NULL DEFAULT 'all';
+
+ALTER TABLE broadcasts ADD COLUMN variant_b_message_id INT;
+
+ALTER TABLE broadcasts ADD COLUMN button_text TEXT;
+
+ALTER TABLE broadcasts ADD COLUMN button_url TEXT;
+
+ALTER TABLE broadcast_recipients ADD COLUMN variant CHAR(1) NOT NULL DEFAULT 'A';
+
+CREATE TABLE broadcast_clicks (
+    broadcast_id BIGINT NOT NULL REFERENCES broadcasts (id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL,
+    variant CHAR(1) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (broadcast_id, user_id)
+);
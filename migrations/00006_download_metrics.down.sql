@@ -0,0 +1,21 @@
+DROP TABLE media_cache;
+
+DROP INDEX downloads_created_at_idx;
+
+ALTER TABLE downloads DROP COLUMN title;
+
+ALTER TABLE downloads DROP COLUMN file_id;
+
+ALTER TABLE downloads DROP COLUMN media_type;
+
+ALTER TABLE downloads DROP COLUMN cached;
+
+ALTER TABLE downloads DROP COLUMN bytes;
+
+ALTER TABLE downloads DROP COLUMN duration_ms;
+
+ALTER TABLE downloads DROP COLUMN error;
+
+ALTER TABLE downloads DROP COLUMN status;
+
+ALTER TABLE downloads DROP COLUMN source_url;
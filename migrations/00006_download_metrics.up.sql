@@ -0,0 +1,29 @@
+ALTER TABLE downloads ADD COLUMN source_url TEXT;
+
+ALTER TABLE downloads ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'pending';
+
+ALTER TABLE downloads ADD COLUMN error TEXT;
+
+ALTER TABLE downloads ADD COLUMN duration_ms INT;
+
+ALTER TABLE downloads ADD COLUMN bytes BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE downloads ADD COLUMN cached BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE downloads ADD COLUMN media_type VARCHAR(10);
+
+ALTER TABLE downloads ADD COLUMN file_id TEXT;
+
+ALTER TABLE downloads ADD COLUMN title TEXT;
+
+CREATE INDEX downloads_created_at_idx ON downloads (created_at);
+
+CREATE TABLE media_cache (
+    cache_key TEXT PRIMARY KEY,
+    platform VARCHAR(20) NOT NULL,
+    media_type VARCHAR(10) NOT NULL,
+    file_id TEXT NOT NULL,
+    title TEXT,
+    bytes BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT NOW()
+);
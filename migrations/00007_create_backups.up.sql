@@ -0,0 +1,11 @@
+CREATE TABLE backups (
+    id BIGSERIAL PRIMARY KEY,
+    file_name VARCHAR(255),
+    bytes BIGINT NOT NULL DEFAULT 0,
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    destination TEXT,
+    trigger VARCHAR(20) NOT NULL DEFAULT 'manual',
+    status VARCHAR(20) NOT NULL,
+    error TEXT,
+    created_at TIMESTAMP DEFAULT NOW()
+);
@@ -0,0 +1,11 @@
+CREATE TABLE admin_audit (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id BIGINT NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    target TEXT,
+    payload JSONB,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX admin_audit_actor_idx ON admin_audit (actor_id, id);
+CREATE INDEX admin_audit_action_idx ON admin_audit (action, id);
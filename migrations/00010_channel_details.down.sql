@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS channels_username_key;
+DROP INDEX IF EXISTS channels_id_key;
+
+ALTER TABLE channels
+    DROP COLUMN created_at,
+    DROP COLUMN title,
+    DROP COLUMN id;
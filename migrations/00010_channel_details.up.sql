@@ -0,0 +1,11 @@
+-- Eski yozuvlarda to'liq havola yoki @ bilan saqlangan usernamelar bo'lishi mumkin
+UPDATE channels SET username = regexp_replace(username, '^\s*((https?://)?(www\.)?(t|telegram)\.me/)?@?', '', 'i');
+DELETE FROM channels a USING channels b WHERE a.ctid < b.ctid AND LOWER(a.username) = LOWER(b.username);
+
+ALTER TABLE channels
+    ADD COLUMN id BIGINT,
+    ADD COLUMN title TEXT,
+    ADD COLUMN created_at TIMESTAMP DEFAULT NOW();
+
+CREATE UNIQUE INDEX channels_id_key ON channels (id);
+CREATE UNIQUE INDEX channels_username_key ON channels (LOWER(username));
@@ -0,0 +1,5 @@
+DROP TABLE channel_join_requests;
+
+ALTER TABLE channels
+    DROP COLUMN join_request,
+    DROP COLUMN invite_link;
@@ -0,0 +1,11 @@
+ALTER TABLE channels
+    ADD COLUMN invite_link TEXT,
+    ADD COLUMN join_request BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Tasdiqlanmagan qo'shilish so'rovlari, obuna tekshiruvida a'zolik o'rnida hisoblanadi
+CREATE TABLE channel_join_requests (
+    channel_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (channel_id, user_id)
+);
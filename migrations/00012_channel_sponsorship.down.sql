@@ -0,0 +1,6 @@
+DROP TABLE channel_subscriptions;
+
+ALTER TABLE channels
+    DROP COLUMN target,
+    DROP COLUMN ends_at,
+    DROP COLUMN starts_at;
@@ -0,0 +1,15 @@
+ALTER TABLE channels
+    ADD COLUMN starts_at TIMESTAMP,
+    ADD COLUMN ends_at TIMESTAMP,
+    ADD COLUMN target INT;
+
+-- Bot orqali obuna: shown_at — obuna talabi ko'rsatilgan, joined_at — keyin obuna bo'lgan,
+-- left_at — obuna bo'lgach kanaldan chiqqan
+CREATE TABLE channel_subscriptions (
+    channel_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    shown_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    joined_at TIMESTAMP,
+    left_at TIMESTAMP,
+    PRIMARY KEY (channel_id, user_id)
+);
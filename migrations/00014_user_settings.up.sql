@@ -0,0 +1,9 @@
+CREATE TABLE user_settings (
+    user_id BIGINT PRIMARY KEY,
+    audio_mode VARCHAR(10) NOT NULL DEFAULT 'ask',
+    youtube_quality VARCHAR(10) NOT NULL DEFAULT 'ask',
+    as_document BOOLEAN NOT NULL DEFAULT FALSE,
+    caption BOOLEAN NOT NULL DEFAULT TRUE,
+    no_watermark BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP DEFAULT NOW()
+);
@@ -0,0 +1,14 @@
+CREATE TABLE group_settings (
+    chat_id BIGINT PRIMARY KEY,
+    title VARCHAR(255),
+    language VARCHAR(10),
+    instagram BOOLEAN NOT NULL DEFAULT TRUE,
+    tiktok BOOLEAN NOT NULL DEFAULT TRUE,
+    youtube BOOLEAN NOT NULL DEFAULT TRUE,
+    delete_link BOOLEAN NOT NULL DEFAULT FALSE,
+    reply BOOLEAN NOT NULL DEFAULT TRUE,
+    quiet BOOLEAN NOT NULL DEFAULT FALSE,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
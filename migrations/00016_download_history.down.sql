@@ -0,0 +1,3 @@
+DROP INDEX downloads_user_created_at_idx;
+
+ALTER TABLE downloads DROP COLUMN hidden;
@@ -0,0 +1,4 @@
+-- Foydalanuvchi tarixni tozalaganda yozuvlar statistika uchun qoladi, faqat tarixdan yashiriladi
+ALTER TABLE downloads ADD COLUMN hidden BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX downloads_user_created_at_idx ON downloads (user_id, created_at DESC);
@@ -0,0 +1,25 @@
+CREATE TABLE collections (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    name VARCHAR(64) NOT NULL,
+    -- Ulashilgan to'plam /start col_<id> havolasi orqali boshqa foydalanuvchilarga ochiladi
+    shared BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE (user_id, name)
+);
+
+-- Sevimlilar fayl ma'lumotlarini o'zida saqlaydi, tarix tozalansa ham qoladi
+CREATE TABLE favourites (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    collection_id BIGINT REFERENCES collections (id) ON DELETE SET NULL,
+    platform VARCHAR(20) NOT NULL,
+    media_type VARCHAR(10),
+    file_id TEXT NOT NULL,
+    title TEXT,
+    source_url TEXT,
+    created_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE (user_id, file_id)
+);
+
+CREATE INDEX favourites_collection_idx ON favourites (collection_id);
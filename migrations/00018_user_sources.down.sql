@@ -0,0 +1,5 @@
+DROP INDEX users_campaign_idx;
+DROP INDEX users_referred_by_idx;
+
+ALTER TABLE users DROP COLUMN campaign;
+ALTER TABLE users DROP COLUMN referred_by;
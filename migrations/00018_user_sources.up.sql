@@ -0,0 +1,6 @@
+-- Foydalanuvchi qayerdan kelgani: kim taklif qilgani va /start c_<teg> kampaniya tegi (faqat birinchi /start da yoziladi)
+ALTER TABLE users ADD COLUMN referred_by BIGINT;
+ALTER TABLE users ADD COLUMN campaign VARCHAR(64);
+
+CREATE INDEX users_referred_by_idx ON users (referred_by) WHERE referred_by IS NOT NULL;
+CREATE INDEX users_campaign_idx ON users (campaign) WHERE campaign IS NOT NULL;
@@ -0,0 +1,51 @@
+ALTER TABLE users
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN status_updated_at TYPE TIMESTAMP,
+    ALTER COLUMN last_active_at TYPE TIMESTAMP;
+
+ALTER TABLE admins
+    ALTER COLUMN created_at TYPE TIMESTAMP;
+
+ALTER TABLE broadcasts
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN finished_at TYPE TIMESTAMP;
+
+ALTER TABLE broadcast_recipients
+    ALTER COLUMN sent_at TYPE TIMESTAMP;
+
+ALTER TABLE broadcast_clicks
+    ALTER COLUMN created_at TYPE TIMESTAMP;
+
+ALTER TABLE scheduled_messages
+    ALTER COLUMN created_at TYPE TIMESTAMP;
+
+ALTER TABLE downloads
+    ALTER COLUMN created_at TYPE TIMESTAMP;
+
+ALTER TABLE media_cache
+    ALTER COLUMN created_at TYPE TIMESTAMP;
+
+ALTER TABLE backups
+    ALTER COLUMN created_at TYPE TIMESTAMP;
+
+ALTER TABLE admin_audit
+    ALTER COLUMN created_at TYPE TIMESTAMP;
+
+ALTER TABLE channels
+    ALTER COLUMN created_at TYPE TIMESTAMP;
+
+ALTER TABLE channel_join_requests
+    ALTER COLUMN created_at TYPE TIMESTAMP;
+
+ALTER TABLE user_settings
+    ALTER COLUMN updated_at TYPE TIMESTAMP;
+
+ALTER TABLE group_settings
+    ALTER COLUMN created_at TYPE TIMESTAMP,
+    ALTER COLUMN updated_at TYPE TIMESTAMP;
+
+ALTER TABLE collections
+    ALTER COLUMN created_at TYPE TIMESTAMP;
+
+ALTER TABLE favourites
+    ALTER COLUMN created_at TYPE TIMESTAMP;
@@ -0,0 +1,53 @@
+-- TIMESTAMP ustunlari sessiya zonasidagi devor soatini saqlaydi, Go tomonidagi Toshkent vaqti bilan
+-- solishtirishda oyna zonalar farqicha siljiydi. Mavjud qiymatlar sessiya zonasida (NOW() yozgan zona) talqin qilinadi.
+ALTER TABLE users
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN status_updated_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN last_active_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE admins
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE broadcasts
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN finished_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE broadcast_recipients
+    ALTER COLUMN sent_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE broadcast_clicks
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE scheduled_messages
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE downloads
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE media_cache
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE backups
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE admin_audit
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE channels
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE channel_join_requests
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE user_settings
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE group_settings
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE collections
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE favourites
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ;
@@ -0,0 +1,8 @@
+ALTER TABLE channel_subscriptions
+    ALTER COLUMN shown_at TYPE TIMESTAMP,
+    ALTER COLUMN joined_at TYPE TIMESTAMP,
+    ALTER COLUMN left_at TYPE TIMESTAMP;
+
+ALTER TABLE channels
+    ALTER COLUMN starts_at TYPE TIMESTAMP USING starts_at AT TIME ZONE 'Asia/Tashkent',
+    ALTER COLUMN ends_at TYPE TIMESTAMP USING ends_at AT TIME ZONE 'Asia/Tashkent';
@@ -0,0 +1,11 @@
+-- starts_at/ends_at bot zonasidagi (TIMEZONE, standart Asia/Tashkent) devor soati sifatida yozilgan,
+-- boshqa TIMEZONE ishlatilgan bo'lsa quyidagi zona nomini moslang
+ALTER TABLE channels
+    ALTER COLUMN starts_at TYPE TIMESTAMPTZ USING starts_at AT TIME ZONE 'Asia/Tashkent',
+    ALTER COLUMN ends_at TYPE TIMESTAMPTZ USING ends_at AT TIME ZONE 'Asia/Tashkent';
+
+-- NOW() bilan yozilgan ustunlar sessiya zonasida talqin qilinadi
+ALTER TABLE channel_subscriptions
+    ALTER COLUMN shown_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN joined_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN left_at TYPE TIMESTAMPTZ;
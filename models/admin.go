@@ -0,0 +1,65 @@
+package models
+
+import "time"
+
+const (
+	RoleOwner     = "owner"
+	RoleAdmin     = "admin"
+	RoleModerator = "moderator"
+	RoleAnalyst   = "analyst"
+)
+
+const (
+	PermBroadcast = "broadcast"
+	PermChannels  = "channels"
+	PermBackups   = "backups"
+	PermStats     = "stats"
+	// PermAdmins adminlarni qo'shish/o'chirish va rollarini o'zgartirish
+	PermAdmins = "admins"
+	// PermRestore bazani backupdan tiklash
+	PermRestore = "restore"
+	// PermAudit adminlar amallari tarixini ko'rish
+	PermAudit = "audit"
+)
+
+// Roles owner'dan boshlab, kuchliroq roldan kuchsizrog'iga
+var Roles = []string{RoleOwner, RoleAdmin, RoleModerator, RoleAnalyst}
+
+var rolePermissions = map[string][]string{
+	RoleOwner:     {PermBroadcast, PermChannels, PermBackups, PermStats, PermAdmins, PermRestore, PermAudit},
+	RoleAdmin:     {PermBroadcast, PermChannels, PermBackups, PermStats, PermAudit},
+	RoleModerator: {PermBroadcast, PermChannels},
+	RoleAnalyst:   {PermStats},
+}
+
+// HasPermission rol berilgan amalni bajarishi mumkinligini tekshiradi
+func HasPermission(role, permission string) bool {
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// RolesWith berilgan ruxsatga ega rollar
+func RolesWith(permission string) []string {
+	var roles []string
+	for _, role := range Roles {
+		if HasPermission(role, permission) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+type Admin struct {
+	ID        int64
+	Role      string
+	CreatedAt time.Time
+}
+
+func IsValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
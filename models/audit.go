@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// Audit amallari "<bo'lim>.<amal>" ko'rinishida, bo'lim bo'yicha filtrlash mumkin
+const (
+	AuditChannelAdd    = "channel.add"
+	AuditChannelDelete = "channel.delete"
+	// AuditChannelUpdate homiylik muddati yoki maqsadi o'zgartirildi
+	AuditChannelUpdate = "channel.update"
+	// AuditChannelExpire kanal muddati tugab yoki maqsadga yetib avtomatik o'chirildi
+	AuditChannelExpire = "channel.expire"
+
+	AuditAdminAdd    = "admin.add"
+	AuditAdminRemove = "admin.remove"
+
+	AuditBroadcastStart  = "broadcast.start"
+	AuditBroadcastPause  = "broadcast.pause"
+	AuditBroadcastResume = "broadcast.resume"
+	AuditBroadcastCancel = "broadcast.cancel"
+
+	AuditScheduleCreate = "schedule.create"
+	AuditScheduleCancel = "schedule.cancel"
+
+	AuditBackupCreate  = "backup.create"
+	AuditBackupRestore = "backup.restore"
+
+	AuditExport = "export.create"
+)
+
+// AuditSystem avtomatik (admin bosmagan) amallar uchun actor ID
+const AuditSystem int64 = 0
+
+type AuditEntry struct {
+	ID        int64
+	ActorID   int64
+	Action    string
+	Target    string
+	Payload   string
+	CreatedAt time.Time
+}
+
+// AuditFilter bo'sh maydonlar filtrlanmaydi. Section amal prefiksi, masalan "channel".
+type AuditFilter struct {
+	ActorID int64
+	Section string
+}
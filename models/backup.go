@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+const (
+	BackupManual    = "manual"
+	BackupScheduled = "scheduled"
+
+	BackupSuccess = "success"
+	BackupFailed  = "failed"
+)
+
+type Backup struct {
+	ID          int64
+	FileName    string
+	Bytes       int64
+	Duration    time.Duration
+	Destination string
+	Trigger     string
+	Status      string
+	Error       string
+	CreatedAt   time.Time
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	BroadcastRunning   = "running"
+	BroadcastPaused    = "paused"
+	BroadcastCancelled = "cancelled"
+	BroadcastCompleted = "completed"
+
+	RecipientPending = "pending"
+	RecipientSent    = "sent"
+	RecipientFailed  = "failed"
+	RecipientBlocked = "blocked"
+
+	VariantA = "A"
+	VariantB = "B"
+)
+
+const (
+	AudienceAll      = "all"
+	AudienceNew      = "new"
+	AudienceActive   = "active"
+	AudienceLanguage = "lang"
+	AudiencePlatform = "platform"
+)
+
+// Audience broadcast qabul qiluvchilari filtri. Days "new" va "active" uchun,
+// Value "lang" (til kodi) va "platform" (instagram, tiktok, youtube) uchun ishlatiladi.
+type Audience struct {
+	Kind  string
+	Days  int
+	Value string
+}
+
+// String auditoriyani bazada saqlanadigan "kind:param" ko'rinishiga keltiradi
+func (a Audience) String() string {
+	switch a.Kind {
+	case AudienceNew, AudienceActive:
+		return fmt.Sprintf("%s:%d", a.Kind, a.Days)
+	case AudienceLanguage, AudiencePlatform:
+		return a.Kind + ":" + a.Value
+	}
+	return AudienceAll
+}
+
+func ParseAudience(s string) Audience {
+	kind, param, _ := strings.Cut(s, ":")
+	switch kind {
+	case AudienceNew, AudienceActive:
+		days, err := strconv.Atoi(param)
+		if err != nil || days <= 0 {
+			break
+		}
+		return Audience{Kind: kind, Days: days}
+	case AudienceLanguage, AudiencePlatform:
+		if param == "" {
+			break
+		}
+		return Audience{Kind: kind, Value: param}
+	}
+	return Audience{Kind: AudienceAll}
+}
+
+type Broadcast struct {
+	ID                int64
+	AdminID           int64
+	SourceChatID      int64
+	SourceMessageID   int
+	VariantBMessageID int
+	ButtonText        string
+	ButtonURL         string
+	Audience          Audience
+	ProgressMessageID int
+	Status            string
+	CreatedAt         time.Time
+}
+
+// MessageID variant uchun yuboriladigan xabar ID'si
+func (b Broadcast) MessageID(variant string) int {
+	if variant == VariantB && b.VariantBMessageID != 0 {
+		return b.VariantBMessageID
+	}
+	return b.SourceMessageID
+}
+
+type BroadcastRecipient struct {
+	UserID  int64
+	Variant string
+}
+
+type BroadcastStats struct {
+	Total   int
+	Sent    int
+	Failed  int
+	Blocked int
+	Pending int
+	Clicks  int
+}
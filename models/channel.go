@@ -0,0 +1,73 @@
+package models
+
+import (
+	"strconv"
+	"time"
+)
+
+// Channel majburiy obuna kanali yoki guruhi. Eski yozuvlarda ID va Title bo'sh bo'lishi mumkin.
+type Channel struct {
+	ID       int64
+	Username string
+	Title    string
+	// InviteLink username'siz (yopiq) kanallar uchun bot yaratgan taklif havolasi
+	InviteLink string
+	// JoinRequest havola qo'shilish so'rovi bilan ishlaydi, yuborilgan so'rov obuna o'rnida hisoblanadi
+	JoinRequest bool
+	// StartsAt va EndsAt homiylik muddati, nol qiymat — cheklanmagan
+	StartsAt time.Time
+	EndsAt   time.Time
+	// Target bot orqali shuncha obunachi yig'ilgach kanal avtomatik o'chiriladi, 0 — cheklanmagan
+	Target    int
+	CreatedAt time.Time
+}
+
+// ChannelStats bot orqali obuna ko'rsatkichlari
+type ChannelStats struct {
+	Shown  int
+	Passed int
+	Left   int
+}
+
+type ChannelReport struct {
+	Channel
+	ChannelStats
+}
+
+// Active kanal hozir majburiy obunada ko'rsatiladimi (homiylik muddati boshlangan va tugamagan)
+func (c Channel) Active(now time.Time) bool {
+	if !c.StartsAt.IsZero() && now.Before(c.StartsAt) {
+		return false
+	}
+	return c.EndsAt.IsZero() || now.Before(c.EndsAt)
+}
+
+// Key kanalni callback data va o'chirishda aniqlash uchun: username, bo'lmasa chat ID
+func (c Channel) Key() string {
+	if c.Username != "" {
+		return c.Username
+	}
+	return strconv.FormatInt(c.ID, 10)
+}
+
+// Name ro'yxatlarda ko'rsatiladigan nom
+func (c Channel) Name() string {
+	switch {
+	case c.Title != "":
+		return c.Title
+	case c.Username != "":
+		return "@" + c.Username
+	}
+	return strconv.FormatInt(c.ID, 10)
+}
+
+// URL obuna tugmasi havolasi: taklif havolasi, bo'lmasa ochiq kanal havolasi, ikkalasi ham bo'lmasa ""
+func (c Channel) URL() string {
+	switch {
+	case c.InviteLink != "":
+		return c.InviteLink
+	case c.Username != "":
+		return "https://t.me/" + c.Username
+	}
+	return ""
+}
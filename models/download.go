@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+const (
+	PlatformInstagram = "instagram"
+	PlatformTikTok    = "tiktok"
+	PlatformYouTube   = "youtube"
+
+	DownloadPending = "pending"
+	DownloadSuccess = "success"
+	DownloadFailed  = "failed"
+
+	MediaVideo    = "video"
+	MediaAudio    = "audio"
+	MediaDocument = "document"
+)
+
+var Platforms = []string{PlatformInstagram, PlatformTikTok, PlatformYouTube}
+
+type Download struct {
+	ID        int64
+	UserID    int64
+	Platform  string
+	SourceURL string
+	Status    string
+	MediaType string
+	FileID    string
+	Title     string
+	CreatedAt time.Time
+	// Hidden foydalanuvchi tarixni tozalagan, yozuv statistika uchun qoladi
+	Hidden bool
+}
+
+// MediaCache Telegram serverida saqlangan fayl, bir xil havola qayta so'ralganda file_id orqali yuboriladi
+type MediaCache struct {
+	Key       string
+	Platform  string
+	MediaType string
+	FileID    string
+	Title     string
+	Bytes     int64
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Favourite foydalanuvchi ⭐ bilan belgilagan fayl. CollectionID 0 — hech qaysi to'plamda emas.
+type Favourite struct {
+	ID           int64
+	UserID       int64
+	CollectionID int64
+	Platform     string
+	MediaType    string
+	FileID       string
+	Title        string
+	SourceURL    string
+	CreatedAt    time.Time
+}
+
+// Collection sevimlilarni guruhlash uchun foydalanuvchi yaratgan nomli to'plam
+type Collection struct {
+	ID     int64
+	UserID int64
+	Name   string
+	Shared bool
+	// Items to'plamdagi fayllar soni
+	Items     int
+	CreatedAt time.Time
+}
@@ -0,0 +1,42 @@
+package models
+
+// GroupSettings bot qo'shilgan guruh sozlamalari, ularni guruh adminlari /settings orqali o'zgartiradi
+type GroupSettings struct {
+	ChatID   int64
+	Title    string
+	Language string
+
+	// Instagram, TikTok, YouTube qaysi platformalar havolalari yuklanadi
+	Instagram bool
+	TikTok    bool
+	YouTube   bool
+	// DeleteLink yuklangandan keyin havolali xabarni o'chirish (bot admin bo'lishi kerak)
+	DeleteLink bool
+	// Reply media havolali xabarga javob sifatida yuboriladi, aks holda oddiy xabar
+	Reply bool
+	// Quiet xatolik va limit haqidagi xabarlar guruhga yozilmaydi
+	Quiet bool
+}
+
+func DefaultGroupSettings(chatID int64) GroupSettings {
+	return GroupSettings{
+		ChatID:    chatID,
+		Instagram: true,
+		TikTok:    true,
+		YouTube:   true,
+		Reply:     true,
+	}
+}
+
+// Enabled platforma havolalari guruhda yuklanadimi
+func (s GroupSettings) Enabled(platform string) bool {
+	switch platform {
+	case PlatformInstagram:
+		return s.Instagram
+	case PlatformTikTok:
+		return s.TikTok
+	case PlatformYouTube:
+		return s.YouTube
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+const (
+	RepeatOnce   = "once"
+	RepeatWeekly = "weekly"
+
+	ScheduledPending   = "pending"
+	ScheduledRunning   = "running"
+	ScheduledDone      = "done"
+	ScheduledFailed    = "failed"
+	ScheduledCancelled = "cancelled"
+)
+
+type ScheduledMessage struct {
+	ID              int64
+	AdminID         int64
+	SourceChatID    int64
+	SourceMessageID int
+	RunAt           time.Time
+	Repeat          string
+	Status          string
+}
@@ -0,0 +1,38 @@
+package models
+
+const (
+	AudioAsk    = "ask"
+	AudioAlways = "always"
+	AudioNever  = "never"
+
+	QualityAsk   = "ask"
+	QualityAudio = "audio"
+)
+
+// AudioModes va YouTubeQualities sozlamalar tugmasi bosilganda shu tartibda almashadi
+var (
+	AudioModes       = []string{AudioAsk, AudioAlways, AudioNever}
+	YouTubeQualities = []string{QualityAsk, "360", "480", "720", "1080", QualityAudio}
+)
+
+// UserSettings foydalanuvchining yuklash sozlamalari
+type UserSettings struct {
+	// AudioMode Instagram va TikTok videolaridan keyin audio: so'rash, har doim yuborish yoki yubormaslik
+	AudioMode string
+	// YouTubeQuality format ro'yxatini ko'rsatish (ask), balandlik ("720") yoki faqat audio
+	YouTubeQuality string
+	// AsDocument videoni siqilmagan fayl sifatida yuborish
+	AsDocument bool
+	Caption    bool
+	// NoWatermark TikTok videosini suv belgisisiz yuklash
+	NoWatermark bool
+}
+
+func DefaultUserSettings() UserSettings {
+	return UserSettings{
+		AudioMode:      AudioAsk,
+		YouTubeQuality: QualityAsk,
+		Caption:        true,
+		NoWatermark:    true,
+	}
+}
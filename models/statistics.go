@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+type UserStats struct {
+	Total       int
+	New         int
+	DAU         int
+	WAU         int
+	MAU         int
+	Blocked     int
+	Deactivated int
+}
+
+type PlatformStats struct {
+	Platform    string
+	Total       int
+	Success     int
+	Failed      int
+	Cached      int
+	AvgDuration time.Duration
+	Bytes       int64
+}
+
+type DailyDownloads struct {
+	Day      time.Time
+	Platform string
+	Count    int
+	Failed   int
+}
+
+type ErrorCount struct {
+	Reason string
+	Count  int
+}
+
+type DailyCount struct {
+	Day   time.Time
+	Count int
+}
+
+// SourceStats foydalanuvchilar qayerdan kelgani bo'yicha umumiy sonlar
+type SourceStats struct {
+	Referred int
+	Campaign int
+	Organic  int
+}
+
+// ReferrerStats bitta foydalanuvchi taklif qilganlar soni
+type ReferrerStats struct {
+	UserID  int64
+	Invited int
+	Active  int
+}
+
+// CampaignStats /start c_<teg> orqali kelganlar: jami, faol, kamida bitta yuklagan va since dan keyin kelganlar
+type CampaignStats struct {
+	Campaign   string
+	Users      int
+	Active     int
+	Downloaded int
+	New        int
+}
@@ -2,7 +2,14 @@ package models
 
 import "time"
 
+const (
+	UserActive      = "active"
+	UserBlocked     = "blocked"
+	UserDeactivated = "deactivated"
+)
+
 type User struct {
 	ID        int64
+	Status    string
 	createdAt time.Time
 }
@@ -0,0 +1,293 @@
+package chart
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	width  = 900
+	height = 500
+
+	marginLeft   = 60
+	marginRight  = 20
+	marginTop    = 50
+	marginBottom = 50
+
+	gridLines = 5
+)
+
+var (
+	background = color.RGBA{255, 255, 255, 255}
+	axisColor  = color.RGBA{90, 90, 90, 255}
+	gridColor  = color.RGBA{225, 225, 225, 255}
+	textColor  = color.RGBA{40, 40, 40, 255}
+
+	// Palette seriyalar uchun standart ranglar
+	Palette = []color.RGBA{
+		{225, 48, 108, 255},
+		{37, 244, 238, 255},
+		{255, 0, 0, 255},
+		{66, 133, 244, 255},
+		{52, 168, 83, 255},
+	}
+)
+
+type Series struct {
+	Name   string
+	Values []float64
+	Color  color.RGBA
+}
+
+// Chart Labels X o'qidagi yozuvlar, har bir seriyada Labels bilan bir xil miqdorda qiymat bo'lishi kerak
+type Chart struct {
+	Title  string
+	Labels []string
+	Series []Series
+	// YSuffix Y o'qi qiymatlaridan keyin qo'shiladi (masalan "%")
+	YSuffix string
+}
+
+// Line chiziqli grafikni PNG ko'rinishida qaytaradi
+func Line(c Chart) ([]byte, error) {
+	img, plot := newCanvas(c, maxValue(c, false))
+
+	for _, s := range c.Series {
+		var prev image.Point
+		for i, v := range s.Values {
+			p := plot.point(i, v)
+			if i > 0 {
+				drawLine(img, prev, p, s.Color)
+			}
+			fillRect(img, image.Rect(p.X-2, p.Y-2, p.X+3, p.Y+3), s.Color)
+			prev = p
+		}
+	}
+
+	return encode(img)
+}
+
+// StackedBar ustunli grafik, seriyalar bir-birining ustiga qo'yiladi
+func StackedBar(c Chart) ([]byte, error) {
+	img, plot := newCanvas(c, maxValue(c, true))
+
+	barWidth := int(plot.step() * 0.7)
+	if barWidth < 1 {
+		barWidth = 1
+	}
+
+	for i := range c.Labels {
+		var sum float64
+		for _, s := range c.Series {
+			if i >= len(s.Values) || s.Values[i] <= 0 {
+				continue
+			}
+			bottom := plot.point(i, sum)
+			sum += s.Values[i]
+			top := plot.point(i, sum)
+			fillRect(img, image.Rect(bottom.X-barWidth/2, top.Y, bottom.X+barWidth/2+1, bottom.Y), s.Color)
+		}
+	}
+
+	return encode(img)
+}
+
+type plotArea struct {
+	rect  image.Rectangle
+	count int
+	maxY  float64
+}
+
+func (p plotArea) step() float64 {
+	if p.count <= 1 {
+		return float64(p.rect.Dx())
+	}
+	return float64(p.rect.Dx()) / float64(p.count)
+}
+
+func (p plotArea) point(i int, v float64) image.Point {
+	x := float64(p.rect.Min.X) + p.step()*(float64(i)+0.5)
+	y := float64(p.rect.Max.Y) - v/p.maxY*float64(p.rect.Dy())
+	return image.Pt(int(math.Round(x)), int(math.Round(y)))
+}
+
+// newCanvas fon, sarlavha, o'qlar, to'r chiziqlari va legendani chizadi
+func newCanvas(c Chart, maxY float64) (*image.RGBA, plotArea) {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: background}, image.Point{}, draw.Src)
+
+	plot := plotArea{
+		rect:  image.Rect(marginLeft, marginTop, width-marginRight, height-marginBottom),
+		count: len(c.Labels),
+		maxY:  niceMax(maxY),
+	}
+
+	drawText(img, c.Title, marginLeft, 25, textColor)
+
+	// Y o'qi va to'r
+	for i := 0; i <= gridLines; i++ {
+		v := plot.maxY * float64(i) / gridLines
+		y := plot.point(0, v).Y
+		drawLine(img, image.Pt(plot.rect.Min.X, y), image.Pt(plot.rect.Max.X, y), gridColor)
+		label := formatValue(v) + c.YSuffix
+		drawText(img, label, plot.rect.Min.X-8-textWidth(label), y+4, textColor)
+	}
+	drawLine(img, image.Pt(plot.rect.Min.X, plot.rect.Min.Y), image.Pt(plot.rect.Min.X, plot.rect.Max.Y), axisColor)
+	drawLine(img, image.Pt(plot.rect.Min.X, plot.rect.Max.Y), image.Pt(plot.rect.Max.X, plot.rect.Max.Y), axisColor)
+
+	// X o'qi yozuvlari bir-birini bosib qolmasligi uchun har n-chisi chiziladi
+	if len(c.Labels) > 0 {
+		every := 1
+		if maxWidth := maxTextWidth(c.Labels) + 10; plot.step() < float64(maxWidth) {
+			every = int(math.Ceil(float64(maxWidth) / plot.step()))
+		}
+		for i, label := range c.Labels {
+			if i%every != 0 {
+				continue
+			}
+			x := plot.point(i, 0).X
+			drawText(img, label, x-textWidth(label)/2, plot.rect.Max.Y+20, textColor)
+		}
+	}
+
+	// Legenda
+	x := width - marginRight
+	for i := len(c.Series) - 1; i >= 0; i-- {
+		s := c.Series[i]
+		x -= textWidth(s.Name) + 30
+		fillRect(img, image.Rect(x, 15, x+12, 27), s.Color)
+		drawText(img, s.Name, x+16, 26, textColor)
+	}
+
+	return img, plot
+}
+
+func maxValue(c Chart, stacked bool) float64 {
+	var maxY float64
+	for i := range c.Labels {
+		var sum float64
+		for _, s := range c.Series {
+			if i >= len(s.Values) {
+				continue
+			}
+			if stacked {
+				sum += s.Values[i]
+			} else if s.Values[i] > maxY {
+				maxY = s.Values[i]
+			}
+		}
+		if sum > maxY {
+			maxY = sum
+		}
+	}
+	return maxY
+}
+
+// niceMax Y o'qining yuqori chegarasini yaxlit songa keltiradi (1, 2, 5 × 10^n)
+func niceMax(v float64) float64 {
+	if v <= 0 {
+		return 1
+	}
+	exp := math.Pow(10, math.Floor(math.Log10(v)))
+	for _, m := range []float64{1, 2, 5, 10} {
+		if v <= m*exp {
+			return m * exp
+		}
+	}
+	return 10 * exp
+}
+
+func formatValue(v float64) string {
+	switch {
+	case v >= 1e6:
+		return fmt.Sprintf("%.1fM", v/1e6)
+	case v >= 1e4:
+		return fmt.Sprintf("%.0fk", v/1e3)
+	case v == math.Trunc(v):
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.1f", v)
+}
+
+func drawText(img *image.RGBA, text string, x, y int, c color.Color) {
+	d := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(c),
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(text)
+}
+
+func textWidth(text string) int {
+	return font.MeasureString(basicfont.Face7x13, text).Round()
+}
+
+func maxTextWidth(texts []string) int {
+	var w int
+	for _, t := range texts {
+		if tw := textWidth(t); tw > w {
+			w = tw
+		}
+	}
+	return w
+}
+
+func fillRect(img *image.RGBA, r image.Rectangle, c color.Color) {
+	draw.Draw(img, r, &image.Uniform{C: c}, image.Point{}, draw.Src)
+}
+
+// drawLine Bresenham algoritmi bilan 2px qalinlikdagi chiziq chizadi
+func drawLine(img *image.RGBA, a, b image.Point, c color.RGBA) {
+	dx, dy := abs(b.X-a.X), -abs(b.Y-a.Y)
+	sx, sy := 1, 1
+	if a.X > b.X {
+		sx = -1
+	}
+	if a.Y > b.Y {
+		sy = -1
+	}
+
+	err := dx + dy
+	x, y := a.X, a.Y
+	for {
+		img.SetRGBA(x, y, c)
+		img.SetRGBA(x, y+1, c)
+		if x == b.X && y == b.Y {
+			return
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y += sy
+		}
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func encode(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,76 @@
+package cron
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestNext(t *testing.T) {
+	tashkent, err := time.LoadLocation("Asia/Tashkent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(year int, month time.Month, day, hour, min int) time.Time {
+		return time.Date(year, month, day, hour, min, 0, 0, tashkent)
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{"daily before time", "0 3 * * *", at(2026, 10, 19, 2, 59), at(2026, 10, 19, 3, 0)},
+		{"daily after time", "0 3 * * *", at(2026, 10, 19, 10, 0), at(2026, 10, 20, 3, 0)},
+		{"daily at time is not now", "0 3 * * *", at(2026, 10, 19, 3, 0), at(2026, 10, 20, 3, 0)},
+		{"daily end of year", "0 3 * * *", at(2026, 12, 31, 4, 0), at(2027, 1, 1, 3, 0)},
+		{"every 15 minutes", "*/15 * * * *", at(2026, 10, 19, 10, 7), at(2026, 10, 19, 10, 15)},
+		{"every 15 minutes next hour", "*/15 * * * *", at(2026, 10, 19, 10, 45), at(2026, 10, 19, 11, 0)},
+		{"every 15 minutes next day", "*/15 * * * *", at(2026, 10, 19, 23, 50), at(2026, 10, 20, 0, 0)},
+		{"weekdays from friday", "0 9 * * 1-5", at(2026, 10, 23, 10, 0), at(2026, 10, 26, 9, 0)},
+		{"weekdays same day", "0 9 * * 1-5", at(2026, 10, 21, 8, 0), at(2026, 10, 21, 9, 0)},
+		{"sunday as 7", "0 0 * * 7", at(2026, 10, 19, 12, 0), at(2026, 10, 25, 0, 0)},
+		{"sunday as 0", "0 0 * * 0", at(2026, 10, 19, 12, 0), at(2026, 10, 25, 0, 0)},
+		{"dom or dow: dom first", "0 0 13 * 1", at(2026, 11, 10, 12, 0), at(2026, 11, 13, 0, 0)},
+		{"dom or dow: dow first", "0 0 13 * 1", at(2026, 11, 13, 12, 0), at(2026, 11, 16, 0, 0)},
+		{"list and range", "0 8,20 1-2 * *", at(2026, 10, 19, 12, 0), at(2026, 11, 1, 8, 0)},
+		{"30 february never", "30 2 30 2 *", at(2026, 10, 19, 12, 0), time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.expr, err)
+			}
+			got := s.Next(tt.from)
+			if !got.Equal(tt.want) {
+				t.Errorf("Next(%q, %v) = %v, want %v", tt.expr, tt.from, got, tt.want)
+			}
+			if !got.IsZero() && got.Location() != tashkent {
+				t.Errorf("Next(%q) location = %v, want %v", tt.expr, got.Location(), tashkent)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+	} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) succeeded, want error", expr)
+		}
+	}
+}
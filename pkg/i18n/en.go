@@ -0,0 +1,380 @@
+package i18n
+
+var english = map[string]string{
+	"lang.name":    "🇬🇧 English",
+	"lang.choose":  "🌐 Choose your language:",
+	"lang.changed": "✅ Interface language: %s",
+
+	"cmd.start":      "Start the bot",
+	"cmd.help":       "Help",
+	"cmd.history":    "Download history",
+	"cmd.favourites": "Favourites and collections",
+	"cmd.invite":     "Invite friends",
+	"cmd.settings":   "Settings",
+	"cmd.lang":       "Change language",
+	"cmd.cancel":     "Cancel the current action",
+	"cmd.admin":      "Admin panel",
+
+	"help.text": "ℹ️ Send me a video or post link and I'll download it.\n\n" +
+		"Supported:\n• Instagram — reels and posts\n• TikTok — videos\n• YouTube — video and audio (up to 50 MB)\n\n" +
+		"I can also extract audio from Instagram and TikTok videos.\n\n" +
+		"In any chat, type the bot's username and a link to send the video right there.\n\n" +
+		"Commands:\n/history — download history\n/favourites — favourites\n/invite — invite friends\n/settings — settings\n/lang — change language\n/cancel — cancel the current action",
+
+	"settings.title":            "⚙️ Settings\n\nTap a button to change its value.",
+	"settings.lang":             "🌐 Language: %s",
+	"settings.audio":            "🎵 Audio: %s",
+	"settings.audio.ask":        "ask",
+	"settings.audio.always":     "always",
+	"settings.audio.never":      "never",
+	"settings.quality":          "📺 YouTube quality: %s",
+	"settings.quality.ask":      "choose from list",
+	"settings.quality.audio":    "audio only",
+	"settings.send_as":          "📦 Send as: %s",
+	"settings.send_as.video":    "video",
+	"settings.send_as.document": "file",
+	"settings.caption":          "📝 Caption: %s",
+	"settings.watermark":        "💧 TikTok without watermark: %s",
+	"settings.on":               "on",
+	"settings.off":              "off",
+
+	"cancel.done":    "Cancelled.",
+	"cancel.nothing": "Nothing to cancel.",
+
+	"start.welcome":      "👋 Hello [%s](tg://user?id=%d), welcome!\n\nI can help you download videos from Instagram and TikTok.\n\nPlease send me a video link.",
+	"start.welcome_back": "👋 Hello [%s](tg://user?id=%d), welcome!",
+
+	"sub.gate":         "Please join the channels.",
+	"sub.check":        "I've joined",
+	"sub.join_request": "✅ Your request to join %s has been received. Now tap \"I've joined\".",
+
+	"download.caption":        "Here is your video.",
+	"download.audio_prompt":   "Here is your video.\n\nDo you want its audio as well?",
+	"download.audio_question": "Do you want its audio as well?",
+	"download.yes":            "Yes",
+	"download.no":             "No",
+	"download.audio_caption":  "Here is the audio from the video:",
+	"download.audio_error":    "❌ Failed to extract the audio.",
+	"download.error":          "❌ Failed to download the video.",
+	"download.error_detail":   "❌ Failed to download the video: %s",
+	"download.not_found":      "❌ The video could not be downloaded. Please try another link.",
+	"download.api_error":      "❌ Failed to read the API response.",
+	"download.problem":        "❌ There is a problem downloading the video.",
+	"download.file_error":     "❌ Video download failed.",
+	"download.rate_limited":   "⏳ Too many requests. Please try again in %d seconds.",
+
+	"youtube.meta_error":   "❌ Failed to get video information.",
+	"youtube.caption":      "*%s*\nDuration: %s\nChoose format to download:",
+	"youtube.audio_button": "Audio - %.1fMB",
+	"youtube.format_error": "Failed to download the selected format.",
+	"youtube.too_large":    "Sorry, the file is larger than 50 MB. I can't send it.",
+
+	"inline.hint":         "Type an Instagram, TikTok or YouTube link",
+	"inline.subscribe":    "Join the channels first",
+	"inline.rate_limited": "⏳ Try again in %d seconds",
+	"inline.error":        "❌ Download failed, try it in the bot",
+	"inline.title":        "Video",
+	"inline.description":  "Tap to send",
+	"inline.caption":      "📥 Downloaded via @%s",
+
+	"group.welcome":     "👋 Hi! I download Instagram, TikTok and YouTube links posted in this group.\n\nMake me a group admin so I can see the links. Settings: /settings (for group admins).",
+	"group.settings":    "⚙️ Group settings\n\nTap a button to change its value.",
+	"group.platform":    "%s: %s",
+	"group.delete_link": "🗑 Delete the link message: %s",
+	"group.reply":       "↩️ Send as a reply: %s",
+	"group.quiet":       "🔕 Quiet mode: %s",
+	"group.admin_only":  "Only group admins can do this.",
+	"group.error":       "❌ Couldn't download this link.",
+	"group.caption":     "👤 %s",
+
+	"history.title":         "📂 Download history (page %d)",
+	"history.empty":         "📂 Your download history is empty.",
+	"history.error":         "❌ Failed to load the history.",
+	"history.not_found":     "File not found or no longer available.",
+	"history.prev":          "⬅️ Previous",
+	"history.next":          "Next ➡️",
+	"history.clear":         "🗑 Clear history",
+	"history.clear_confirm": "Clear your download history?",
+	"history.clear_yes":     "Yes, clear",
+	"history.clear_no":      "No",
+	"history.cleared":       "✅ History cleared.",
+
+	"fav.title":             "⭐ Favourites: %d files.\n\nChoose a collection:",
+	"fav.empty":             "⭐ You have no favourites yet.\n\nStar a file with the ⭐ button in /history.",
+	"fav.all":               "⭐ All (%d)",
+	"fav.all_name":          "All favourites",
+	"fav.new_collection":    "➕ New collection",
+	"fav.collection_title":  "📁 %s (page %d)",
+	"fav.collection_empty":  "No files here yet.",
+	"fav.share":             "🔗 Share",
+	"fav.delete_collection": "🗑 Delete collection",
+	"fav.back":              "⬅️ Back",
+	"fav.moved":             "✅ Moved",
+	"fav.no_collection":     "No collection",
+	"fav.choose_collection": "Which collection should the file go to?",
+	"fav.name_prompt":       "Send a name for the new collection (up to 64 characters). Send /cancel to cancel.",
+	"fav.name_invalid":      "A collection name must be 1 to 64 characters long.",
+	"fav.limit":             "You can create at most %d collections.",
+	"fav.delete_confirm":    "Delete this collection? Its files stay in your favourites.",
+	"fav.delete_yes":        "Yes, delete",
+	"fav.added":             "⭐ Added to favourites",
+	"fav.already":           "This file is already in your favourites",
+	"fav.error":             "❌ Something went wrong with your favourites.",
+	"fav.share_link":        "🔗 Link to the \"%s\" collection:\n%s\n\nAnyone who opens it will receive the collection's files.",
+	"fav.col_not_found":     "❌ Collection not found or not shared.",
+	"fav.col_shared":        "📁 %s — %d files:",
+
+	"ref.invite":      "👥 Your invite link:\n%s\n\nFriends who joined with it: %d",
+	"ref.joined":      "🎉 %s joined using your invite link. Friends invited: %d",
+	"share.link":      "🔗 Link to the file:\n%s\n\nAnyone who opens it will receive the file right away.",
+	"share.not_found": "❌ The file was not found or the link is invalid.",
+
+	"admin.not_admin":       "You are not an admin.",
+	"admin.menu":            "Admin commands (%s):",
+	"admin.channel_prompt":  "Send the channel link, @username or ID, or forward a message from the channel (e.g. https://t.me/your_channel).\nThe bot must be an admin of the channel.",
+	"admin.remove_prompt":   "Send the ID of the admin to remove:",
+	"admin.schedule_prompt": "Send the message you want to schedule (/cancel to abort):",
+
+	"menu.stats":          "Statistics",
+	"menu.broadcast":      "Broadcast",
+	"menu.schedule":       "Schedule",
+	"menu.scheduled":      "Scheduled",
+	"menu.channel_add":    "Add channel",
+	"menu.channel_delete": "Remove channel",
+	"menu.channels":       "Channels",
+	"menu.admins":         "Admins",
+	"menu.admin_add":      "Add admin",
+	"menu.admin_remove":   "Remove admin",
+	"menu.backup":         "Create backup",
+	"menu.restore":        "Restore backup",
+	"menu.backups":        "Backups",
+	"menu.export":         "Export",
+	"menu.audit":          "Audit log",
+	"menu.sources":        "Sources",
+
+	"common.yes":                      "Yes",
+	"common.no":                       "No",
+	"common.cancel":                   "Cancel",
+	"role.owner":                      "Owner",
+	"role.admin":                      "Admin",
+	"role.moderator":                  "Moderator",
+	"role.analyst":                    "Analyst",
+	"channel.invite_link_unsupported": "A channel can't be identified by an invite link. Send the channel ID or forward a message from the channel.",
+	"channel.bad_format":              "Invalid format. For example: https://t.me/your_channel, @your_channel or -1001234567890.",
+	"channel.not_found":               "Channel not found. Check the link or make the bot an admin of the channel first.",
+	"channel.not_channel":             "This is not a channel or a group.",
+	"channel.bot_not_admin":           "The bot is not an admin of this channel. Make it an admin first, otherwise subscriptions can't be checked.",
+	"channel.private":                 "%s is a private channel. The bot will create an invite link for the subscription.\n\nWith a join-request link, a user counts as subscribed as soon as they send the request.",
+	"channel.invite_plain":            "🔗 Regular link",
+	"channel.invite_request":          "📨 With join requests",
+	"channel.invite_name":             "Required subscription",
+	"channel.invite_error":            "Couldn't create an invite link. Give the bot the \"Invite users via link\" right in the channel.",
+	"channel.add_cancelled":           "Adding the channel was cancelled.",
+	"channel.add_error":               "Failed to add the channel.",
+	"channel.exists":                  "%s has already been added.",
+	"channel.added":                   "Channel %s added.",
+	"channel.added_link":              "\nLink: %s",
+	"channel.list_error":              "Failed to load channels.",
+	"channel.delete_choose":           "Choose a channel to remove:",
+	"channel.delete_confirm":          "Remove channel %s?",
+	"channel.delete_error":            "Failed to remove the channel.",
+	"channel.deleted":                 "Channel %s removed.",
+	"channel.delete_cancelled":        "Channel removal cancelled.",
+	"admins.title":                    "👥 Admins (%d):",
+	"admins.list_error":               "Failed to load admins.",
+	"admins.add":                      "➕ Add admin",
+	"admins.remove_confirm":           "Remove %s (%d) from admins?",
+	"admins.pick_user":                "👤 Choose a user",
+	"admins.pick_cancel":              "Cancel",
+	"admins.pick_prompt":              "Choose the new admin:\n• tap the \"%s\" button,\n• forward their message or send their contact,\n• or type their ID and role (e.g. 123456789 moderator).\nRoles: admin, moderator, analyst. Without a role they become admin.",
+	"admins.choose_role":              "Choose a role:",
+	"admins.add_cancelled":            "Adding an admin was cancelled.",
+	"admins.hidden_forward":           "The user hides their account in forwarding settings. Use the \"%s\" button or their ID.",
+	"admins.contact_no_account":       "This contact isn't linked to a Telegram account.",
+	"admins.bad_id":                   "Invalid admin ID format.",
+	"admins.bad_role":                 "Invalid role. Available roles: admin, moderator, analyst.",
+	"admins.owner_role":               "The bot owner's role can't be changed.",
+	"admins.add_error":                "Failed to add the admin.",
+	"admins.added":                    "Admin added (%s).",
+	"admins.remove_self":              "You can't remove yourself.",
+	"admins.remove_owner":             "The bot owner can't be removed.",
+	"admins.remove_error":             "Failed to remove the admin.",
+	"admins.not_found":                "No such admin.",
+	"admins.removed":                  "Admin removed.",
+
+	"audience.prompt":         "Who should receive the message?",
+	"audience.all_button":     "All users",
+	"audience.new_button":     "New: %d days",
+	"audience.active_button":  "Active: %d days",
+	"audience.lang_uz":        "🇺🇿 Uzbek",
+	"audience.lang_ru":        "🇷🇺 Russian",
+	"audience.lang_en":        "🇬🇧 English",
+	"audience.all":            "all users",
+	"audience.new":            "joined in the last %d days",
+	"audience.active":         "active in the last %d days",
+	"audience.language":       "language: %s",
+	"audience.platform":       "used %s",
+	"audience.count_error":    "Failed to load users.",
+	"broadcast.ask_message":   "Audience: %s (%d users)\n\nPlease send the message you want to broadcast (/cancel to cancel):",
+	"broadcast.button_format": "Invalid format. For example: More | https://example.com",
+	"broadcast.draft_missing": "Message not found, please start over.",
+	"broadcast.ask_variant":   "Send the variant B message (/cancel to cancel):",
+	"broadcast.ask_button":    "Send the button as \"Text | link\", for example: More | https://example.com",
+	"broadcast.start_error":   "Failed to start the broadcast.",
+	"broadcast.cancelled":     "Broadcast cancelled.",
+	"broadcast.add_variant":   "🅱️ Add variant B",
+	"broadcast.add_button":    "🔘 Add button",
+	"broadcast.send":          "✅ Send",
+	"broadcast.cancel":        "❌ Cancel",
+	"broadcast.audience":      "Audience: %s",
+	"broadcast.ab_note":       "\nA/B test: users are split evenly between the two variants.",
+	"broadcast.button_line":   "\nButton: %s → %s",
+
+	"broadcast.starting":         "Starting the broadcast...",
+	"broadcast.finished":         "The broadcast has finished.",
+	"broadcast.variant_stats":    "\n\nVariant %s: sent %d/%d, failed %d, blocked %d",
+	"broadcast.variant_clicks":   ", clicks %d (%s)",
+	"broadcast.status_running":   "⏳ Sending",
+	"broadcast.status_paused":    "⏸ Paused",
+	"broadcast.status_cancelled": "❌ Cancelled",
+	"broadcast.status_completed": "✅ Completed",
+	"broadcast.progress":         "Broadcast #%d: %s\nAudience: %s\n\nSent: %d\nFailed: %d\nBlocked the bot: %d\nPending: %d\nTotal: %d",
+	"broadcast.clicks":           "\nButton clicks: %d (%s)",
+	"broadcast.eta":              "\nEstimated time: %s",
+	"broadcast.pause":            "⏸ Pause",
+	"broadcast.resume":           "▶️ Resume",
+	"broadcast.status_error":     "Failed to change the broadcast status.",
+
+	"audit.load_error":          "Failed to load the action log.",
+	"audit.ask_actor":           "Send the admin's ID or forward one of their messages (/cancel to cancel):",
+	"audit.title":               "📜 Admin actions (page %d)\n",
+	"audit.actor":               "Admin: %d\n",
+	"audit.section":             "Section: %s\n",
+	"audit.empty":               "No entries found.",
+	"audit.system":              "system",
+	"audit.by_actor":            "👤 By admin",
+	"audit.all_actors":          "✖️ All admins",
+	"audit.section_all":         "All",
+	"audit.section_channel":     "Channels",
+	"audit.section_admin":       "Admins",
+	"audit.section_broadcast":   "Broadcasts",
+	"audit.section_schedule":    "Schedules",
+	"audit.section_backup":      "Backups",
+	"audit.section_export":      "Export",
+	"page.prev":                 "⬅️ Previous",
+	"page.next":                 "Next ➡️",
+	"backups.load_error":        "Failed to load the backup list.",
+	"backups.title":             "💾 Backups\n\n",
+	"backups.next":              "Next automatic backup: %s (%s)\n",
+	"backups.auto_off":          "Automatic backups are disabled\n",
+	"backups.retention":         "Kept: last %d\n\n",
+	"backups.empty":             "No backups yet.",
+	"backups.trigger_scheduled": "automatic",
+	"backups.trigger_manual":    "manual",
+
+	"period.today":          "Today",
+	"period.7d":             "7 days",
+	"period.30d":            "30 days",
+	"period.90d":            "90 days",
+	"period.all":            "All time",
+	"stats.load_error":      "Failed to load statistics.",
+	"stats.title":           "📊 Statistics: %s\n\n",
+	"stats.users":           "👥 Users\nTotal users: %d\nNew: %d\nDAU / WAU / MAU: %d / %d / %d\nBlocked the bot: %d\nDeleted accounts: %d\n",
+	"stats.downloads":       "\n📥 Downloads\n",
+	"stats.downloads_total": "Total: %d, successful: %s\nAverage download time: %s\nSent: %s\nFrom cache: %s\n",
+	"stats.daily":           "\n📅 By day\n",
+	"stats.top_errors":      "\n⚠️ Most common errors\n",
+	"chart.users":           "Users",
+	"chart.downloads":       "Downloads",
+	"chart.errors":          "Errors",
+	"chart.error":           "Failed to draw the chart.",
+	"chart.users_title":     "Number of users",
+	"chart.users_total":     "Total",
+	"chart.errors_title":    "Error rate",
+	"chart.errors_series":   "Errors",
+	"chart.downloads_title": "Daily downloads",
+	"sources.load_error":    "Failed to load source statistics.",
+	"sources.title":         "🧭 User sources\n\n",
+	"sources.totals":        "Invited: %d (%s)\nFrom campaigns: %d (%s)\nDirect: %d (%s)\n",
+	"sources.referrers":     "\n👥 Top referrers (total / active)\n",
+	"sources.campaigns":     "\n📣 Campaigns (total / active / downloaded / last 7 days)\n",
+	"sources.none":          "None yet\n",
+	"sources.link":          "\nCampaign link: https://t.me/%s?start=c_<tag>\nTag: Latin letters, digits, _ and - (up to 62 characters).",
+
+	"export.prompt":       "What should be exported?",
+	"export.range_button": "📅 Range",
+	"export.ask_period":   "%s: for which period?",
+	"export.ask_range":    "Send a date range, for example: 2025-01-01 2025-01-31 (/cancel to cancel)",
+	"export.preparing":    "⏳ Preparing the file...",
+	"export.range_format": "Invalid format. For example: 2025-01-01 2025-01-31",
+	"export.ask_format":   "%s: choose the file format",
+	"export.error":        "Export failed.",
+	"export.caption":      "%s: %d rows (%s)",
+	"export.send_error":   "Failed to send the file.",
+	"export.all_time":     "all time",
+	"export.users":        "Users",
+	"export.downloads":    "Downloads",
+	"export.broadcasts":   "Broadcasts",
+
+	"schedule.ask_time":      "Enter the send time (%s time), for example: %s\n\n/cancel to cancel",
+	"schedule.bad_time":      "Invalid time. Enter a future time in YYYY-MM-DD HH:MM format:",
+	"schedule.once":          "Once",
+	"schedule.weekly":        "Every week",
+	"schedule.ask_repeat":    "The message will be sent at %s. Repeat it?",
+	"schedule.expired":       "Scheduling has expired, please start over.",
+	"schedule.cancelled":     "Scheduling cancelled.",
+	"schedule.save_error":    "Failed to schedule the message.",
+	"schedule.saved":         "Message #%d will be sent at %s.",
+	"schedule.saved_weekly":  " It repeats every week.",
+	"schedule.load_error":    "Failed to load scheduled messages.",
+	"schedule.empty":         "No scheduled messages.",
+	"schedule.title":         "Scheduled messages:\n",
+	"schedule.weekly_mark":   " (weekly)",
+	"schedule.cancel_button": "❌ Cancel #%d",
+	"schedule.cancel_error":  "Failed to cancel the message.",
+	"sponsor.load_error":     "Failed to load the channel report.",
+	"sponsor.empty":          "There are no required channels.",
+	"sponsor.title":          "📊 Channel report\n",
+	"sponsor.ask_period":     "Enter the sponsorship period in YYYY-MM-DD format:\n• 2026-11-01 2026-11-30 — start and end dates\n• - 2026-11-30 — end date only\n• 0 — remove the period\n\n(/cancel to cancel)",
+	"sponsor.ask_target":     "After how many subscribers through the bot should the channel be removed? Send 0 to remove the target (/cancel to cancel):",
+	"sponsor.period_button":  "📅 Period",
+	"sponsor.target_button":  "🎯 Target",
+	"sponsor.back":           "⬅️ Back",
+	"sponsor.not_found":      "Channel not found.",
+	"sponsor.bad_period":     "Invalid date. For example: 2026-11-01 2026-11-30",
+	"sponsor.period_error":   "Failed to save the period.",
+	"sponsor.bad_target":     "Invalid number.",
+	"sponsor.target_error":   "Failed to save the target.",
+	"sponsor.expired":        "⏹ Sponsor channel removed from required subscriptions: %s\n%s",
+	"sponsor.unlimited":      "unlimited",
+	"sponsor.from":           "from %s",
+	"sponsor.until":          "until %s",
+	"sponsor.period":         "Period: %s",
+	"sponsor.inactive":       " (inactive)",
+	"sponsor.target":         "Target: %d/%d\n",
+	"sponsor.stats":          "Shown: %d · Subscribed: %d (%.1f%%) · Left: %d",
+
+	"backup.failed":          "❌ Failed to create a backup. See \"%s\" for details.",
+	"backup.done":            "✅ Backup: %.1f MB, %s",
+	"backup.part":            "%s\nPart %d/%d. Join with: cat %s.part* > %s",
+	"restore.ask_file":       "Send the backup file to restore (.sql, .sql.gz, .jsonl or .jsonl.gz, up to 20 MB) (/cancel to cancel):",
+	"restore.bad_file":       "Please send a .sql, .sql.gz, .jsonl or .jsonl.gz file.",
+	"restore.too_big":        "Couldn't download the file. It must not exceed 20 MB.",
+	"restore.download_error": "Couldn't download the file.",
+	"restore.confirm_button": "Yes, restore",
+	"restore.confirm":        "⚠️ %s will be restored into the database and the current data replaced. The current database is backed up first.\n\nContinue?",
+	"restore.no_file":        "No file to restore.",
+	"restore.cancelled":      "Restore cancelled.",
+	"restore.running":        "⏳ Restoring the database...",
+	"restore.safety_failed":  "Couldn't back up the current database, restore aborted.",
+	"restore.failed":         "❌ Restore failed, changes were rolled back.\nPrevious state: %s",
+	"restore.done":           "✅ Database restored.\nPrevious state: %s",
+
+	"inline.unavailable": "Inline mode is currently disabled, message the bot",
+
+	"fav.col_progress": "📁 Sent %d of %d files",
+	"fav.col_more":     "⬇️ More",
+
+	"inline.preparing": "⏳ Preparing, try again in a few seconds",
+	"inline.busy":      "⏳ The bot is busy, try again shortly",
+}
@@ -0,0 +1,124 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Lang interfeys tili
+type Lang string
+
+const (
+	UzLatin    Lang = "uz"
+	UzCyrillic Lang = "uz-Cyrl"
+	Russian    Lang = "ru"
+	English    Lang = "en"
+
+	Default = UzLatin
+)
+
+// Languages tanlash tugmalari tartibi
+var Languages = []Lang{UzLatin, UzCyrillic, Russian, English}
+
+var catalogs = map[Lang]map[string]string{
+	UzLatin:    uzLatin,
+	UzCyrillic: uzCyrillic,
+	Russian:    russian,
+	English:    english,
+}
+
+// Resolve foydalanuvchi tanlagan tilni yoki uning Telegram language_code'ini qaytaradi
+// (main'da bazadan o'qish uchun o'rnatiladi).
+var Resolve func(userID int64) string
+
+var (
+	mu    sync.Mutex
+	users = make(map[int64]Lang)
+)
+
+// Parse saqlangan til kodini yoki Telegram language_code'ini interfeys tiliga keltiradi
+func Parse(code string) Lang {
+	code = strings.ToLower(strings.TrimSpace(code))
+	for _, l := range Languages {
+		if code == strings.ToLower(string(l)) {
+			return l
+		}
+	}
+
+	switch {
+	case code == "":
+		return Default
+	case strings.HasPrefix(code, "uz"):
+		return UzLatin
+	case strings.HasPrefix(code, "ru"), strings.HasPrefix(code, "uk"), strings.HasPrefix(code, "be"),
+		strings.HasPrefix(code, "kk"), strings.HasPrefix(code, "ky"), strings.HasPrefix(code, "tg"):
+		return Russian
+	default:
+		return English
+	}
+}
+
+// For foydalanuvchi tilini keshdan yoki Resolve orqali oladi
+func For(userID int64) Lang {
+	mu.Lock()
+	lang, ok := users[userID]
+	mu.Unlock()
+	if ok {
+		return lang
+	}
+
+	code := ""
+	if Resolve != nil {
+		code = Resolve(userID)
+	}
+	lang = Parse(code)
+	Set(userID, lang)
+	return lang
+}
+
+// Set foydalanuvchi tilini keshga yozadi
+func Set(userID int64, lang Lang) {
+	mu.Lock()
+	defer mu.Unlock()
+	// Kesh cheksiz o'smasligi uchun
+	if len(users) > 100000 {
+		users = make(map[int64]Lang)
+	}
+	users[userID] = lang
+}
+
+// T kalit bo'yicha matnni qaytaradi. Tarjima topilmasa o'zbekcha matn, u ham bo'lmasa kalitning o'zi olinadi.
+func (l Lang) T(key string, args ...interface{}) string {
+	text, ok := catalogs[l][key]
+	if !ok {
+		if text, ok = catalogs[Default][key]; !ok {
+			text = key
+		}
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+	return text
+}
+
+// Name til nomi o'sha tilning o'zida
+func (l Lang) Name() string {
+	return l.T("lang.name")
+}
+
+// Match tugma matnini barcha tillardan qidirib, uning kalitini qaytaradi.
+// Faqat prefix bilan boshlanadigan kalitlar qidiriladi (masalan "menu.").
+func Match(text, prefix string) string {
+	if text == "" {
+		return ""
+	}
+	for _, l := range Languages {
+		for key, value := range catalogs[l] {
+			if value == text && strings.HasPrefix(key, prefix) {
+				return key
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,380 @@
+package i18n
+
+var russian = map[string]string{
+	"lang.name":    "🇷🇺 Русский",
+	"lang.choose":  "🌐 Выберите язык:",
+	"lang.changed": "✅ Язык интерфейса: %s",
+
+	"cmd.start":      "Запустить бота",
+	"cmd.help":       "Помощь",
+	"cmd.history":    "История загрузок",
+	"cmd.favourites": "Избранное и подборки",
+	"cmd.invite":     "Пригласить друзей",
+	"cmd.settings":   "Настройки",
+	"cmd.lang":       "Сменить язык",
+	"cmd.cancel":     "Отменить текущее действие",
+	"cmd.admin":      "Панель администратора",
+
+	"help.text": "ℹ️ Отправьте ссылку на видео или пост, и я его скачаю.\n\n" +
+		"Поддерживается:\n• Instagram — reels и посты\n• TikTok — видео\n• YouTube — видео и аудио (до 50 МБ)\n\n" +
+		"Из видео Instagram и TikTok могу извлечь аудио.\n\n" +
+		"В любом чате напишите username бота и ссылку — видео отправится прямо туда.\n\n" +
+		"Команды:\n/history — история загрузок\n/favourites — избранное\n/invite — пригласить друзей\n/settings — настройки\n/lang — сменить язык\n/cancel — отменить текущее действие",
+
+	"settings.title":            "⚙️ Настройки\n\nНажмите на кнопку, чтобы изменить значение.",
+	"settings.lang":             "🌐 Язык: %s",
+	"settings.audio":            "🎵 Аудио: %s",
+	"settings.audio.ask":        "спрашивать",
+	"settings.audio.always":     "всегда",
+	"settings.audio.never":      "не отправлять",
+	"settings.quality":          "📺 Качество YouTube: %s",
+	"settings.quality.ask":      "выбирать из списка",
+	"settings.quality.audio":    "только аудио",
+	"settings.send_as":          "📦 Отправлять: %s",
+	"settings.send_as.video":    "видео",
+	"settings.send_as.document": "файлом",
+	"settings.caption":          "📝 Подпись: %s",
+	"settings.watermark":        "💧 TikTok без водяного знака: %s",
+	"settings.on":               "вкл.",
+	"settings.off":              "выкл.",
+
+	"cancel.done":    "Отменено.",
+	"cancel.nothing": "Нечего отменять.",
+
+	"start.welcome":      "👋 Здравствуйте, [%s](tg://user?id=%d), добро пожаловать!\n\nЯ помогу скачать видео из Instagram и TikTok.\n\nПожалуйста, отправьте мне ссылку на видео.",
+	"start.welcome_back": "👋 Здравствуйте, [%s](tg://user?id=%d), добро пожаловать!",
+
+	"sub.gate":         "Пожалуйста, подпишитесь на каналы.",
+	"sub.check":        "Я подписался",
+	"sub.join_request": "✅ Ваша заявка в канал %s принята. Теперь нажмите кнопку \"Я подписался\".",
+
+	"download.caption":        "Запрошенное вами видео.",
+	"download.audio_prompt":   "Запрошенное вами видео.\n\nСкачать его аудио?",
+	"download.audio_question": "Скачать его аудио?",
+	"download.yes":            "Да",
+	"download.no":             "Нет",
+	"download.audio_caption":  "Аудио из видео:",
+	"download.audio_error":    "❌ Не удалось извлечь аудио.",
+	"download.error":          "❌ Ошибка при скачивании видео.",
+	"download.error_detail":   "❌ Ошибка при скачивании видео: %s",
+	"download.not_found":      "❌ Не удалось скачать видео. Попробуйте другую ссылку.",
+	"download.api_error":      "❌ Ошибка при чтении ответа API.",
+	"download.problem":        "❌ Не получается скачать видео.",
+	"download.file_error":     "❌ Ошибка при скачивании видео.",
+	"download.rate_limited":   "⏳ Слишком много запросов. Попробуйте снова через %d сек.",
+
+	"youtube.meta_error":   "❌ Не удалось получить информацию о видео.",
+	"youtube.caption":      "*%s*\nДлительность: %s\nВыберите формат для скачивания:",
+	"youtube.audio_button": "Аудио - %.1fMB",
+	"youtube.format_error": "Ошибка при скачивании выбранного формата.",
+	"youtube.too_large":    "Извините, размер файла больше 50 МБ. Не могу отправить.",
+
+	"inline.hint":         "Введите ссылку Instagram, TikTok или YouTube",
+	"inline.subscribe":    "Сначала подпишитесь на каналы",
+	"inline.rate_limited": "⏳ Попробуйте снова через %d сек.",
+	"inline.error":        "❌ Не удалось скачать, попробуйте в боте",
+	"inline.title":        "Видео",
+	"inline.description":  "Нажмите, чтобы отправить",
+	"inline.caption":      "📥 Скачано через @%s",
+
+	"group.welcome":     "👋 Привет! Я скачиваю ссылки Instagram, TikTok и YouTube, отправленные в группу.\n\nЧтобы я видел ссылки, сделайте меня администратором группы. Настройки: /settings (для администраторов группы).",
+	"group.settings":    "⚙️ Настройки группы\n\nНажмите кнопку, чтобы изменить значение.",
+	"group.platform":    "%s: %s",
+	"group.delete_link": "🗑 Удалять сообщение со ссылкой: %s",
+	"group.reply":       "↩️ Отправлять ответом: %s",
+	"group.quiet":       "🔕 Тихий режим: %s",
+	"group.admin_only":  "Это могут делать только администраторы группы.",
+	"group.error":       "❌ Не удалось скачать по этой ссылке.",
+	"group.caption":     "👤 %s",
+
+	"history.title":         "📂 История загрузок (страница %d)",
+	"history.empty":         "📂 История загрузок пуста.",
+	"history.error":         "❌ Не удалось получить историю.",
+	"history.not_found":     "Файл не найден или больше недоступен.",
+	"history.prev":          "⬅️ Назад",
+	"history.next":          "Далее ➡️",
+	"history.clear":         "🗑 Очистить историю",
+	"history.clear_confirm": "Очистить историю загрузок?",
+	"history.clear_yes":     "Да, очистить",
+	"history.clear_no":      "Нет",
+	"history.cleared":       "✅ История очищена.",
+
+	"fav.title":             "⭐ Избранное: %d файлов.\n\nВыберите подборку:",
+	"fav.empty":             "⭐ Избранное пусто.\n\nОтметьте файл кнопкой ⭐ в списке /history.",
+	"fav.all":               "⭐ Все (%d)",
+	"fav.all_name":          "Всё избранное",
+	"fav.new_collection":    "➕ Новая подборка",
+	"fav.collection_title":  "📁 %s (страница %d)",
+	"fav.collection_empty":  "Здесь пока нет файлов.",
+	"fav.share":             "🔗 Поделиться",
+	"fav.delete_collection": "🗑 Удалить подборку",
+	"fav.back":              "⬅️ Назад",
+	"fav.moved":             "✅ Перемещено",
+	"fav.no_collection":     "Без подборки",
+	"fav.choose_collection": "В какую подборку переместить файл?",
+	"fav.name_prompt":       "Отправьте название новой подборки (до 64 символов). Для отмены /cancel",
+	"fav.name_invalid":      "Название подборки должно быть от 1 до 64 символов.",
+	"fav.limit":             "Можно создать не более %d подборок.",
+	"fav.delete_confirm":    "Удалить подборку? Файлы останутся в избранном.",
+	"fav.delete_yes":        "Да, удалить",
+	"fav.added":             "⭐ Добавлено в избранное",
+	"fav.already":           "Этот файл уже в избранном",
+	"fav.error":             "❌ Ошибка при работе с избранным.",
+	"fav.share_link":        "🔗 Ссылка на подборку \"%s\":\n%s\n\nКаждый, кто откроет ссылку, получит файлы подборки.",
+	"fav.col_not_found":     "❌ Подборка не найдена или не опубликована.",
+	"fav.col_shared":        "📁 %s — файлов: %d",
+
+	"ref.invite":      "👥 Ваша пригласительная ссылка:\n%s\n\nПрисоединились по ней: %d",
+	"ref.joined":      "🎉 По вашей ссылке пришёл новый пользователь: %s. Приглашено друзей: %d",
+	"share.link":      "🔗 Ссылка на файл:\n%s\n\nКаждый, кто её откроет, сразу получит файл.",
+	"share.not_found": "❌ Файл не найден или ссылка недействительна.",
+
+	"admin.not_admin":       "Вы не администратор.",
+	"admin.menu":            "Команды администратора (%s):",
+	"admin.channel_prompt":  "Отправьте ссылку, @username или ID канала, либо перешлите сообщение из канала (например, https://t.me/your_channel).\nБот должен быть администратором канала.",
+	"admin.remove_prompt":   "Отправьте ID администратора для удаления:",
+	"admin.schedule_prompt": "Отправьте сообщение, которое хотите запланировать (для отмены /cancel):",
+
+	"menu.stats":          "Статистика",
+	"menu.broadcast":      "Рассылка",
+	"menu.schedule":       "Запланировать",
+	"menu.scheduled":      "Запланированные",
+	"menu.channel_add":    "Добавить канал",
+	"menu.channel_delete": "Удалить канал",
+	"menu.channels":       "Каналы",
+	"menu.admins":         "Администраторы",
+	"menu.admin_add":      "Добавить админа",
+	"menu.admin_remove":   "Удалить админа",
+	"menu.backup":         "Создать бэкап",
+	"menu.restore":        "Восстановить бэкап",
+	"menu.backups":        "Бэкапы",
+	"menu.export":         "Экспорт",
+	"menu.audit":          "Аудит",
+	"menu.sources":        "Источники",
+
+	"common.yes":                      "Да",
+	"common.no":                       "Нет",
+	"common.cancel":                   "Отмена",
+	"role.owner":                      "Владелец",
+	"role.admin":                      "Администратор",
+	"role.moderator":                  "Модератор",
+	"role.analyst":                    "Аналитик",
+	"channel.invite_link_unsupported": "По пригласительной ссылке нельзя определить канал. Отправьте ID канала или перешлите сообщение из канала.",
+	"channel.bad_format":              "Неверный формат. Например: https://t.me/your_channel, @your_channel или -1001234567890.",
+	"channel.not_found":               "Канал не найден. Проверьте ссылку или сначала сделайте бота администратором канала.",
+	"channel.not_channel":             "Это не канал и не группа.",
+	"channel.bot_not_admin":           "Бот не является администратором этого канала. Сначала сделайте его администратором, иначе подписку не проверить.",
+	"channel.private":                 "%s — закрытый канал. Бот создаст пригласительную ссылку для подписки.\n\nПо ссылке с заявкой пользователь считается подписанным, как только отправит заявку.",
+	"channel.invite_plain":            "🔗 Обычная ссылка",
+	"channel.invite_request":          "📨 С заявкой на вступление",
+	"channel.invite_name":             "Обязательная подписка",
+	"channel.invite_error":            "Не удалось создать пригласительную ссылку. Дайте боту право «Пригласительные ссылки» в канале.",
+	"channel.add_cancelled":           "Добавление канала отменено.",
+	"channel.add_error":               "Не удалось добавить канал.",
+	"channel.exists":                  "%s уже добавлен.",
+	"channel.added":                   "Канал %s добавлен.",
+	"channel.added_link":              "\nСсылка: %s",
+	"channel.list_error":              "Не удалось получить список каналов.",
+	"channel.delete_choose":           "Выберите канал для удаления:",
+	"channel.delete_confirm":          "Удалить канал %s?",
+	"channel.delete_error":            "Не удалось удалить канал.",
+	"channel.deleted":                 "Канал %s удалён.",
+	"channel.delete_cancelled":        "Удаление канала отменено.",
+	"admins.title":                    "👥 Администраторы (%d):",
+	"admins.list_error":               "Не удалось получить список администраторов.",
+	"admins.add":                      "➕ Добавить администратора",
+	"admins.remove_confirm":           "Удалить %s (%d) из администраторов?",
+	"admins.pick_user":                "👤 Выбрать пользователя",
+	"admins.pick_cancel":              "Отмена",
+	"admins.pick_prompt":              "Выберите нового администратора:\n• нажмите кнопку «%s»,\n• перешлите его сообщение или отправьте контакт,\n• или напишите ID и роль (например: 123456789 moderator).\nРоли: admin, moderator, analyst. Без роли назначается admin.",
+	"admins.choose_role":              "Выберите роль:",
+	"admins.add_cancelled":            "Добавление администратора отменено.",
+	"admins.hidden_forward":           "Пользователь скрыл аккаунт в настройках пересылки. Используйте кнопку «%s» или ID.",
+	"admins.contact_no_account":       "Этот контакт не привязан к аккаунту Telegram.",
+	"admins.bad_id":                   "Неверный формат ID администратора.",
+	"admins.bad_role":                 "Неверная роль. Доступные роли: admin, moderator, analyst.",
+	"admins.owner_role":               "Роль владельца бота изменить нельзя.",
+	"admins.add_error":                "Не удалось добавить администратора.",
+	"admins.added":                    "Администратор добавлен (%s).",
+	"admins.remove_self":              "Нельзя удалить самого себя.",
+	"admins.remove_owner":             "Владельца бота удалить нельзя.",
+	"admins.remove_error":             "Не удалось удалить администратора.",
+	"admins.not_found":                "Такой администратор не найден.",
+	"admins.removed":                  "Администратор удалён.",
+
+	"audience.prompt":         "Кому отправить сообщение?",
+	"audience.all_button":     "Все пользователи",
+	"audience.new_button":     "Новые: %d дн.",
+	"audience.active_button":  "Активные: %d дн.",
+	"audience.lang_uz":        "🇺🇿 Узбекский",
+	"audience.lang_ru":        "🇷🇺 Русский",
+	"audience.lang_en":        "🇬🇧 Английский",
+	"audience.all":            "все пользователи",
+	"audience.new":            "присоединившиеся за последние %d дн.",
+	"audience.active":         "активные за последние %d дн.",
+	"audience.language":       "язык: %s",
+	"audience.platform":       "пользовавшиеся %s",
+	"audience.count_error":    "Не удалось получить пользователей.",
+	"broadcast.ask_message":   "Аудитория: %s (%d польз.)\n\nОтправьте сообщение для рассылки (для отмены /cancel):",
+	"broadcast.button_format": "Неверный формат. Например: Подробнее | https://example.com",
+	"broadcast.draft_missing": "Сообщение не найдено, начните заново.",
+	"broadcast.ask_variant":   "Отправьте сообщение варианта B (для отмены /cancel):",
+	"broadcast.ask_button":    "Отправьте кнопку в виде «Текст | ссылка», например: Подробнее | https://example.com",
+	"broadcast.start_error":   "Не удалось запустить рассылку.",
+	"broadcast.cancelled":     "Рассылка отменена.",
+	"broadcast.add_variant":   "🅱️ Добавить вариант B",
+	"broadcast.add_button":    "🔘 Добавить кнопку",
+	"broadcast.send":          "✅ Отправить",
+	"broadcast.cancel":        "❌ Отменить",
+	"broadcast.audience":      "Аудитория: %s",
+	"broadcast.ab_note":       "\nA/B тест: пользователи делятся поровну между двумя вариантами.",
+	"broadcast.button_line":   "\nКнопка: %s → %s",
+
+	"broadcast.starting":         "Запуск рассылки...",
+	"broadcast.finished":         "Рассылка завершена.",
+	"broadcast.variant_stats":    "\n\nВариант %s: отправлено %d/%d, ошибок %d, заблокировали %d",
+	"broadcast.variant_clicks":   ", нажатий %d (%s)",
+	"broadcast.status_running":   "⏳ Отправляется",
+	"broadcast.status_paused":    "⏸ Приостановлена",
+	"broadcast.status_cancelled": "❌ Отменена",
+	"broadcast.status_completed": "✅ Завершена",
+	"broadcast.progress":         "Рассылка #%d: %s\nАудитория: %s\n\nОтправлено: %d\nОшибок: %d\nЗаблокировали бота: %d\nОсталось: %d\nВсего: %d",
+	"broadcast.clicks":           "\nНажатий кнопки: %d (%s)",
+	"broadcast.eta":              "\nОсталось примерно: %s",
+	"broadcast.pause":            "⏸ Приостановить",
+	"broadcast.resume":           "▶️ Продолжить",
+	"broadcast.status_error":     "Не удалось изменить статус рассылки.",
+
+	"audit.load_error":          "Не удалось получить журнал действий.",
+	"audit.ask_actor":           "Отправьте ID администратора или перешлите его сообщение (для отмены /cancel):",
+	"audit.title":               "📜 Действия администраторов (стр. %d)\n",
+	"audit.actor":               "Администратор: %d\n",
+	"audit.section":             "Раздел: %s\n",
+	"audit.empty":               "Записей не найдено.",
+	"audit.system":              "система",
+	"audit.by_actor":            "👤 По администратору",
+	"audit.all_actors":          "✖️ Все администраторы",
+	"audit.section_all":         "Все",
+	"audit.section_channel":     "Каналы",
+	"audit.section_admin":       "Администраторы",
+	"audit.section_broadcast":   "Рассылки",
+	"audit.section_schedule":    "Расписание",
+	"audit.section_backup":      "Бэкапы",
+	"audit.section_export":      "Экспорт",
+	"page.prev":                 "⬅️ Назад",
+	"page.next":                 "Далее ➡️",
+	"backups.load_error":        "Не удалось получить список бэкапов.",
+	"backups.title":             "💾 Бэкапы\n\n",
+	"backups.next":              "Следующий автоматический бэкап: %s (%s)\n",
+	"backups.auto_off":          "Автоматический бэкап отключён\n",
+	"backups.retention":         "Хранятся: последние %d\n\n",
+	"backups.empty":             "Бэкапов пока нет.",
+	"backups.trigger_scheduled": "автоматически",
+	"backups.trigger_manual":    "вручную",
+
+	"period.today":          "Сегодня",
+	"period.7d":             "7 дней",
+	"period.30d":            "30 дней",
+	"period.90d":            "90 дней",
+	"period.all":            "Всё время",
+	"stats.load_error":      "Не удалось получить статистику.",
+	"stats.title":           "📊 Статистика: %s\n\n",
+	"stats.users":           "👥 Пользователи\nВсего пользователей: %d\nНовых: %d\nDAU / WAU / MAU: %d / %d / %d\nЗаблокировали бота: %d\nУдалённых аккаунтов: %d\n",
+	"stats.downloads":       "\n📥 Загрузки\n",
+	"stats.downloads_total": "Всего: %d, успешно: %s\nСреднее время загрузки: %s\nОтправлено: %s\nИз кэша: %s\n",
+	"stats.daily":           "\n📅 По дням\n",
+	"stats.top_errors":      "\n⚠️ Частые ошибки\n",
+	"chart.users":           "Пользователи",
+	"chart.downloads":       "Загрузки",
+	"chart.errors":          "Ошибки",
+	"chart.error":           "Не удалось построить график.",
+	"chart.users_title":     "Число пользователей",
+	"chart.users_total":     "Всего",
+	"chart.errors_title":    "Доля ошибок",
+	"chart.errors_series":   "Ошибки",
+	"chart.downloads_title": "Загрузки по дням",
+	"sources.load_error":    "Не удалось получить статистику источников.",
+	"sources.title":         "🧭 Источники пользователей\n\n",
+	"sources.totals":        "По приглашению: %d (%s)\nИз кампаний: %d (%s)\nНапрямую: %d (%s)\n",
+	"sources.referrers":     "\n👥 Больше всех пригласили (всего / активных)\n",
+	"sources.campaigns":     "\n📣 Кампании (всего / активных / скачивали / за 7 дней)\n",
+	"sources.none":          "Пока нет\n",
+	"sources.link":          "\nСсылка кампании: https://t.me/%s?start=c_<тег>\nТег: латинские буквы, цифры, _ и - (до 62 символов).",
+
+	"export.prompt":       "Какие данные экспортировать?",
+	"export.range_button": "📅 Период",
+	"export.ask_period":   "%s: за какой период?",
+	"export.ask_range":    "Отправьте диапазон дат, например: 2025-01-01 2025-01-31 (для отмены /cancel)",
+	"export.preparing":    "⏳ Готовлю файл...",
+	"export.range_format": "Неверный формат. Например: 2025-01-01 2025-01-31",
+	"export.ask_format":   "%s: выберите формат файла",
+	"export.error":        "Не удалось выполнить экспорт.",
+	"export.caption":      "%s: %d строк (%s)",
+	"export.send_error":   "Не удалось отправить файл.",
+	"export.all_time":     "всё время",
+	"export.users":        "Пользователи",
+	"export.downloads":    "Загрузки",
+	"export.broadcasts":   "Рассылки",
+
+	"schedule.ask_time":      "Введите время отправки (по времени %s), например: %s\n\nДля отмены /cancel",
+	"schedule.bad_time":      "Неверное время. Введите время в будущем в формате YYYY-MM-DD HH:MM:",
+	"schedule.once":          "Один раз",
+	"schedule.weekly":        "Каждую неделю",
+	"schedule.ask_repeat":    "Сообщение будет отправлено %s. Повторять?",
+	"schedule.expired":       "Время планирования истекло, начните заново.",
+	"schedule.cancelled":     "Планирование отменено.",
+	"schedule.save_error":    "Не удалось запланировать сообщение.",
+	"schedule.saved":         "Сообщение #%d будет отправлено %s.",
+	"schedule.saved_weekly":  " Повторяется каждую неделю.",
+	"schedule.load_error":    "Не удалось получить запланированные сообщения.",
+	"schedule.empty":         "Запланированных сообщений нет.",
+	"schedule.title":         "Запланированные сообщения:\n",
+	"schedule.weekly_mark":   " (каждую неделю)",
+	"schedule.cancel_button": "❌ Отменить #%d",
+	"schedule.cancel_error":  "Не удалось отменить сообщение.",
+	"sponsor.load_error":     "Не удалось получить отчёт по каналам.",
+	"sponsor.empty":          "Каналов обязательной подписки нет.",
+	"sponsor.title":          "📊 Отчёт по каналам\n",
+	"sponsor.ask_period":     "Введите срок спонсорства в формате YYYY-MM-DD:\n• 2026-11-01 2026-11-30 — даты начала и окончания\n• - 2026-11-30 — только дата окончания\n• 0 — снять ограничение срока\n\n(Для отмены /cancel)",
+	"sponsor.ask_target":     "После скольких подписчиков через бота убрать канал? Отправьте 0, чтобы снять цель (для отмены /cancel):",
+	"sponsor.period_button":  "📅 Срок",
+	"sponsor.target_button":  "🎯 Цель",
+	"sponsor.back":           "⬅️ Назад",
+	"sponsor.not_found":      "Канал не найден.",
+	"sponsor.bad_period":     "Неверная дата. Например: 2026-11-01 2026-11-30",
+	"sponsor.period_error":   "Не удалось сохранить срок.",
+	"sponsor.bad_target":     "Неверное число.",
+	"sponsor.target_error":   "Не удалось сохранить цель.",
+	"sponsor.expired":        "⏹ Спонсорский канал убран из обязательной подписки: %s\n%s",
+	"sponsor.unlimited":      "без ограничений",
+	"sponsor.from":           "с %s",
+	"sponsor.until":          "до %s",
+	"sponsor.period":         "Срок: %s",
+	"sponsor.inactive":       " (не активен)",
+	"sponsor.target":         "Цель: %d/%d\n",
+	"sponsor.stats":          "Показов: %d · Подписались: %d (%.1f%%) · Отписались: %d",
+
+	"backup.failed":          "❌ Не удалось создать бэкап. Подробности в разделе «%s».",
+	"backup.done":            "✅ Бэкап: %.1f MB, %s",
+	"backup.part":            "%s\nЧасть %d/%d. Объединить: cat %s.part* > %s",
+	"restore.ask_file":       "Отправьте файл бэкапа для восстановления (.sql, .sql.gz, .jsonl или .jsonl.gz, до 20 MB) (для отмены /cancel):",
+	"restore.bad_file":       "Пожалуйста, отправьте файл .sql, .sql.gz, .jsonl или .jsonl.gz.",
+	"restore.too_big":        "Не удалось скачать файл. Размер файла не должен превышать 20 MB.",
+	"restore.download_error": "Не удалось скачать файл.",
+	"restore.confirm_button": "Да, восстановить",
+	"restore.confirm":        "⚠️ Файл %s будет восстановлен в базу, текущие данные будут заменены. Перед восстановлением будет сделан бэкап текущей базы.\n\nПродолжить?",
+	"restore.no_file":        "Файл для восстановления не найден.",
+	"restore.cancelled":      "Восстановление отменено.",
+	"restore.running":        "⏳ Восстанавливаю базу...",
+	"restore.safety_failed":  "Не удалось сделать бэкап текущей базы, восстановление остановлено.",
+	"restore.failed":         "❌ Не удалось восстановить базу, изменения отменены.\nПредыдущее состояние: %s",
+	"restore.done":           "✅ База успешно восстановлена.\nПредыдущее состояние: %s",
+
+	"inline.unavailable": "Инлайн-режим сейчас отключён, напишите боту",
+
+	"fav.col_progress": "📁 Отправлено файлов: %d из %d",
+	"fav.col_more":     "⬇️ Ещё",
+
+	"inline.preparing": "⏳ Готовится, повторите запрос через несколько секунд",
+	"inline.busy":      "⏳ Бот занят, попробуйте чуть позже",
+}
@@ -0,0 +1,380 @@
+package i18n
+
+var uzLatin = map[string]string{
+	"lang.name":    "🇺🇿 O'zbekcha",
+	"lang.choose":  "🌐 Tilni tanlang:",
+	"lang.changed": "✅ Interfeys tili: %s",
+
+	"cmd.start":      "Botni ishga tushirish",
+	"cmd.help":       "Yordam",
+	"cmd.history":    "Yuklashlar tarixi",
+	"cmd.favourites": "Sevimlilar va to'plamlar",
+	"cmd.invite":     "Do'stlarni taklif qilish",
+	"cmd.settings":   "Sozlamalar",
+	"cmd.lang":       "Tilni o'zgartirish",
+	"cmd.cancel":     "Joriy amalni bekor qilish",
+	"cmd.admin":      "Admin panel",
+
+	"help.text": "ℹ️ Video yoki post havolasini yuboring, men uni yuklab beraman.\n\n" +
+		"Qo'llab-quvvatlanadi:\n• Instagram — reels va postlar\n• TikTok — videolar\n• YouTube — video va audio (50 MB gacha)\n\n" +
+		"Instagram va TikTok videolaridan audioni ham ajratib beraman.\n\n" +
+		"Istalgan chatda bot username'i va havolani yozib, videoni shu yerning o'zida yuborishingiz mumkin.\n\n" +
+		"Buyruqlar:\n/history — yuklashlar tarixi\n/favourites — sevimlilar\n/invite — do'stlarni taklif qilish\n/settings — sozlamalar\n/lang — tilni o'zgartirish\n/cancel — joriy amalni bekor qilish",
+
+	"settings.title":            "⚙️ Sozlamalar\n\nTugmani bosib qiymatni o'zgartiring.",
+	"settings.lang":             "🌐 Til: %s",
+	"settings.audio":            "🎵 Audio: %s",
+	"settings.audio.ask":        "so'ralsin",
+	"settings.audio.always":     "har doim",
+	"settings.audio.never":      "yuborilmasin",
+	"settings.quality":          "📺 YouTube sifati: %s",
+	"settings.quality.ask":      "ro'yxatdan tanlash",
+	"settings.quality.audio":    "faqat audio",
+	"settings.send_as":          "📦 Yuborish: %s",
+	"settings.send_as.video":    "video",
+	"settings.send_as.document": "fayl",
+	"settings.caption":          "📝 Izoh: %s",
+	"settings.watermark":        "💧 TikTok suv belgisisiz: %s",
+	"settings.on":               "yoqilgan",
+	"settings.off":              "o'chirilgan",
+
+	"cancel.done":    "Bekor qilindi.",
+	"cancel.nothing": "Bekor qilinadigan amal yo'q.",
+
+	"start.welcome":      "👋 Assalomu alaykum [%s](tg://user?id=%d), botimizga xush kelibsiz.\n\nMen sizga Instagram va TikTokdan videolarni yuklashda yordam beruvchi botman.\n\n Iltimos menga video havolasini yuboring.",
+	"start.welcome_back": "👋 Assalomu alaykum [%s](tg://user?id=%d) botimizga xush kelibsiz.",
+
+	"sub.gate":         "Iltimos, kanallarga azo bo'ling.",
+	"sub.check":        "Azo bo'ldim",
+	"sub.join_request": "✅ %s kanaliga so'rovingiz qabul qilindi. Endi \"Azo bo'ldim\" tugmasini bosing.",
+
+	"download.caption":        "Siz so‘ragan video.",
+	"download.audio_prompt":   "Siz so‘ragan video.\n\nAudiosini yuklashni istaysizmi?",
+	"download.audio_question": "Audiosini yuklashni istaysizmi?",
+	"download.yes":            "Ha",
+	"download.no":             "Yo‘q",
+	"download.audio_caption":  "Mana videoning audio fayli:",
+	"download.audio_error":    "❌ Audio ajratishda xatolik yuz berdi.",
+	"download.error":          "❌ Video yuklab olishda xatolik yuz berdi.",
+	"download.error_detail":   "❌ Video yuklab olishda xatolik yuz berdi: %s",
+	"download.not_found":      "❌ Video yuklab olinmadi. Iltimos, boshqa linkni sinab ko'ring.",
+	"download.api_error":      "❌ API javobini o‘qishda xatolik yuz berdi.",
+	"download.problem":        "❌ Video yuklab olishda muammo bor.",
+	"download.file_error":     "❌ Video yuklab olishda xatolik.",
+	"download.rate_limited":   "⏳ Juda ko'p so'rov yubordingiz. %d soniyadan keyin qayta urinib ko'ring.",
+
+	"youtube.meta_error":   "❌ Video ma'lumotlarini olishda xatolik yuz berdi.",
+	"youtube.caption":      "*%s*\nDavomiyligi: %s\nYuklash uchun formatni tanlang:",
+	"youtube.audio_button": "Audio - %.1fMB",
+	"youtube.format_error": "Tanlangan formatni yuklashda xatolik yuz berdi.",
+	"youtube.too_large":    "Kechirasiz, fayl hajmi 50mb dan oshdi. Jo'nata olmayman.",
+
+	"inline.hint":         "Instagram, TikTok yoki YouTube havolasini yozing",
+	"inline.subscribe":    "Avval kanallarga a'zo bo'ling",
+	"inline.rate_limited": "⏳ %d soniyadan keyin qayta urinib ko'ring",
+	"inline.error":        "❌ Yuklab bo'lmadi, botda urinib ko'ring",
+	"inline.title":        "Video",
+	"inline.description":  "Yuborish uchun bosing",
+	"inline.caption":      "📥 @%s orqali yuklandi",
+
+	"group.welcome":     "👋 Salom! Guruhga yuborilgan Instagram, TikTok va YouTube havolalarini yuklab beraman.\n\nHavolalarni ko'rishim uchun meni guruh admini qiling. Sozlamalar: /settings (guruh adminlari uchun).",
+	"group.settings":    "⚙️ Guruh sozlamalari\n\nTugmani bosib qiymatni o'zgartiring.",
+	"group.platform":    "%s: %s",
+	"group.delete_link": "🗑 Havolali xabarni o'chirish: %s",
+	"group.reply":       "↩️ Javob sifatida yuborish: %s",
+	"group.quiet":       "🔕 Jim rejim: %s",
+	"group.admin_only":  "Buni faqat guruh adminlari qila oladi.",
+	"group.error":       "❌ Bu havolani yuklab bo'lmadi.",
+	"group.caption":     "👤 %s",
+
+	"history.title":         "📂 Yuklashlar tarixi (sahifa %d)",
+	"history.empty":         "📂 Yuklashlar tarixi bo'sh.",
+	"history.error":         "❌ Tarixni olishda xatolik yuz berdi.",
+	"history.not_found":     "Fayl topilmadi yoki endi mavjud emas.",
+	"history.prev":          "⬅️ Oldingi",
+	"history.next":          "Keyingi ➡️",
+	"history.clear":         "🗑 Tarixni tozalash",
+	"history.clear_confirm": "Yuklashlar tarixini tozalashni tasdiqlaysizmi?",
+	"history.clear_yes":     "Ha, tozalash",
+	"history.clear_no":      "Yo'q",
+	"history.cleared":       "✅ Tarix tozalandi.",
+
+	"fav.title":             "⭐ Sevimlilar: %d ta fayl.\n\nTo'plamni tanlang:",
+	"fav.empty":             "⭐ Sevimlilar bo'sh.\n\n/history ro'yxatida faylni ⭐ tugmasi bilan belgilang.",
+	"fav.all":               "⭐ Barchasi (%d)",
+	"fav.all_name":          "Barcha sevimlilar",
+	"fav.new_collection":    "➕ Yangi to'plam",
+	"fav.collection_title":  "📁 %s (sahifa %d)",
+	"fav.collection_empty":  "Bu yerda hali fayl yo'q.",
+	"fav.share":             "🔗 Ulashish",
+	"fav.delete_collection": "🗑 To'plamni o'chirish",
+	"fav.back":              "⬅️ Orqaga",
+	"fav.moved":             "✅ O'tkazildi",
+	"fav.no_collection":     "Hech qaysi to'plam",
+	"fav.choose_collection": "Faylni qaysi to'plamga o'tkazamiz?",
+	"fav.name_prompt":       "Yangi to'plam nomini yuboring (64 belgigacha). Bekor qilish uchun /cancel",
+	"fav.name_invalid":      "To'plam nomi 1 dan 64 belgigacha bo'lishi kerak.",
+	"fav.limit":             "Ko'pi bilan %d ta to'plam yaratish mumkin.",
+	"fav.delete_confirm":    "To'plamni o'chirasizmi? Undagi fayllar sevimlilarda qoladi.",
+	"fav.delete_yes":        "Ha, o'chirish",
+	"fav.added":             "⭐ Sevimlilarga qo'shildi",
+	"fav.already":           "Bu fayl allaqachon sevimlilarda",
+	"fav.error":             "❌ Sevimlilar bilan ishlashda xatolik yuz berdi.",
+	"fav.share_link":        "🔗 \"%s\" to'plami havolasi:\n%s\n\nHavolani ochgan har kim to'plamdagi fayllarni oladi.",
+	"fav.col_not_found":     "❌ To'plam topilmadi yoki ulashilmagan.",
+	"fav.col_shared":        "📁 %s — %d ta fayl:",
+
+	"ref.invite":      "👥 Sizning taklif havolangiz:\n%s\n\nU orqali qo'shilganlar: %d",
+	"ref.joined":      "🎉 %s taklif havolangiz orqali qo'shildi. Taklif qilingan do'stlar: %d",
+	"share.link":      "🔗 Fayl havolasi:\n%s\n\nUni ochgan har kim faylni darhol oladi.",
+	"share.not_found": "❌ Fayl topilmadi yoki havola noto'g'ri.",
+
+	"admin.not_admin":       "Siz admin emassiz.",
+	"admin.menu":            "Admin buyrug'lari (%s):",
+	"admin.channel_prompt":  "Kanal linkini, @username yoki ID sini yuboring, yoki kanaldan habar forward qiling (masalan, https://t.me/your_channel).\nBot kanalda admin bo'lishi kerak.",
+	"admin.remove_prompt":   "Iltimos, admin ID sini o'chirish uchun yuboring:",
+	"admin.schedule_prompt": "Rejalashtirmoqchi bo'lgan habaringizni yuboring (Bekor qilish uchun /cancel):",
+
+	"menu.stats":          "Statistika",
+	"menu.broadcast":      "Habar yuborish",
+	"menu.schedule":       "Rejalashtirish",
+	"menu.scheduled":      "Rejalashtirilganlar",
+	"menu.channel_add":    "Kanal qo'shish",
+	"menu.channel_delete": "Kanal o'chirish",
+	"menu.channels":       "Kanallar",
+	"menu.admins":         "Adminlar",
+	"menu.admin_add":      "Admin qo'shish",
+	"menu.admin_remove":   "Admin o'chirish",
+	"menu.backup":         "BackUp olish",
+	"menu.restore":        "BackUp tiklash",
+	"menu.backups":        "Backuplar",
+	"menu.export":         "Eksport",
+	"menu.audit":          "Audit",
+	"menu.sources":        "Manbalar",
+
+	"common.yes":                      "Ha",
+	"common.no":                       "Yo'q",
+	"common.cancel":                   "Bekor qilish",
+	"role.owner":                      "Ega",
+	"role.admin":                      "Admin",
+	"role.moderator":                  "Moderator",
+	"role.analyst":                    "Tahlilchi",
+	"channel.invite_link_unsupported": "Taklif havolasi bo'yicha kanalni aniqlab bo'lmaydi. Kanal ID sini yuboring yoki kanaldan habar forward qiling.",
+	"channel.bad_format":              "Noto'g'ri format. Masalan: https://t.me/your_channel, @your_channel yoki -1001234567890.",
+	"channel.not_found":               "Kanal topilmadi. Havola to'g'riligini tekshiring yoki avval botni kanalga admin qiling.",
+	"channel.not_channel":             "Bu kanal yoki guruh emas.",
+	"channel.bot_not_admin":           "Bot bu kanalda admin emas. Avval botni kanalga admin qiling, aks holda obunani tekshirib bo'lmaydi.",
+	"channel.private":                 "%s yopiq kanal. Bot obuna uchun taklif havolasini yaratadi.\n\nQo'shilish so'rovi bilan havolada foydalanuvchi so'rov yuborishi bilan obuna bo'lgan hisoblanadi.",
+	"channel.invite_plain":            "🔗 Oddiy havola",
+	"channel.invite_request":          "📨 Qo'shilish so'rovi bilan",
+	"channel.invite_name":             "Majburiy obuna",
+	"channel.invite_error":            "Taklif havolasini yaratib bo'lmadi. Botga kanalda \"Foydalanuvchilarni taklif qilish\" huquqini bering.",
+	"channel.add_cancelled":           "Kanal qo'shish bekor qilindi.",
+	"channel.add_error":               "Kanalni qo'shishda xatolik yuz berdi.",
+	"channel.exists":                  "%s allaqachon qo'shilgan.",
+	"channel.added":                   "%s kanali muvaffaqiyatli qo'shildi.",
+	"channel.added_link":              "\nHavola: %s",
+	"channel.list_error":              "Kanallarni olishda xatolik yuz berdi.",
+	"channel.delete_choose":           "O'chirilishi kerak bo'lgan kanalni tanlang:",
+	"channel.delete_confirm":          "%s kanalini o'chirmoqchimisiz?",
+	"channel.delete_error":            "Kanalni o'chirishda xatolik yuz berdi.",
+	"channel.deleted":                 "%s kanali muvaffaqiyatli o'chirildi.",
+	"channel.delete_cancelled":        "Kanal o'chirish bekor qilindi.",
+	"admins.title":                    "👥 Adminlar (%d):",
+	"admins.list_error":               "Adminlarni olishda xatolik yuz berdi.",
+	"admins.add":                      "➕ Admin qo'shish",
+	"admins.remove_confirm":           "%s (%d) ni adminlikdan o'chirmoqchimisiz?",
+	"admins.pick_user":                "👤 Foydalanuvchini tanlash",
+	"admins.pick_cancel":              "Bekor qilish",
+	"admins.pick_prompt":              "Yangi adminni tanlang:\n• \"%s\" tugmasini bosing,\n• uning habarini forward qiling yoki kontaktini yuboring,\n• yoki ID va rolini yozing (masalan: 123456789 moderator).\nRollar: admin, moderator, analyst. Rol ko'rsatilmasa admin bo'ladi.",
+	"admins.choose_role":              "Rolni tanlang:",
+	"admins.add_cancelled":            "Admin qo'shish bekor qilindi.",
+	"admins.hidden_forward":           "Foydalanuvchi forward sozlamalarida akkauntini yashirgan. \"%s\" tugmasidan yoki ID dan foydalaning.",
+	"admins.contact_no_account":       "Bu kontakt Telegram akkauntga bog'lanmagan.",
+	"admins.bad_id":                   "Noto'g'ri admin ID formati.",
+	"admins.bad_role":                 "Noto'g'ri rol. Mavjud rollar: admin, moderator, analyst.",
+	"admins.owner_role":               "Bot egasining rolini o'zgartirib bo'lmaydi.",
+	"admins.add_error":                "Admin qo'shishda xatolik yuz berdi.",
+	"admins.added":                    "Admin muvaffaqiyatli qo'shildi (%s).",
+	"admins.remove_self":              "O'zingizni adminlikdan o'chira olmaysiz.",
+	"admins.remove_owner":             "Bot egasini o'chirib bo'lmaydi.",
+	"admins.remove_error":             "Admin o'chirishda xatolik yuz berdi.",
+	"admins.not_found":                "Bunday admin topilmadi.",
+	"admins.removed":                  "Admin muvaffaqiyatli o'chirildi.",
+
+	"audience.prompt":         "Habar kimlarga yuborilsin?",
+	"audience.all_button":     "Barcha foydalanuvchilar",
+	"audience.new_button":     "Yangi: %d kun",
+	"audience.active_button":  "Faol: %d kun",
+	"audience.lang_uz":        "🇺🇿 O'zbek",
+	"audience.lang_ru":        "🇷🇺 Rus",
+	"audience.lang_en":        "🇬🇧 Ingliz",
+	"audience.all":            "barcha foydalanuvchilar",
+	"audience.new":            "oxirgi %d kunda qo'shilganlar",
+	"audience.active":         "oxirgi %d kunda faol bo'lganlar",
+	"audience.language":       "til: %s",
+	"audience.platform":       "%s dan foydalanganlar",
+	"audience.count_error":    "Foydalanuvchilarni olishda xatolik yuz berdi.",
+	"broadcast.ask_message":   "Auditoriya: %s (%d ta foydalanuvchi)\n\nIltimos, yubormoqchi bo'lgan habaringizni kiriting (Bekor qilish uchun /cancel):",
+	"broadcast.button_format": "Noto'g'ri format. Masalan: Batafsil | https://example.com",
+	"broadcast.draft_missing": "Habar topilmadi, qaytadan boshlang.",
+	"broadcast.ask_variant":   "B variant habarini yuboring (Bekor qilish uchun /cancel):",
+	"broadcast.ask_button":    "Tugmani \"Matn | havola\" ko'rinishida yuboring, masalan: Batafsil | https://example.com",
+	"broadcast.start_error":   "Habar yuborishni boshlashda xatolik yuz berdi.",
+	"broadcast.cancelled":     "Habar yuborish bekor qilindi.",
+	"broadcast.add_variant":   "🅱️ B variant qo'shish",
+	"broadcast.add_button":    "🔘 Tugma qo'shish",
+	"broadcast.send":          "✅ Yuborish",
+	"broadcast.cancel":        "❌ Bekor qilish",
+	"broadcast.audience":      "Auditoriya: %s",
+	"broadcast.ab_note":       "\nA/B test: foydalanuvchilar ikki variantga teng bo'linadi.",
+	"broadcast.button_line":   "\nTugma: %s → %s",
+
+	"broadcast.starting":         "Habar yuborish boshlanmoqda...",
+	"broadcast.finished":         "Habar yuborish yakunlandi.",
+	"broadcast.variant_stats":    "\n\n%s variant: yuborildi %d/%d, xatolik %d, bloklagan %d",
+	"broadcast.variant_clicks":   ", bosishlar %d (%s)",
+	"broadcast.status_running":   "⏳ Yuborilmoqda",
+	"broadcast.status_paused":    "⏸ To'xtatilgan",
+	"broadcast.status_cancelled": "❌ Bekor qilingan",
+	"broadcast.status_completed": "✅ Yakunlandi",
+	"broadcast.progress":         "Habar #%d: %s\nAuditoriya: %s\n\nYuborildi: %d\nXatolik: %d\nBotni bloklagan: %d\nQoldi: %d\nJami: %d",
+	"broadcast.clicks":           "\nTugma bosishlar: %d (%s)",
+	"broadcast.eta":              "\nTaxminiy vaqt: %s",
+	"broadcast.pause":            "⏸ To'xtatish",
+	"broadcast.resume":           "▶️ Davom ettirish",
+	"broadcast.status_error":     "Habar holatini o'zgartirishda xatolik yuz berdi.",
+
+	"audit.load_error":          "Amallar tarixini olishda xatolik yuz berdi.",
+	"audit.ask_actor":           "Admin ID sini yuboring yoki uning habarini forward qiling (Bekor qilish uchun /cancel):",
+	"audit.title":               "📜 Adminlar amallari (sahifa %d)\n",
+	"audit.actor":               "Admin: %d\n",
+	"audit.section":             "Bo'lim: %s\n",
+	"audit.empty":               "Yozuvlar topilmadi.",
+	"audit.system":              "tizim",
+	"audit.by_actor":            "👤 Admin bo'yicha",
+	"audit.all_actors":          "✖️ Barcha adminlar",
+	"audit.section_all":         "Hammasi",
+	"audit.section_channel":     "Kanallar",
+	"audit.section_admin":       "Adminlar",
+	"audit.section_broadcast":   "Habarlar",
+	"audit.section_schedule":    "Rejalar",
+	"audit.section_backup":      "Backup",
+	"audit.section_export":      "Eksport",
+	"page.prev":                 "⬅️ Oldingi",
+	"page.next":                 "Keyingi ➡️",
+	"backups.load_error":        "Backuplar ro'yxatini olishda xatolik yuz berdi.",
+	"backups.title":             "💾 Backuplar\n\n",
+	"backups.next":              "Keyingi avtomatik backup: %s (%s)\n",
+	"backups.auto_off":          "Avtomatik backup o'chirilgan\n",
+	"backups.retention":         "Saqlanadi: oxirgi %d ta\n\n",
+	"backups.empty":             "Hali backup olinmagan.",
+	"backups.trigger_scheduled": "avtomatik",
+	"backups.trigger_manual":    "qo'lda",
+
+	"period.today":          "Bugun",
+	"period.7d":             "7 kun",
+	"period.30d":            "30 kun",
+	"period.90d":            "90 kun",
+	"period.all":            "Hammasi",
+	"stats.load_error":      "Statistikani olishda xatolik yuz berdi.",
+	"stats.title":           "📊 Statistika: %s\n\n",
+	"stats.users":           "👥 Foydalanuvchilar\nUmumiy foydalanuvchilar soni: %d\nYangi qo'shilganlar: %d\nDAU / WAU / MAU: %d / %d / %d\nBotni bloklaganlar: %d\nO'chirilgan akkauntlar: %d\n",
+	"stats.downloads":       "\n📥 Yuklashlar\n",
+	"stats.downloads_total": "Jami: %d, muvaffaqiyatli: %s\nO'rtacha yuklash vaqti: %s\nYuborilgan hajm: %s\nKeshdan yuborilgan: %s\n",
+	"stats.daily":           "\n📅 Kunlar bo'yicha\n",
+	"stats.top_errors":      "\n⚠️ Ko'p uchragan xatoliklar\n",
+	"chart.users":           "Foydalanuvchilar",
+	"chart.downloads":       "Yuklashlar",
+	"chart.errors":          "Xatoliklar",
+	"chart.error":           "Grafikni chizishda xatolik yuz berdi.",
+	"chart.users_title":     "Foydalanuvchilar soni",
+	"chart.users_total":     "Jami",
+	"chart.errors_title":    "Xatoliklar ulushi",
+	"chart.errors_series":   "Xatolik",
+	"chart.downloads_title": "Kunlik yuklashlar",
+	"sources.load_error":    "Manbalar statistikasini olishda xatolik yuz berdi.",
+	"sources.title":         "🧭 Foydalanuvchilar manbalari\n\n",
+	"sources.totals":        "Taklif orqali: %d (%s)\nKampaniyalardan: %d (%s)\nTo'g'ridan-to'g'ri: %d (%s)\n",
+	"sources.referrers":     "\n👥 Eng ko'p taklif qilganlar (jami / faol)\n",
+	"sources.campaigns":     "\n📣 Kampaniyalar (jami / faol / yuklagan / 7 kunda)\n",
+	"sources.none":          "Hali yo'q\n",
+	"sources.link":          "\nKampaniya havolasi: https://t.me/%s?start=c_<teg>\nTeg: lotin harflari, raqamlar, _ va - (62 belgigacha).",
+
+	"export.prompt":       "Qaysi ma'lumotlarni eksport qilamiz?",
+	"export.range_button": "📅 Oraliq",
+	"export.ask_period":   "%s: qaysi davr uchun?",
+	"export.ask_range":    "Sanalar oralig'ini yuboring, masalan: 2025-01-01 2025-01-31 (Bekor qilish uchun /cancel)",
+	"export.preparing":    "⏳ Fayl tayyorlanmoqda...",
+	"export.range_format": "Noto'g'ri format. Masalan: 2025-01-01 2025-01-31",
+	"export.ask_format":   "%s: fayl formatini tanlang",
+	"export.error":        "Eksport qilishda xatolik yuz berdi.",
+	"export.caption":      "%s: %d ta qator (%s)",
+	"export.send_error":   "Faylni yuborishda xatolik yuz berdi.",
+	"export.all_time":     "barcha vaqt",
+	"export.users":        "Foydalanuvchilar",
+	"export.downloads":    "Yuklashlar",
+	"export.broadcasts":   "Habarlar",
+
+	"schedule.ask_time":      "Yuborish vaqtini kiriting (%s vaqti bilan), masalan: %s\n\nBekor qilish uchun /cancel",
+	"schedule.bad_time":      "Noto'g'ri vaqt. Kelajakdagi vaqtni YYYY-MM-DD HH:MM formatida kiriting:",
+	"schedule.once":          "Bir marta",
+	"schedule.weekly":        "Har hafta",
+	"schedule.ask_repeat":    "Habar %s da yuboriladi. Takrorlansinmi?",
+	"schedule.expired":       "Rejalashtirish muddati o'tgan, qaytadan boshlang.",
+	"schedule.cancelled":     "Rejalashtirish bekor qilindi.",
+	"schedule.save_error":    "Habarni rejalashtirishda xatolik yuz berdi.",
+	"schedule.saved":         "Habar #%d %s da yuboriladi.",
+	"schedule.saved_weekly":  " Har hafta takrorlanadi.",
+	"schedule.load_error":    "Rejalashtirilgan habarlarni olishda xatolik yuz berdi.",
+	"schedule.empty":         "Rejalashtirilgan habarlar yo'q.",
+	"schedule.title":         "Rejalashtirilgan habarlar:\n",
+	"schedule.weekly_mark":   " (har hafta)",
+	"schedule.cancel_button": "❌ #%d ni bekor qilish",
+	"schedule.cancel_error":  "Habarni bekor qilishda xatolik yuz berdi.",
+	"sponsor.load_error":     "Kanallar hisobotini olishda xatolik yuz berdi.",
+	"sponsor.empty":          "Majburiy obuna kanallari yo'q.",
+	"sponsor.title":          "📊 Kanallar hisoboti\n",
+	"sponsor.ask_period":     "Homiylik muddatini YYYY-MM-DD formatida kiriting:\n• 2026-11-01 2026-11-30 — boshlanish va tugash sanasi\n• - 2026-11-30 — faqat tugash sanasi\n• 0 — muddatni olib tashlash\n\n(Bekor qilish uchun /cancel)",
+	"sponsor.ask_target":     "Bot orqali nechta obunachi yig'ilgach kanal o'chirilsin? Maqsadni olib tashlash uchun 0 yuboring (Bekor qilish uchun /cancel):",
+	"sponsor.period_button":  "📅 Muddat",
+	"sponsor.target_button":  "🎯 Maqsad",
+	"sponsor.back":           "⬅️ Orqaga",
+	"sponsor.not_found":      "Kanal topilmadi.",
+	"sponsor.bad_period":     "Noto'g'ri sana. Masalan: 2026-11-01 2026-11-30",
+	"sponsor.period_error":   "Muddatni saqlashda xatolik yuz berdi.",
+	"sponsor.bad_target":     "Noto'g'ri son.",
+	"sponsor.target_error":   "Maqsadni saqlashda xatolik yuz berdi.",
+	"sponsor.expired":        "⏹ Homiy kanal majburiy obunadan olib tashlandi: %s\n%s",
+	"sponsor.unlimited":      "cheklanmagan",
+	"sponsor.from":           "%s dan",
+	"sponsor.until":          "%s gacha",
+	"sponsor.period":         "Muddat: %s",
+	"sponsor.inactive":       " (faol emas)",
+	"sponsor.target":         "Maqsad: %d/%d\n",
+	"sponsor.stats":          "Ko'rsatildi: %d · Obuna bo'ldi: %d (%.1f%%) · Chiqib ketdi: %d",
+
+	"backup.failed":          "❌ Backup yaratishda xatolik yuz berdi. Tafsilotlar \"%s\" bo'limida.",
+	"backup.done":            "✅ Backup: %.1f MB, %s",
+	"backup.part":            "%s\nQism %d/%d. Birlashtirish: cat %s.part* > %s",
+	"restore.ask_file":       "Tiklash uchun backup faylini (.sql, .sql.gz, .jsonl yoki .jsonl.gz, 20 MB gacha) yuboring (Bekor qilish uchun /cancel):",
+	"restore.bad_file":       "Iltimos, .sql, .sql.gz, .jsonl yoki .jsonl.gz faylini yuboring.",
+	"restore.too_big":        "Faylni yuklab bo'lmadi. Fayl hajmi 20 MB dan oshmasligi kerak.",
+	"restore.download_error": "Faylni yuklab bo'lmadi.",
+	"restore.confirm_button": "Ha, tiklash",
+	"restore.confirm":        "⚠️ %s fayli bazaga tiklanadi, joriy ma'lumotlar almashtiriladi. Tiklashdan oldin joriy baza backup qilinadi.\n\nDavom etamizmi?",
+	"restore.no_file":        "Tiklash uchun fayl topilmadi.",
+	"restore.cancelled":      "Tiklash bekor qilindi.",
+	"restore.running":        "⏳ Baza tiklanmoqda...",
+	"restore.safety_failed":  "Joriy bazani backup qilib bo'lmadi, tiklash to'xtatildi.",
+	"restore.failed":         "❌ Bazani tiklashda xatolik yuz berdi, o'zgarishlar bekor qilindi.\nOldingi holat: %s",
+	"restore.done":           "✅ Baza muvaffaqiyatli tiklandi.\nOldingi holat: %s",
+
+	"inline.unavailable": "Inline rejim hozircha o'chirilgan, botga yozing",
+
+	"fav.col_progress": "📁 %d / %d ta fayl yuborildi",
+	"fav.col_more":     "⬇️ Yana",
+
+	"inline.preparing": "⏳ Tayyorlanmoqda, bir necha soniyadan keyin qayta yozing",
+	"inline.busy":      "⏳ Bot band, birozdan keyin urinib ko'ring",
+}
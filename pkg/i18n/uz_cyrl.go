@@ -0,0 +1,380 @@
+package i18n
+
+var uzCyrillic = map[string]string{
+	"lang.name":    "🇺🇿 Ўзбекча",
+	"lang.choose":  "🌐 Тилни танланг:",
+	"lang.changed": "✅ Интерфейс тили: %s",
+
+	"cmd.start":      "Ботни ишга тушириш",
+	"cmd.help":       "Ёрдам",
+	"cmd.history":    "Юклашлар тарихи",
+	"cmd.favourites": "Севимлилар ва тўпламлар",
+	"cmd.invite":     "Дўстларни таклиф қилиш",
+	"cmd.settings":   "Созламалар",
+	"cmd.lang":       "Тилни ўзгартириш",
+	"cmd.cancel":     "Жорий амални бекор қилиш",
+	"cmd.admin":      "Админ панел",
+
+	"help.text": "ℹ️ Видео ёки пост ҳаволасини юборинг, мен уни юклаб бераман.\n\n" +
+		"Қўллаб-қувватланади:\n• Instagram — reels ва постлар\n• TikTok — видеолар\n• YouTube — видео ва аудио (50 MB гача)\n\n" +
+		"Instagram ва TikTok видеоларидан аудиони ҳам ажратиб бераман.\n\n" +
+		"Исталган чатда бот username'и ва ҳаволани ёзиб, видеони шу ернинг ўзида юборишингиз мумкин.\n\n" +
+		"Буйруқлар:\n/history — юклашлар тарихи\n/favourites — севимлилар\n/invite — дўстларни таклиф қилиш\n/settings — созламалар\n/lang — тилни ўзгартириш\n/cancel — жорий амални бекор қилиш",
+
+	"settings.title":            "⚙️ Созламалар\n\nТугмани босиб қийматни ўзгартиринг.",
+	"settings.lang":             "🌐 Тил: %s",
+	"settings.audio":            "🎵 Аудио: %s",
+	"settings.audio.ask":        "сўралсин",
+	"settings.audio.always":     "ҳар доим",
+	"settings.audio.never":      "юборилмасин",
+	"settings.quality":          "📺 YouTube сифати: %s",
+	"settings.quality.ask":      "рўйхатдан танлаш",
+	"settings.quality.audio":    "фақат аудио",
+	"settings.send_as":          "📦 Юбориш: %s",
+	"settings.send_as.video":    "видео",
+	"settings.send_as.document": "файл",
+	"settings.caption":          "📝 Изоҳ: %s",
+	"settings.watermark":        "💧 TikTok сув белгисиз: %s",
+	"settings.on":               "ёқилган",
+	"settings.off":              "ўчирилган",
+
+	"cancel.done":    "Бекор қилинди.",
+	"cancel.nothing": "Бекор қилинадиган амал йўқ.",
+
+	"start.welcome":      "👋 Ассалому алайкум [%s](tg://user?id=%d), ботимизга хуш келибсиз.\n\nМен сизга Instagram ва TikTokдан видеоларни юклашда ёрдам берувчи ботман.\n\nИлтимос, менга видео ҳаволасини юборинг.",
+	"start.welcome_back": "👋 Ассалому алайкум [%s](tg://user?id=%d), ботимизга хуш келибсиз.",
+
+	"sub.gate":         "Илтимос, каналларга аъзо бўлинг.",
+	"sub.check":        "Аъзо бўлдим",
+	"sub.join_request": "✅ %s каналига сўровингиз қабул қилинди. Энди \"Аъзо бўлдим\" тугмасини босинг.",
+
+	"download.caption":        "Сиз сўраган видео.",
+	"download.audio_prompt":   "Сиз сўраган видео.\n\nАудиосини юклашни истайсизми?",
+	"download.audio_question": "Аудиосини юклашни истайсизми?",
+	"download.yes":            "Ҳа",
+	"download.no":             "Йўқ",
+	"download.audio_caption":  "Мана видеонинг аудио файли:",
+	"download.audio_error":    "❌ Аудио ажратишда хатолик юз берди.",
+	"download.error":          "❌ Видео юклаб олишда хатолик юз берди.",
+	"download.error_detail":   "❌ Видео юклаб олишда хатолик юз берди: %s",
+	"download.not_found":      "❌ Видео юклаб олинмади. Илтимос, бошқа ҳаволани синаб кўринг.",
+	"download.api_error":      "❌ API жавобини ўқишда хатолик юз берди.",
+	"download.problem":        "❌ Видео юклаб олишда муаммо бор.",
+	"download.file_error":     "❌ Видео юклаб олишда хатолик.",
+	"download.rate_limited":   "⏳ Жуда кўп сўров юбордингиз. %d сониядан кейин қайта уриниб кўринг.",
+
+	"youtube.meta_error":   "❌ Видео маълумотларини олишда хатолик юз берди.",
+	"youtube.caption":      "*%s*\nДавомийлиги: %s\nЮклаш учун форматни танланг:",
+	"youtube.audio_button": "Аудио - %.1fMB",
+	"youtube.format_error": "Танланган форматни юклашда хатолик юз берди.",
+	"youtube.too_large":    "Кечирасиз, файл ҳажми 50mb дан ошди. Жўната олмайман.",
+
+	"inline.hint":         "Instagram, TikTok ёки YouTube ҳаволасини ёзинг",
+	"inline.subscribe":    "Аввал каналларга аъзо бўлинг",
+	"inline.rate_limited": "⏳ %d сониядан кейин қайта уриниб кўринг",
+	"inline.error":        "❌ Юклаб бўлмади, ботда уриниб кўринг",
+	"inline.title":        "Видео",
+	"inline.description":  "Юбориш учун босинг",
+	"inline.caption":      "📥 @%s орқали юкланди",
+
+	"group.welcome":     "👋 Салом! Гуруҳга юборилган Instagram, TikTok ва YouTube ҳаволаларини юклаб бераман.\n\nҲаволаларни кўришим учун мени гуруҳ админи қилинг. Созламалар: /settings (гуруҳ админлари учун).",
+	"group.settings":    "⚙️ Гуруҳ созламалари\n\nТугмани босиб қийматни ўзгартиринг.",
+	"group.platform":    "%s: %s",
+	"group.delete_link": "🗑 Ҳаволали хабарни ўчириш: %s",
+	"group.reply":       "↩️ Жавоб сифатида юбориш: %s",
+	"group.quiet":       "🔕 Жим режим: %s",
+	"group.admin_only":  "Буни фақат гуруҳ админлари қила олади.",
+	"group.error":       "❌ Бу ҳаволани юклаб бўлмади.",
+	"group.caption":     "👤 %s",
+
+	"history.title":         "📂 Юклашлар тарихи (саҳифа %d)",
+	"history.empty":         "📂 Юклашлар тарихи бўш.",
+	"history.error":         "❌ Тарихни олишда хатолик юз берди.",
+	"history.not_found":     "Файл топилмади ёки энди мавжуд эмас.",
+	"history.prev":          "⬅️ Олдинги",
+	"history.next":          "Кейинги ➡️",
+	"history.clear":         "🗑 Тарихни тозалаш",
+	"history.clear_confirm": "Юклашлар тарихини тозалашни тасдиқлайсизми?",
+	"history.clear_yes":     "Ҳа, тозалаш",
+	"history.clear_no":      "Йўқ",
+	"history.cleared":       "✅ Тарих тозаланди.",
+
+	"fav.title":             "⭐ Севимлилар: %d та файл.\n\nТўпламни танланг:",
+	"fav.empty":             "⭐ Севимлилар бўш.\n\n/history рўйхатида файлни ⭐ тугмаси билан белгиланг.",
+	"fav.all":               "⭐ Барчаси (%d)",
+	"fav.all_name":          "Барча севимлилар",
+	"fav.new_collection":    "➕ Янги тўплам",
+	"fav.collection_title":  "📁 %s (саҳифа %d)",
+	"fav.collection_empty":  "Бу ерда ҳали файл йўқ.",
+	"fav.share":             "🔗 Улашиш",
+	"fav.delete_collection": "🗑 Тўпламни ўчириш",
+	"fav.back":              "⬅️ Орқага",
+	"fav.moved":             "✅ Ўтказилди",
+	"fav.no_collection":     "Ҳеч қайси тўплам",
+	"fav.choose_collection": "Файлни қайси тўпламга ўтказамиз?",
+	"fav.name_prompt":       "Янги тўплам номини юборинг (64 белгигача). Бекор қилиш учун /cancel",
+	"fav.name_invalid":      "Тўплам номи 1 дан 64 белгигача бўлиши керак.",
+	"fav.limit":             "Кўпи билан %d та тўплам яратиш мумкин.",
+	"fav.delete_confirm":    "Тўпламни ўчирасизми? Ундаги файллар севимлиларда қолади.",
+	"fav.delete_yes":        "Ҳа, ўчириш",
+	"fav.added":             "⭐ Севимлиларга қўшилди",
+	"fav.already":           "Бу файл аллақачон севимлиларда",
+	"fav.error":             "❌ Севимлилар билан ишлашда хатолик юз берди.",
+	"fav.share_link":        "🔗 \"%s\" тўплами ҳаволаси:\n%s\n\nҲаволани очган ҳар ким тўпламдаги файлларни олади.",
+	"fav.col_not_found":     "❌ Тўплам топилмади ёки улашилмаган.",
+	"fav.col_shared":        "📁 %s — %d та файл:",
+
+	"ref.invite":      "👥 Сизнинг таклиф ҳаволангиз:\n%s\n\nУ орқали қўшилганлар: %d",
+	"ref.joined":      "🎉 %s таклиф ҳаволангиз орқали қўшилди. Таклиф қилинган дўстлар: %d",
+	"share.link":      "🔗 Файл ҳаволаси:\n%s\n\nУни очган ҳар ким файлни дарҳол олади.",
+	"share.not_found": "❌ Файл топилмади ёки ҳавола нотўғри.",
+
+	"admin.not_admin":       "Сиз админ эмассиз.",
+	"admin.menu":            "Админ буйруқлари (%s):",
+	"admin.channel_prompt":  "Канал ҳаволасини, @username ёки ID сини юборинг, ёки каналдан хабар форвард қилинг (масалан, https://t.me/your_channel).\nБот каналда админ бўлиши керак.",
+	"admin.remove_prompt":   "Илтимос, ўчириш учун админ ID сини юборинг:",
+	"admin.schedule_prompt": "Режалаштирмоқчи бўлган хабарингизни юборинг (Бекор қилиш учун /cancel):",
+
+	"menu.stats":          "Статистика",
+	"menu.broadcast":      "Хабар юбориш",
+	"menu.schedule":       "Режалаштириш",
+	"menu.scheduled":      "Режалаштирилганлар",
+	"menu.channel_add":    "Канал қўшиш",
+	"menu.channel_delete": "Канал ўчириш",
+	"menu.channels":       "Каналлар",
+	"menu.admins":         "Админлар",
+	"menu.admin_add":      "Админ қўшиш",
+	"menu.admin_remove":   "Админ ўчириш",
+	"menu.backup":         "Бэкап олиш",
+	"menu.restore":        "Бэкапни тиклаш",
+	"menu.backups":        "Бэкаплар",
+	"menu.export":         "Экспорт",
+	"menu.audit":          "Аудит",
+	"menu.sources":        "Манбалар",
+
+	"common.yes":                      "Ҳа",
+	"common.no":                       "Йўқ",
+	"common.cancel":                   "Бекор қилиш",
+	"role.owner":                      "Эга",
+	"role.admin":                      "Админ",
+	"role.moderator":                  "Модератор",
+	"role.analyst":                    "Таҳлилчи",
+	"channel.invite_link_unsupported": "Таклиф ҳаволаси бўйича канални аниқлаб бўлмайди. Канал ID сини юборинг ёки каналдан хабар форвард қилинг.",
+	"channel.bad_format":              "Нотўғри формат. Масалан: https://t.me/your_channel, @your_channel ёки -1001234567890.",
+	"channel.not_found":               "Канал топилмади. Ҳавола тўғрилигини текширинг ёки аввал ботни каналга админ қилинг.",
+	"channel.not_channel":             "Бу канал ёки гуруҳ эмас.",
+	"channel.bot_not_admin":           "Бот бу каналда админ эмас. Аввал ботни каналга админ қилинг, акс ҳолда обунани текшириб бўлмайди.",
+	"channel.private":                 "%s ёпиқ канал. Бот обуна учун таклиф ҳаволасини яратади.\n\nҚўшилиш сўрови билан ҳаволада фойдаланувчи сўров юбориши билан обуна бўлган ҳисобланади.",
+	"channel.invite_plain":            "🔗 Оддий ҳавола",
+	"channel.invite_request":          "📨 Қўшилиш сўрови билан",
+	"channel.invite_name":             "Мажбурий обуна",
+	"channel.invite_error":            "Таклиф ҳаволасини яратиб бўлмади. Ботга каналда \"Фойдаланувчиларни таклиф қилиш\" ҳуқуқини беринг.",
+	"channel.add_cancelled":           "Канал қўшиш бекор қилинди.",
+	"channel.add_error":               "Канални қўшишда хатолик юз берди.",
+	"channel.exists":                  "%s аллақачон қўшилган.",
+	"channel.added":                   "%s канали муваффақиятли қўшилди.",
+	"channel.added_link":              "\nҲавола: %s",
+	"channel.list_error":              "Каналларни олишда хатолик юз берди.",
+	"channel.delete_choose":           "Ўчирилиши керак бўлган канални танланг:",
+	"channel.delete_confirm":          "%s каналини ўчирмоқчимисиз?",
+	"channel.delete_error":            "Канални ўчиришда хатолик юз берди.",
+	"channel.deleted":                 "%s канали муваффақиятли ўчирилди.",
+	"channel.delete_cancelled":        "Канал ўчириш бекор қилинди.",
+	"admins.title":                    "👥 Админлар (%d):",
+	"admins.list_error":               "Админларни олишда хатолик юз берди.",
+	"admins.add":                      "➕ Админ қўшиш",
+	"admins.remove_confirm":           "%s (%d) ни админликдан ўчирмоқчимисиз?",
+	"admins.pick_user":                "👤 Фойдаланувчини танлаш",
+	"admins.pick_cancel":              "Бекор қилиш",
+	"admins.pick_prompt":              "Янги админни танланг:\n• \"%s\" тугмасини босинг,\n• унинг хабарини форвард қилинг ёки контактини юборинг,\n• ёки ID ва ролини ёзинг (масалан: 123456789 moderator).\nРоллар: admin, moderator, analyst. Рол кўрсатилмаса admin бўлади.",
+	"admins.choose_role":              "Ролни танланг:",
+	"admins.add_cancelled":            "Админ қўшиш бекор қилинди.",
+	"admins.hidden_forward":           "Фойдаланувчи форвард созламаларида аккаунтини яширган. \"%s\" тугмасидан ёки ID дан фойдаланинг.",
+	"admins.contact_no_account":       "Бу контакт Telegram аккаунтга боғланмаган.",
+	"admins.bad_id":                   "Нотўғри админ ID формати.",
+	"admins.bad_role":                 "Нотўғри рол. Мавжуд роллар: admin, moderator, analyst.",
+	"admins.owner_role":               "Бот эгасининг ролини ўзгартириб бўлмайди.",
+	"admins.add_error":                "Админ қўшишда хатолик юз берди.",
+	"admins.added":                    "Админ муваффақиятли қўшилди (%s).",
+	"admins.remove_self":              "Ўзингизни админликдан ўчира олмайсиз.",
+	"admins.remove_owner":             "Бот эгасини ўчириб бўлмайди.",
+	"admins.remove_error":             "Админ ўчиришда хатолик юз берди.",
+	"admins.not_found":                "Бундай админ топилмади.",
+	"admins.removed":                  "Админ муваффақиятли ўчирилди.",
+
+	"audience.prompt":         "Хабар кимларга юборилсин?",
+	"audience.all_button":     "Барча фойдаланувчилар",
+	"audience.new_button":     "Янги: %d кун",
+	"audience.active_button":  "Фаол: %d кун",
+	"audience.lang_uz":        "🇺🇿 Ўзбек",
+	"audience.lang_ru":        "🇷🇺 Рус",
+	"audience.lang_en":        "🇬🇧 Инглиз",
+	"audience.all":            "барча фойдаланувчилар",
+	"audience.new":            "охирги %d кунда қўшилганлар",
+	"audience.active":         "охирги %d кунда фаол бўлганлар",
+	"audience.language":       "тил: %s",
+	"audience.platform":       "%s дан фойдаланганлар",
+	"audience.count_error":    "Фойдаланувчиларни олишда хатолик юз берди.",
+	"broadcast.ask_message":   "Аудитория: %s (%d та фойдаланувчи)\n\nИлтимос, юбормоқчи бўлган хабарингизни киритинг (Бекор қилиш учун /cancel):",
+	"broadcast.button_format": "Нотўғри формат. Масалан: Батафсил | https://example.com",
+	"broadcast.draft_missing": "Хабар топилмади, қайтадан бошланг.",
+	"broadcast.ask_variant":   "B вариант хабарини юборинг (Бекор қилиш учун /cancel):",
+	"broadcast.ask_button":    "Тугмани \"Матн | ҳавола\" кўринишида юборинг, масалан: Батафсил | https://example.com",
+	"broadcast.start_error":   "Хабар юборишни бошлашда хатолик юз берди.",
+	"broadcast.cancelled":     "Хабар юбориш бекор қилинди.",
+	"broadcast.add_variant":   "🅱️ B вариант қўшиш",
+	"broadcast.add_button":    "🔘 Тугма қўшиш",
+	"broadcast.send":          "✅ Юбориш",
+	"broadcast.cancel":        "❌ Бекор қилиш",
+	"broadcast.audience":      "Аудитория: %s",
+	"broadcast.ab_note":       "\nA/B тест: фойдаланувчилар икки вариантга тенг бўлинади.",
+	"broadcast.button_line":   "\nТугма: %s → %s",
+
+	"broadcast.starting":         "Хабар юбориш бошланмоқда...",
+	"broadcast.finished":         "Хабар юбориш якунланди.",
+	"broadcast.variant_stats":    "\n\n%s вариант: юборилди %d/%d, хатолик %d, блоклаган %d",
+	"broadcast.variant_clicks":   ", босишлар %d (%s)",
+	"broadcast.status_running":   "⏳ Юборилмоқда",
+	"broadcast.status_paused":    "⏸ Тўхтатилган",
+	"broadcast.status_cancelled": "❌ Бекор қилинган",
+	"broadcast.status_completed": "✅ Якунланди",
+	"broadcast.progress":         "Хабар #%d: %s\nАудитория: %s\n\nЮборилди: %d\nХатолик: %d\nБотни блоклаган: %d\nҚолди: %d\nЖами: %d",
+	"broadcast.clicks":           "\nТугма босишлар: %d (%s)",
+	"broadcast.eta":              "\nТахминий вақт: %s",
+	"broadcast.pause":            "⏸ Тўхтатиш",
+	"broadcast.resume":           "▶️ Давом эттириш",
+	"broadcast.status_error":     "Хабар ҳолатини ўзгартиришда хатолик юз берди.",
+
+	"audit.load_error":          "Амаллар тарихини олишда хатолик юз берди.",
+	"audit.ask_actor":           "Админ ID сини юборинг ёки унинг хабарини форвард қилинг (Бекор қилиш учун /cancel):",
+	"audit.title":               "📜 Админлар амаллари (саҳифа %d)\n",
+	"audit.actor":               "Админ: %d\n",
+	"audit.section":             "Бўлим: %s\n",
+	"audit.empty":               "Ёзувлар топилмади.",
+	"audit.system":              "тизим",
+	"audit.by_actor":            "👤 Админ бўйича",
+	"audit.all_actors":          "✖️ Барча админлар",
+	"audit.section_all":         "Ҳаммаси",
+	"audit.section_channel":     "Каналлар",
+	"audit.section_admin":       "Админлар",
+	"audit.section_broadcast":   "Хабарлар",
+	"audit.section_schedule":    "Режалар",
+	"audit.section_backup":      "Бэкап",
+	"audit.section_export":      "Экспорт",
+	"page.prev":                 "⬅️ Олдинги",
+	"page.next":                 "Кейинги ➡️",
+	"backups.load_error":        "Бэкаплар рўйхатини олишда хатолик юз берди.",
+	"backups.title":             "💾 Бэкаплар\n\n",
+	"backups.next":              "Кейинги автоматик бэкап: %s (%s)\n",
+	"backups.auto_off":          "Автоматик бэкап ўчирилган\n",
+	"backups.retention":         "Сақланади: охирги %d та\n\n",
+	"backups.empty":             "Ҳали бэкап олинмаган.",
+	"backups.trigger_scheduled": "автоматик",
+	"backups.trigger_manual":    "қўлда",
+
+	"period.today":          "Бугун",
+	"period.7d":             "7 кун",
+	"period.30d":            "30 кун",
+	"period.90d":            "90 кун",
+	"period.all":            "Ҳаммаси",
+	"stats.load_error":      "Статистикани олишда хатолик юз берди.",
+	"stats.title":           "📊 Статистика: %s\n\n",
+	"stats.users":           "👥 Фойдаланувчилар\nУмумий фойдаланувчилар сони: %d\nЯнги қўшилганлар: %d\nDAU / WAU / MAU: %d / %d / %d\nБотни блоклаганлар: %d\nЎчирилган аккаунтлар: %d\n",
+	"stats.downloads":       "\n📥 Юклашлар\n",
+	"stats.downloads_total": "Жами: %d, муваффақиятли: %s\nЎртача юклаш вақти: %s\nЮборилган ҳажм: %s\nКешдан юборилган: %s\n",
+	"stats.daily":           "\n📅 Кунлар бўйича\n",
+	"stats.top_errors":      "\n⚠️ Кўп учраган хатоликлар\n",
+	"chart.users":           "Фойдаланувчилар",
+	"chart.downloads":       "Юклашлар",
+	"chart.errors":          "Хатоликлар",
+	"chart.error":           "Графикни чизишда хатолик юз берди.",
+	"chart.users_title":     "Фойдаланувчилар сони",
+	"chart.users_total":     "Жами",
+	"chart.errors_title":    "Хатоликлар улуши",
+	"chart.errors_series":   "Хатолик",
+	"chart.downloads_title": "Кунлик юклашлар",
+	"sources.load_error":    "Манбалар статистикасини олишда хатолик юз берди.",
+	"sources.title":         "🧭 Фойдаланувчилар манбалари\n\n",
+	"sources.totals":        "Таклиф орқали: %d (%s)\nКампаниялардан: %d (%s)\nТўғридан-тўғри: %d (%s)\n",
+	"sources.referrers":     "\n👥 Энг кўп таклиф қилганлар (жами / фаол)\n",
+	"sources.campaigns":     "\n📣 Кампаниялар (жами / фаол / юклаган / 7 кунда)\n",
+	"sources.none":          "Ҳали йўқ\n",
+	"sources.link":          "\nКампания ҳаволаси: https://t.me/%s?start=c_<тег>\nТег: лотин ҳарфлари, рақамлар, _ ва - (62 белгигача).",
+
+	"export.prompt":       "Қайси маълумотларни экспорт қиламиз?",
+	"export.range_button": "📅 Оралиқ",
+	"export.ask_period":   "%s: қайси давр учун?",
+	"export.ask_range":    "Саналар оралиғини юборинг, масалан: 2025-01-01 2025-01-31 (Бекор қилиш учун /cancel)",
+	"export.preparing":    "⏳ Файл тайёрланмоқда...",
+	"export.range_format": "Нотўғри формат. Масалан: 2025-01-01 2025-01-31",
+	"export.ask_format":   "%s: файл форматини танланг",
+	"export.error":        "Экспорт қилишда хатолик юз берди.",
+	"export.caption":      "%s: %d та қатор (%s)",
+	"export.send_error":   "Файлни юборишда хатолик юз берди.",
+	"export.all_time":     "барча вақт",
+	"export.users":        "Фойдаланувчилар",
+	"export.downloads":    "Юклашлар",
+	"export.broadcasts":   "Хабарлар",
+
+	"schedule.ask_time":      "Юбориш вақтини киритинг (%s вақти билан), масалан: %s\n\nБекор қилиш учун /cancel",
+	"schedule.bad_time":      "Нотўғри вақт. Келажакдаги вақтни YYYY-MM-DD HH:MM форматида киритинг:",
+	"schedule.once":          "Бир марта",
+	"schedule.weekly":        "Ҳар ҳафта",
+	"schedule.ask_repeat":    "Хабар %s да юборилади. Такрорлансинми?",
+	"schedule.expired":       "Режалаштириш муддати ўтган, қайтадан бошланг.",
+	"schedule.cancelled":     "Режалаштириш бекор қилинди.",
+	"schedule.save_error":    "Хабарни режалаштиришда хатолик юз берди.",
+	"schedule.saved":         "Хабар #%d %s да юборилади.",
+	"schedule.saved_weekly":  " Ҳар ҳафта такрорланади.",
+	"schedule.load_error":    "Режалаштирилган хабарларни олишда хатолик юз берди.",
+	"schedule.empty":         "Режалаштирилган хабарлар йўқ.",
+	"schedule.title":         "Режалаштирилган хабарлар:\n",
+	"schedule.weekly_mark":   " (ҳар ҳафта)",
+	"schedule.cancel_button": "❌ #%d ни бекор қилиш",
+	"schedule.cancel_error":  "Хабарни бекор қилишда хатолик юз берди.",
+	"sponsor.load_error":     "Каналлар ҳисоботини олишда хатолик юз берди.",
+	"sponsor.empty":          "Мажбурий обуна каналлари йўқ.",
+	"sponsor.title":          "📊 Каналлар ҳисоботи\n",
+	"sponsor.ask_period":     "Ҳомийлик муддатини YYYY-MM-DD форматида киритинг:\n• 2026-11-01 2026-11-30 — бошланиш ва тугаш санаси\n• - 2026-11-30 — фақат тугаш санаси\n• 0 — муддатни олиб ташлаш\n\n(Бекор қилиш учун /cancel)",
+	"sponsor.ask_target":     "Бот орқали нечта обуначи йиғилгач канал ўчирилсин? Мақсадни олиб ташлаш учун 0 юборинг (Бекор қилиш учун /cancel):",
+	"sponsor.period_button":  "📅 Муддат",
+	"sponsor.target_button":  "🎯 Мақсад",
+	"sponsor.back":           "⬅️ Орқага",
+	"sponsor.not_found":      "Канал топилмади.",
+	"sponsor.bad_period":     "Нотўғри сана. Масалан: 2026-11-01 2026-11-30",
+	"sponsor.period_error":   "Муддатни сақлашда хатолик юз берди.",
+	"sponsor.bad_target":     "Нотўғри сон.",
+	"sponsor.target_error":   "Мақсадни сақлашда хатолик юз берди.",
+	"sponsor.expired":        "⏹ Ҳомий канал мажбурий обунадан олиб ташланди: %s\n%s",
+	"sponsor.unlimited":      "чекланмаган",
+	"sponsor.from":           "%s дан",
+	"sponsor.until":          "%s гача",
+	"sponsor.period":         "Муддат: %s",
+	"sponsor.inactive":       " (фаол эмас)",
+	"sponsor.target":         "Мақсад: %d/%d\n",
+	"sponsor.stats":          "Кўрсатилди: %d · Обуна бўлди: %d (%.1f%%) · Чиқиб кетди: %d",
+
+	"backup.failed":          "❌ Бэкап яратишда хатолик юз берди. Тафсилотлар \"%s\" бўлимида.",
+	"backup.done":            "✅ Бэкап: %.1f MB, %s",
+	"backup.part":            "%s\nҚисм %d/%d. Бирлаштириш: cat %s.part* > %s",
+	"restore.ask_file":       "Тиклаш учун бэкап файлини (.sql, .sql.gz, .jsonl ёки .jsonl.gz, 20 MB гача) юборинг (Бекор қилиш учун /cancel):",
+	"restore.bad_file":       "Илтимос, .sql, .sql.gz, .jsonl ёки .jsonl.gz файлини юборинг.",
+	"restore.too_big":        "Файлни юклаб бўлмади. Файл ҳажми 20 MB дан ошмаслиги керак.",
+	"restore.download_error": "Файлни юклаб бўлмади.",
+	"restore.confirm_button": "Ҳа, тиклаш",
+	"restore.confirm":        "⚠️ %s файли базага тикланади, жорий маълумотлар алмаштирилади. Тиклашдан олдин жорий база бэкап қилинади.\n\nДавом этамизми?",
+	"restore.no_file":        "Тиклаш учун файл топилмади.",
+	"restore.cancelled":      "Тиклаш бекор қилинди.",
+	"restore.running":        "⏳ База тикланмоқда...",
+	"restore.safety_failed":  "Жорий базани бэкап қилиб бўлмади, тиклаш тўхтатилди.",
+	"restore.failed":         "❌ Базани тиклашда хатолик юз берди, ўзгаришлар бекор қилинди.\nОлдинги ҳолат: %s",
+	"restore.done":           "✅ База муваффақиятли тикланди.\nОлдинги ҳолат: %s",
+
+	"inline.unavailable": "Инлайн режим ҳозирча ўчирилган, ботга ёзинг",
+
+	"fav.col_progress": "📁 %d / %d та файл юборилди",
+	"fav.col_more":     "⬇️ Яна",
+
+	"inline.preparing": "⏳ Тайёрланмоқда, бир неча сониядан кейин қайта ёзинг",
+	"inline.busy":      "⏳ Бот банд, бироздан кейин уриниб кўринг",
+}
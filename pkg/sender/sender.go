@@ -0,0 +1,258 @@
+package sender
+
+import (
+	"errors"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const (
+	StatusBlocked     = "blocked"
+	StatusDeactivated = "deactivated"
+
+	maxAttempts = 4
+	maxBackoff  = 30 * time.Second
+)
+
+// OnUserInactive foydalanuvchi botni bloklagani yoki akkaunti o'chirilgani
+// aniqlanganda chaqiriladi (main'da bazaga yozish uchun o'rnatiladi).
+var OnUserInactive func(userID int64, status string)
+
+var (
+	floodMu    sync.Mutex
+	floodUntil time.Time
+)
+
+// APIError Telegram API qaytargan xatolik
+type APIError struct {
+	Code        int
+	Description string
+	RetryAfter  int
+	err         error
+}
+
+func (e *APIError) Error() string {
+	return e.err.Error()
+}
+
+func (e *APIError) Unwrap() error {
+	return e.err
+}
+
+// ParseError xatolikni APIError ko'rinishiga keltiradi. tgbotapi.Error xatolik
+// kodini saqlamaydi, fayl yuklashda (UploadFile) esa oddiy errors.New(description)
+// qaytadi, shuning uchun kod ikkala holatda ham tavsif prefiksidan aniqlanadi.
+func ParseError(err error) *APIError {
+	if err == nil {
+		return nil
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
+	apiErr = &APIError{Description: err.Error(), err: err}
+	var tgErr tgbotapi.Error
+	if errors.As(err, &tgErr) {
+		apiErr.Description, apiErr.RetryAfter = tgErr.Message, tgErr.RetryAfter
+	}
+
+	switch {
+	case apiErr.RetryAfter > 0 || strings.HasPrefix(apiErr.Description, "Too Many Requests"):
+		apiErr.Code = 429
+		if apiErr.RetryAfter == 0 {
+			apiErr.RetryAfter = parseRetryAfter(apiErr.Description)
+		}
+	case strings.HasPrefix(apiErr.Description, "Forbidden"):
+		apiErr.Code = 403
+	case strings.HasPrefix(apiErr.Description, "Bad Request"):
+		apiErr.Code = 400
+	case strings.HasPrefix(apiErr.Description, "Internal Server Error"), strings.HasPrefix(apiErr.Description, "Bad Gateway"):
+		apiErr.Code = 500
+	}
+	return apiErr
+}
+
+// parseRetryAfter "Too Many Requests: retry after 5" tavsifidan kutish soniyalarini ajratadi
+func parseRetryAfter(description string) int {
+	i := strings.LastIndex(description, "retry after ")
+	if i < 0 {
+		return 0
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(description[i+len("retry after "):]))
+	return n
+}
+
+// InactiveStatus 403 xatolikdan foydalanuvchi holatini aniqlaydi, aks holda "" qaytaradi.
+func InactiveStatus(err error) string {
+	apiErr := ParseError(err)
+	if apiErr == nil || apiErr.Code != 403 {
+		return ""
+	}
+	if strings.Contains(apiErr.Description, "deactivated") {
+		return StatusDeactivated
+	}
+	return StatusBlocked
+}
+
+// Send botInstance.Send o'rnida ishlatiladi: 429 da retry_after kutib qayta
+// yuboradi va 403 da foydalanuvchini nofaol deb belgilaydi. io.Reader yoki
+// baytlardan yuklanayotgan fayl qayta yuborilmaydi, chunki reader o'qib bo'lingan.
+func Send(botInstance *tgbotapi.BotAPI, c tgbotapi.Chattable) (tgbotapi.Message, error) {
+	var msg tgbotapi.Message
+	err := withRetry(chatIDOf(c), !readsOnce(c), func() error {
+		var err error
+		msg, err = botInstance.Send(c)
+		return err
+	})
+	return msg, err
+}
+
+// Request botInstance.MakeRequest uchun Send bilan bir xil qoidalarni qo'llaydi.
+func Request(botInstance *tgbotapi.BotAPI, endpoint string, params url.Values) (tgbotapi.APIResponse, error) {
+	chatID, _ := strconv.ParseInt(params.Get("chat_id"), 10, 64)
+
+	var resp tgbotapi.APIResponse
+	err := withRetry(chatID, true, func() error {
+		var err error
+		resp, err = botInstance.MakeRequest(endpoint, params)
+		return err
+	})
+	return resp, err
+}
+
+func withRetry(chatID int64, retryable bool, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		waitFlood()
+
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		apiErr := ParseError(err)
+		switch apiErr.Code {
+		case 429:
+			wait := time.Duration(apiErr.RetryAfter) * time.Second
+			if wait <= 0 {
+				wait = backoff(attempt)
+			}
+			setFlood(wait)
+			if retryable && attempt < maxAttempts {
+				log.Printf("Telegram flood limit, %s kutiladi (chat %d)", wait, chatID)
+				continue
+			}
+		case 500:
+			if retryable && attempt < maxAttempts {
+				time.Sleep(backoff(attempt))
+				continue
+			}
+		case 403:
+			// Guruh va kanallardagi 403 foydalanuvchi holatiga taalluqli emas
+			if status := InactiveStatus(apiErr); chatID > 0 && OnUserInactive != nil {
+				OnUserInactive(chatID, status)
+			}
+		}
+		return apiErr
+	}
+}
+
+func backoff(attempt int) time.Duration {
+	wait := time.Second << uint(attempt-1)
+	if wait > maxBackoff {
+		wait = maxBackoff
+	}
+	return wait
+}
+
+// setFlood barcha yuborishlarni berilgan vaqtgacha to'xtatib turadi
+func setFlood(wait time.Duration) {
+	floodMu.Lock()
+	defer floodMu.Unlock()
+	if until := time.Now().Add(wait); until.After(floodUntil) {
+		floodUntil = until
+	}
+}
+
+func waitFlood() {
+	floodMu.Lock()
+	until := floodUntil
+	floodMu.Unlock()
+
+	if wait := time.Until(until); wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+// readsOnce yangi fayl FileReader yoki FileBytes orqali yuklanayotganini bildiradi
+func readsOnce(c tgbotapi.Chattable) bool {
+	var file tgbotapi.BaseFile
+	switch v := c.(type) {
+	case tgbotapi.PhotoConfig:
+		file = v.BaseFile
+	case tgbotapi.AudioConfig:
+		file = v.BaseFile
+	case tgbotapi.DocumentConfig:
+		file = v.BaseFile
+	case tgbotapi.VideoConfig:
+		file = v.BaseFile
+	case tgbotapi.VoiceConfig:
+		file = v.BaseFile
+	case tgbotapi.StickerConfig:
+		file = v.BaseFile
+	case tgbotapi.AnimationConfig:
+		file = v.BaseFile
+	case tgbotapi.VideoNoteConfig:
+		file = v.BaseFile
+	default:
+		return false
+	}
+	if file.UseExisting {
+		return false
+	}
+
+	switch file.File.(type) {
+	case tgbotapi.FileReader, tgbotapi.FileBytes, *tgbotapi.FileReader, *tgbotapi.FileBytes:
+		return true
+	}
+	return false
+}
+
+func chatIDOf(c tgbotapi.Chattable) int64 {
+	switch v := c.(type) {
+	case tgbotapi.MessageConfig:
+		return v.ChatID
+	case tgbotapi.ForwardConfig:
+		return v.ChatID
+	case tgbotapi.PhotoConfig:
+		return v.ChatID
+	case tgbotapi.AudioConfig:
+		return v.ChatID
+	case tgbotapi.DocumentConfig:
+		return v.ChatID
+	case tgbotapi.VideoConfig:
+		return v.ChatID
+	case tgbotapi.VoiceConfig:
+		return v.ChatID
+	case tgbotapi.StickerConfig:
+		return v.ChatID
+	case tgbotapi.ChatActionConfig:
+		return v.ChatID
+	case tgbotapi.EditMessageTextConfig:
+		return v.ChatID
+	case tgbotapi.EditMessageCaptionConfig:
+		return v.ChatID
+	case tgbotapi.EditMessageReplyMarkupConfig:
+		return v.ChatID
+	case tgbotapi.DeleteMessageConfig:
+		return v.ChatID
+	}
+	return 0
+}
@@ -14,3 +14,10 @@ func GetMessageID(chatID int64) (int, bool) {
 	messageID, exists := userMessageIDs[chatID]
 	return messageID, exists
 }
+
+// Clear foydalanuvchining kutilayotgan holatini o'chiradi, holat bo'lgan bo'lsa true qaytaradi
+func Clear(chatID int64) bool {
+	_, exists := UserStates[chatID]
+	delete(UserStates, chatID)
+	return exists
+}
@@ -0,0 +1,267 @@
+package telegram
+
+import (
+	"encoding/json"
+	"log"
+	"net/url"
+	"strconv"
+	"yuklovchiBot/pkg/sender"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// Update tgbotapi.Update'ni kutubxona (v4) hali tanimaydigan Bot API maydonlari bilan kengaytiradi
+type Update struct {
+	tgbotapi.Update
+	// UsersShared request_users tugmasi orqali tanlangan foydalanuvchilar (message.users_shared)
+	UsersShared *UsersShared
+	// ChatJoinRequest yopiq kanalga qo'shilish so'rovi (chat_join_request)
+	ChatJoinRequest *ChatJoinRequest
+}
+
+// UsersShared https://core.telegram.org/bots/api#usersshared
+type UsersShared struct {
+	RequestID int          `json:"request_id"`
+	Users     []SharedUser `json:"users"`
+}
+
+type SharedUser struct {
+	UserID    int64  `json:"user_id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Username  string `json:"username"`
+}
+
+// ChatJoinRequest https://core.telegram.org/bots/api#chatjoinrequest
+type ChatJoinRequest struct {
+	Chat       tgbotapi.Chat `json:"chat"`
+	From       tgbotapi.User `json:"from"`
+	UserChatID int64         `json:"user_chat_id"`
+	Date       int           `json:"date"`
+	InviteLink *InviteLink   `json:"invite_link"`
+}
+
+// InviteLink https://core.telegram.org/bots/api#chatinvitelink
+type InviteLink struct {
+	InviteLink         string `json:"invite_link"`
+	Name               string `json:"name"`
+	CreatesJoinRequest bool   `json:"creates_join_request"`
+}
+
+// messageOrigin forward_origin maydoni, Bot API 7.0 dan beri forward_from o'rnida keladi
+type messageOrigin struct {
+	Type       string         `json:"type"`
+	Date       int            `json:"date"`
+	SenderUser *tgbotapi.User `json:"sender_user"`
+}
+
+type rawUpdate struct {
+	ChatJoinRequest *ChatJoinRequest `json:"chat_join_request"`
+	Message         *struct {
+		UsersShared   *UsersShared   `json:"users_shared"`
+		ForwardOrigin *messageOrigin `json:"forward_origin"`
+	} `json:"message"`
+}
+
+// GetUpdates botInstance.GetUpdates o'rnida ishlatiladi: javob ikki marta o'qiladi —
+// tgbotapi.Update ga va kutubxonada yo'q maydonlar uchun rawUpdate ga.
+func GetUpdates(botInstance *tgbotapi.BotAPI, offset int) ([]Update, error) {
+	params := url.Values{}
+	if offset != 0 {
+		params.Set("offset", strconv.Itoa(offset))
+	}
+
+	resp, err := botInstance.MakeRequest("getUpdates", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var raws []json.RawMessage
+	if err := json.Unmarshal(resp.Result, &raws); err != nil {
+		return nil, err
+	}
+
+	updates := make([]Update, 0, len(raws))
+	for _, raw := range raws {
+		// Kutubxona kabi turdagi nomuvofiqliklarni o'tkazib yuboramiz, aks holda offset siljimay qoladi
+		var u Update
+		if err := json.Unmarshal(raw, &u.Update); err != nil {
+			log.Printf("Error decoding update: %v", err)
+		}
+
+		var extra rawUpdate
+		if err := json.Unmarshal(raw, &extra); err != nil {
+			updates = append(updates, u)
+			continue
+		}
+		u.ChatJoinRequest = extra.ChatJoinRequest
+		if extra.Message != nil && u.Message != nil {
+			u.UsersShared = extra.Message.UsersShared
+
+			// Eski forward_from/forward_date maydonlari endi kelmaydi, qolgan kod ularni ishlatgani uchun to'ldirib qo'yamiz
+			if origin := extra.Message.ForwardOrigin; origin != nil {
+				if u.Message.ForwardDate == 0 {
+					u.Message.ForwardDate = origin.Date
+				}
+				if u.Message.ForwardFrom == nil && origin.SenderUser != nil {
+					u.Message.ForwardFrom = origin.SenderUser
+				}
+			}
+		}
+		updates = append(updates, u)
+	}
+
+	return updates, nil
+}
+
+// CreateInviteLink kanal uchun bot nomidan taklif havolasini yaratadi.
+// joinRequest true bo'lsa, havola orqali kirish admin tasdig'ini talab qiladi (chat_join_request keladi).
+func CreateInviteLink(botInstance *tgbotapi.BotAPI, chatID int64, name string, joinRequest bool) (InviteLink, error) {
+	params := url.Values{}
+	params.Set("chat_id", strconv.FormatInt(chatID, 10))
+	params.Set("name", name)
+	params.Set("creates_join_request", strconv.FormatBool(joinRequest))
+
+	resp, err := sender.Request(botInstance, "createChatInviteLink", params)
+	if err != nil {
+		return InviteLink{}, err
+	}
+
+	var link InviteLink
+	err = json.Unmarshal(resp.Result, &link)
+	return link, err
+}
+
+// IsHiddenForward habar forward qilingan, lekin egasi o'z akkauntini yashirganmi
+func IsHiddenForward(msg *tgbotapi.Message) bool {
+	return msg.ForwardDate != 0 && msg.ForwardFrom == nil && msg.ForwardFromChat == nil
+}
+
+// KeyboardButton tgbotapi.KeyboardButton'ning request_users qo'llaydigan varianti
+type KeyboardButton struct {
+	Text         string                      `json:"text"`
+	RequestUsers *KeyboardButtonRequestUsers `json:"request_users,omitempty"`
+}
+
+// KeyboardButtonRequestUsers https://core.telegram.org/bots/api#keyboardbuttonrequestusers
+type KeyboardButtonRequestUsers struct {
+	RequestID       int   `json:"request_id"`
+	UserIsBot       *bool `json:"user_is_bot,omitempty"`
+	MaxQuantity     int   `json:"max_quantity,omitempty"`
+	RequestName     bool  `json:"request_name,omitempty"`
+	RequestUsername bool  `json:"request_username,omitempty"`
+}
+
+// ReplyKeyboardMarkup MessageConfig.ReplyMarkup ga to'g'ridan-to'g'ri qo'yiladi
+type ReplyKeyboardMarkup struct {
+	Keyboard        [][]KeyboardButton `json:"keyboard"`
+	ResizeKeyboard  bool               `json:"resize_keyboard"`
+	OneTimeKeyboard bool               `json:"one_time_keyboard"`
+}
+
+// BotCommand buyruqlar menyusidagi bitta buyruq
+type BotCommand struct {
+	Command     string `json:"command"`
+	Description string `json:"description"`
+}
+
+// BotCommandScope buyruqlar kimga ko'rinishi: "default", "all_group_chats" yoki "chat" (ChatID bilan)
+type BotCommandScope struct {
+	Type   string `json:"type"`
+	ChatID int64  `json:"chat_id,omitempty"`
+}
+
+// SetMyCommands buyruqlar menyusini o'rnatadi. languageCode bo'sh bo'lsa, tilga xos ro'yxati
+// bo'lmagan foydalanuvchilar uchun umumiy ro'yxat o'rnatiladi.
+func SetMyCommands(botInstance *tgbotapi.BotAPI, commands []BotCommand, scope BotCommandScope, languageCode string) error {
+	params, err := commandParams(scope, languageCode)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(commands)
+	if err != nil {
+		return err
+	}
+	params.Set("commands", string(data))
+
+	_, err = sender.Request(botInstance, "setMyCommands", params)
+	return err
+}
+
+// DeleteMyCommands scope va til uchun o'rnatilgan buyruqlarni o'chiradi
+func DeleteMyCommands(botInstance *tgbotapi.BotAPI, scope BotCommandScope, languageCode string) error {
+	params, err := commandParams(scope, languageCode)
+	if err != nil {
+		return err
+	}
+
+	_, err = sender.Request(botInstance, "deleteMyCommands", params)
+	return err
+}
+
+func commandParams(scope BotCommandScope, languageCode string) (url.Values, error) {
+	data, err := json.Marshal(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("scope", string(data))
+	if languageCode != "" {
+		params.Set("language_code", languageCode)
+	}
+	return params, nil
+}
+
+// InlineQueryResultCachedVideo https://core.telegram.org/bots/api#inlinequeryresultcachedvideo
+type InlineQueryResultCachedVideo struct {
+	Type        string `json:"type"`
+	ID          string `json:"id"`
+	VideoFileID string `json:"video_file_id"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Caption     string `json:"caption,omitempty"`
+}
+
+// InlineQueryResultCachedAudio https://core.telegram.org/bots/api#inlinequeryresultcachedaudio
+type InlineQueryResultCachedAudio struct {
+	Type        string `json:"type"`
+	ID          string `json:"id"`
+	AudioFileID string `json:"audio_file_id"`
+	Caption     string `json:"caption,omitempty"`
+}
+
+// InlineQueryResultsButton natijalar ustidagi tugma: bosilganda bot bilan shaxsiy chat
+// "/start <StartParameter>" bilan ochiladi (eski switch_pm_text o'rnida)
+type InlineQueryResultsButton struct {
+	Text           string `json:"text"`
+	StartParameter string `json:"start_parameter"`
+}
+
+// AnswerInlineQuery inline so'rovga javob beradi. Natijalar foydalanuvchiga xos (obuna, limit),
+// shuning uchun is_personal doim yoqiladi.
+func AnswerInlineQuery(botInstance *tgbotapi.BotAPI, queryID string, results []interface{}, button *InlineQueryResultsButton, cacheTime int) error {
+	if results == nil {
+		results = []interface{}{}
+	}
+	data, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+
+	params := url.Values{}
+	params.Set("inline_query_id", queryID)
+	params.Set("results", string(data))
+	params.Set("cache_time", strconv.Itoa(cacheTime))
+	params.Set("is_personal", "true")
+	if button != nil {
+		data, err := json.Marshal(button)
+		if err != nil {
+			return err
+		}
+		params.Set("button", string(data))
+	}
+
+	_, err = sender.Request(botInstance, "answerInlineQuery", params)
+	return err
+}
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"yuklovchiBot/models"
+)
+
+// AddAuditEntry payload JSON matn ko'rinishida, bo'sh bo'lsa NULL yoziladi
+func AddAuditEntry(db *sql.DB, e models.AuditEntry) error {
+	query := `INSERT INTO admin_audit (actor_id, action, target, payload)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, '')::JSONB)`
+	_, err := db.Exec(query, e.ActorID, e.Action, e.Target, e.Payload)
+	return err
+}
+
+// GetAuditEntries yangisidan eskisiga qarab sahifalab qaytaradi
+func GetAuditEntries(db *sql.DB, f models.AuditFilter, limit, offset int) ([]models.AuditEntry, error) {
+	var conditions []string
+	var args []interface{}
+	if f.ActorID != 0 {
+		args = append(args, f.ActorID)
+		conditions = append(conditions, fmt.Sprintf("actor_id = $%d", len(args)))
+	}
+	if f.Section != "" {
+		args = append(args, f.Section+".%")
+		conditions = append(conditions, fmt.Sprintf("action LIKE $%d", len(args)))
+	}
+
+	query := `SELECT id, actor_id, action, COALESCE(target, ''), COALESCE(payload::TEXT, ''), created_at FROM admin_audit`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, limit, offset)
+	query += fmt.Sprintf(" ORDER BY id DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []models.AuditEntry
+	for rows.Next() {
+		var e models.AuditEntry
+		if err := rows.Scan(&e.ID, &e.ActorID, &e.Action, &e.Target, &e.Payload, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
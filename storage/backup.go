@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"database/sql"
+	"time"
+	"yuklovchiBot/models"
+)
+
+func AddBackup(db *sql.DB, b models.Backup) (int64, error) {
+	var id int64
+	query := `INSERT INTO backups (file_name, bytes, duration_ms, destination, trigger, status, error)
+		VALUES (NULLIF($1, ''), $2, $3, NULLIF($4, ''), $5, $6, NULLIF($7, '')) RETURNING id`
+	err := db.QueryRow(query, b.FileName, b.Bytes, b.Duration.Milliseconds(), b.Destination, b.Trigger, b.Status, b.Error).Scan(&id)
+	return id, err
+}
+
+// GetRecentBackups oxirgi limit ta backup yozuvini yangisidan eskisiga qarab qaytaradi
+func GetRecentBackups(db *sql.DB, limit int) ([]models.Backup, error) {
+	query := `SELECT id, COALESCE(file_name, ''), bytes, duration_ms, COALESCE(destination, ''), trigger, status,
+		COALESCE(error, ''), created_at
+		FROM backups ORDER BY id DESC LIMIT $1`
+	rows, err := db.Query(query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var backups []models.Backup
+	for rows.Next() {
+		var b models.Backup
+		var durationMs int64
+		if err := rows.Scan(&b.ID, &b.FileName, &b.Bytes, &durationMs, &b.Destination, &b.Trigger, &b.Status,
+			&b.Error, &b.CreatedAt); err != nil {
+			return nil, err
+		}
+		b.Duration = time.Duration(durationMs) * time.Millisecond
+		backups = append(backups, b)
+	}
+
+	return backups, rows.Err()
+}
@@ -0,0 +1,200 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"yuklovchiBot/models"
+)
+
+const broadcastColumns = `id, admin_id, source_chat_id, source_message_id, COALESCE(variant_b_message_id, 0),
+	COALESCE(button_text, ''), COALESCE(button_url, ''), audience, progress_message_id, status, created_at`
+
+// CreateBroadcast yangi broadcast yaratadi va auditoriyaga mos faol foydalanuvchilarni
+// "pending" holatida qabul qiluvchilar ro'yxatiga yozadi. B variant bo'lsa,
+// foydalanuvchilar tasodifiy ravishda teng ikkiga bo'linadi.
+func CreateBroadcast(db *sql.DB, b models.Broadcast) (int64, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var id int64
+	query := `INSERT INTO broadcasts (admin_id, source_chat_id, source_message_id, variant_b_message_id,
+		button_text, button_url, audience, progress_message_id)
+		VALUES ($1, $2, $3, NULLIF($4, 0), NULLIF($5, ''), NULLIF($6, ''), $7, $8) RETURNING id`
+	err = tx.QueryRow(query, b.AdminID, b.SourceChatID, b.SourceMessageID, b.VariantBMessageID,
+		b.ButtonText, b.ButtonURL, b.Audience.String(), b.ProgressMessageID).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	cond, args := audienceCondition(b.Audience, 3)
+	query = `INSERT INTO broadcast_recipients (broadcast_id, user_id, variant)
+		SELECT $1, id, CASE WHEN $2 AND row_number() OVER (ORDER BY random()) % 2 = 0 THEN 'B' ELSE 'A' END
+		FROM users WHERE ` + cond
+	args = append([]interface{}{id, b.VariantBMessageID != 0}, args...)
+	if _, err := tx.Exec(query, args...); err != nil {
+		return 0, err
+	}
+
+	return id, tx.Commit()
+}
+
+// CountAudience auditoriyadagi faol foydalanuvchilar soni
+func CountAudience(db *sql.DB, a models.Audience) (int, error) {
+	cond, args := audienceCondition(a, 1)
+	var count int
+	err := db.QueryRow("SELECT COUNT(*) FROM users WHERE "+cond, args...).Scan(&count)
+	return count, err
+}
+
+// audienceCondition users jadvali uchun WHERE shartini qaytaradi. Parametrlar $first dan boshlanadi.
+func audienceCondition(a models.Audience, first int) (string, []interface{}) {
+	cond := "status = 'active'"
+	switch a.Kind {
+	case models.AudienceNew:
+		return fmt.Sprintf("%s AND created_at >= NOW() - make_interval(days => $%d)", cond, first), []interface{}{a.Days}
+	case models.AudienceActive:
+		return fmt.Sprintf("%s AND last_active_at >= NOW() - make_interval(days => $%d)", cond, first), []interface{}{a.Days}
+	case models.AudienceLanguage:
+		// /lang bilan tanlangan til Telegram'dagi language_code'dan ustun (i18n.Resolve kabi)
+		return fmt.Sprintf("%s AND COALESCE(NULLIF(language, ''), language_code) LIKE $%d || '%%'", cond, first), []interface{}{a.Value}
+	case models.AudiencePlatform:
+		return fmt.Sprintf("%s AND EXISTS (SELECT 1 FROM downloads d WHERE d.user_id = users.id AND d.platform = $%d)", cond, first), []interface{}{a.Value}
+	}
+	return cond, nil
+}
+
+func scanBroadcast(row interface{ Scan(...interface{}) error }) (models.Broadcast, error) {
+	var b models.Broadcast
+	var audience string
+	err := row.Scan(&b.ID, &b.AdminID, &b.SourceChatID, &b.SourceMessageID, &b.VariantBMessageID,
+		&b.ButtonText, &b.ButtonURL, &audience, &b.ProgressMessageID, &b.Status, &b.CreatedAt)
+	b.Audience = models.ParseAudience(audience)
+	return b, err
+}
+
+func GetBroadcast(db *sql.DB, id int64) (models.Broadcast, error) {
+	query := `SELECT ` + broadcastColumns + ` FROM broadcasts WHERE id = $1`
+	return scanBroadcast(db.QueryRow(query, id))
+}
+
+// GetRunningBroadcasts qayta ishga tushganda davom ettirilishi kerak bo'lgan broadcastlarni qaytaradi.
+func GetRunningBroadcasts(db *sql.DB) ([]models.Broadcast, error) {
+	query := `SELECT ` + broadcastColumns + ` FROM broadcasts WHERE status = $1 ORDER BY id`
+	rows, err := db.Query(query, models.BroadcastRunning)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var broadcasts []models.Broadcast
+	for rows.Next() {
+		b, err := scanBroadcast(rows)
+		if err != nil {
+			return nil, err
+		}
+		broadcasts = append(broadcasts, b)
+	}
+
+	return broadcasts, rows.Err()
+}
+
+// SetBroadcastStatus broadcast holatini o'zgartiradi. Yakunlangan yoki bekor
+// qilingan broadcastlarning holati boshqa o'zgartirilmaydi.
+func SetBroadcastStatus(db *sql.DB, id int64, status string) (bool, error) {
+	query := `UPDATE broadcasts SET status = $2,
+		finished_at = CASE WHEN $2 IN ('completed', 'cancelled') THEN NOW() ELSE finished_at END
+		WHERE id = $1 AND status NOT IN ('completed', 'cancelled')`
+	res, err := db.Exec(query, id, status)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func GetPendingRecipients(db *sql.DB, broadcastID int64, limit int) ([]models.BroadcastRecipient, error) {
+	query := `SELECT user_id, variant FROM broadcast_recipients
+		WHERE broadcast_id = $1 AND status = 'pending' ORDER BY user_id LIMIT $2`
+	rows, err := db.Query(query, broadcastID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var recipients []models.BroadcastRecipient
+	for rows.Next() {
+		var r models.BroadcastRecipient
+		if err := rows.Scan(&r.UserID, &r.Variant); err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, r)
+	}
+
+	return recipients, rows.Err()
+}
+
+func MarkBroadcastRecipient(db *sql.DB, broadcastID, userID int64, status, errText string) error {
+	query := `UPDATE broadcast_recipients SET status = $3, error = NULLIF($4, ''), sent_at = NOW()
+		WHERE broadcast_id = $1 AND user_id = $2`
+	_, err := db.Exec(query, broadcastID, userID, status, errText)
+	return err
+}
+
+// GetBroadcastVariantStats har bir variant bo'yicha yetkazish va tugma bosish statistikasi
+func GetBroadcastVariantStats(db *sql.DB, broadcastID int64) (map[string]models.BroadcastStats, error) {
+	query := `SELECT r.variant,
+		COUNT(*),
+		COUNT(*) FILTER (WHERE r.status = 'sent'),
+		COUNT(*) FILTER (WHERE r.status = 'failed'),
+		COUNT(*) FILTER (WHERE r.status = 'blocked'),
+		COUNT(*) FILTER (WHERE r.status = 'pending'),
+		(SELECT COUNT(*) FROM broadcast_clicks c WHERE c.broadcast_id = r.broadcast_id AND c.variant = r.variant)
+		FROM broadcast_recipients r WHERE r.broadcast_id = $1
+		GROUP BY r.broadcast_id, r.variant`
+	rows, err := db.Query(query, broadcastID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := make(map[string]models.BroadcastStats)
+	for rows.Next() {
+		var variant string
+		var s models.BroadcastStats
+		if err := rows.Scan(&variant, &s.Total, &s.Sent, &s.Failed, &s.Blocked, &s.Pending, &s.Clicks); err != nil {
+			return nil, err
+		}
+		stats[variant] = s
+	}
+
+	return stats, rows.Err()
+}
+
+func GetBroadcastStats(db *sql.DB, broadcastID int64) (models.BroadcastStats, error) {
+	var total models.BroadcastStats
+	stats, err := GetBroadcastVariantStats(db, broadcastID)
+	if err != nil {
+		return total, err
+	}
+
+	for _, s := range stats {
+		total.Total += s.Total
+		total.Sent += s.Sent
+		total.Failed += s.Failed
+		total.Blocked += s.Blocked
+		total.Pending += s.Pending
+		total.Clicks += s.Clicks
+	}
+	return total, nil
+}
+
+// AddBroadcastClick foydalanuvchining tugma bosishini yozadi (har bir foydalanuvchi bir marta hisoblanadi)
+func AddBroadcastClick(db *sql.DB, broadcastID, userID int64, variant string) error {
+	query := `INSERT INTO broadcast_clicks (broadcast_id, user_id, variant) VALUES ($1, $2, $3)
+		ON CONFLICT (broadcast_id, user_id) DO NOTHING`
+	_, err := db.Exec(query, broadcastID, userID, variant)
+	return err
+}
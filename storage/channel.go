@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"database/sql"
+	"time"
+	"yuklovchiBot/models"
+)
+
+// AddJoinRequest so'rovni faqat majburiy obuna kanallari uchun saqlaydi. Kanal ro'yxatda bo'lmasa false.
+func AddJoinRequest(db *sql.DB, channelID, userID int64) (bool, error) {
+	query := `INSERT INTO channel_join_requests (channel_id, user_id)
+		SELECT $1, $2 WHERE EXISTS (SELECT 1 FROM channels WHERE id = $1 AND join_request)
+		ON CONFLICT (channel_id, user_id) DO UPDATE SET created_at = NOW()`
+	res, err := db.Exec(query, channelID, userID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// GetJoinRequests foydalanuvchi so'rov yuborgan kanallar IDlari
+func GetJoinRequests(db *sql.DB, userID int64) (map[int64]bool, error) {
+	rows, err := db.Query(`SELECT channel_id FROM channel_join_requests WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	requests := make(map[int64]bool)
+	for rows.Next() {
+		var channelID int64
+		if err := rows.Scan(&channelID); err != nil {
+			return nil, err
+		}
+		requests[channelID] = true
+	}
+	return requests, rows.Err()
+}
+
+// DeleteJoinRequest so'rov tasdiqlangach (foydalanuvchi a'zo bo'lgach) o'chiriladi
+func DeleteJoinRequest(db *sql.DB, channelID, userID int64) error {
+	_, err := db.Exec(`DELETE FROM channel_join_requests WHERE channel_id = $1 AND user_id = $2`, channelID, userID)
+	return err
+}
+
+const channelColumns = `COALESCE(id, 0), COALESCE(username, ''), COALESCE(title, ''), COALESCE(invite_link, ''),
+	join_request, starts_at, ends_at, COALESCE(target, 0), COALESCE(created_at, NOW())`
+
+// scanChannel channelColumns tartibidagi ustunlarni o'qiydi, keyin qo'shimcha ustunlar kelishi mumkin
+func scanChannel(rows *sql.Rows, c *models.Channel, extra ...interface{}) error {
+	var startsAt, endsAt sql.NullTime
+	dest := append([]interface{}{&c.ID, &c.Username, &c.Title, &c.InviteLink, &c.JoinRequest, &startsAt, &endsAt, &c.Target, &c.CreatedAt}, extra...)
+	if err := rows.Scan(dest...); err != nil {
+		return err
+	}
+	c.StartsAt, c.EndsAt = startsAt.Time, endsAt.Time
+	return nil
+}
+
+// SetChannelPeriod homiylik muddatini o'rnatadi, nol vaqt — cheklanmagan
+func SetChannelPeriod(db *sql.DB, key string, startsAt, endsAt time.Time) error {
+	query := `UPDATE channels SET starts_at = $2, ends_at = $3 WHERE LOWER(username) = LOWER($1) OR id::TEXT = $1`
+	_, err := db.Exec(query, key, nullTime(startsAt), nullTime(endsAt))
+	return err
+}
+
+// SetChannelTarget obunachilar maqsadini o'rnatadi, 0 — cheklanmagan
+func SetChannelTarget(db *sql.DB, key string, target int) error {
+	query := `UPDATE channels SET target = NULLIF($2, 0) WHERE LOWER(username) = LOWER($1) OR id::TEXT = $1`
+	_, err := db.Exec(query, key, target)
+	return err
+}
+
+// RecordGateShown foydalanuvchiga obuna talabi ko'rsatilgan kanallarni belgilaydi (birinchi marta ko'rsatilgani saqlanadi)
+func RecordGateShown(db *sql.DB, userID int64, channelIDs []int64) error {
+	query := `INSERT INTO channel_subscriptions (channel_id, user_id)
+		SELECT unnest($2::BIGINT[]), $1
+		ON CONFLICT (channel_id, user_id) DO NOTHING`
+	_, err := db.Exec(query, userID, channelIDs)
+	return err
+}
+
+// MarkChannelJoined obuna talabi ko'rsatilgan foydalanuvchi obuna bo'lganini yozadi.
+// Chiqib ketib, qayta obuna bo'lganda left_at tozalanadi.
+func MarkChannelJoined(db *sql.DB, channelID, userID int64) error {
+	query := `UPDATE channel_subscriptions SET joined_at = COALESCE(joined_at, NOW()), left_at = NULL
+		WHERE channel_id = $1 AND user_id = $2 AND (joined_at IS NULL OR left_at IS NOT NULL)`
+	_, err := db.Exec(query, channelID, userID)
+	return err
+}
+
+// MarkChannelLeft bot orqali obuna bo'lib, keyin chiqib ketgan foydalanuvchini belgilaydi
+func MarkChannelLeft(db *sql.DB, channelID, userID int64) error {
+	query := `UPDATE channel_subscriptions SET left_at = NOW()
+		WHERE channel_id = $1 AND user_id = $2 AND joined_at IS NOT NULL AND left_at IS NULL`
+	_, err := db.Exec(query, channelID, userID)
+	return err
+}
+
+// channelStats bot orqali obuna ko'rsatkichlari, kanal ID si bo'yicha
+const channelStats = `WITH stats AS (
+		SELECT channel_id, COUNT(*) AS shown, COUNT(joined_at) AS passed, COUNT(left_at) AS left_count
+		FROM channel_subscriptions GROUP BY channel_id
+	)`
+
+const channelReportColumns = channelColumns + `, COALESCE(stats.shown, 0), COALESCE(stats.passed, 0), COALESCE(stats.left_count, 0)`
+
+// GetChannelReports har bir kanal uchun bot orqali obuna ko'rsatkichlari
+func GetChannelReports(db *sql.DB) ([]models.ChannelReport, error) {
+	query := channelStats + `
+		SELECT ` + channelReportColumns + `
+		FROM channels LEFT JOIN stats ON stats.channel_id = channels.id
+		ORDER BY created_at`
+	return queryChannelReports(db, query)
+}
+
+// ExpireChannels muddati tugagan yoki maqsadga yetgan kanallarni qo'shilish so'rovlari bilan o'chiradi
+// va ularning yakuniy hisobotini qaytaradi
+func ExpireChannels(db *sql.DB) ([]models.ChannelReport, error) {
+	query := channelStats + `, expired AS (
+			DELETE FROM channels
+			WHERE ends_at <= NOW() OR target <= (SELECT passed FROM stats WHERE stats.channel_id = channels.id)
+			RETURNING *
+		), cleared AS (
+			DELETE FROM channel_join_requests WHERE channel_id IN (SELECT id FROM expired)
+		)
+		SELECT ` + channelReportColumns + `
+		FROM expired channels LEFT JOIN stats ON stats.channel_id = channels.id`
+	return queryChannelReports(db, query)
+}
+
+func queryChannelReports(db *sql.DB, query string) ([]models.ChannelReport, error) {
+	rows, err := db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reports []models.ChannelReport
+	for rows.Next() {
+		var r models.ChannelReport
+		if err := scanChannel(rows, &r.Channel, &r.Shown, &r.Passed, &r.Left); err != nil {
+			return nil, err
+		}
+		reports = append(reports, r)
+	}
+	return reports, rows.Err()
+}
+
+func nullTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
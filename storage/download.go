@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"database/sql"
+	"time"
+	"yuklovchiBot/models"
+)
+
+// AddDownload yangi yuklash so'rovini "pending" holatida yozadi va uning ID'sini qaytaradi
+func AddDownload(db *sql.DB, userID int64, platform, sourceURL string) (int64, error) {
+	var id int64
+	query := `INSERT INTO downloads (user_id, platform, source_url) VALUES ($1, $2, NULLIF($3, '')) RETURNING id`
+	err := db.QueryRow(query, userID, platform, sourceURL).Scan(&id)
+	return id, err
+}
+
+// FinishDownload yuklash natijasini yozadi
+func FinishDownload(db *sql.DB, id int64, d models.Download, duration time.Duration, bytes int64, cached bool, errText string) error {
+	query := `UPDATE downloads SET status = $2, media_type = NULLIF($3, ''), file_id = NULLIF($4, ''),
+		title = NULLIF($5, ''), duration_ms = $6, bytes = $7, cached = $8, error = NULLIF($9, '')
+		WHERE id = $1`
+	_, err := db.Exec(query, id, d.Status, d.MediaType, d.FileID, d.Title, duration.Milliseconds(), bytes, cached, errText)
+	return err
+}
+
+func GetDownload(db *sql.DB, id int64) (models.Download, error) {
+	var d models.Download
+	query := `SELECT id, user_id, platform, COALESCE(source_url, ''), status, COALESCE(media_type, ''),
+		COALESCE(file_id, ''), COALESCE(title, ''), created_at, hidden
+		FROM downloads WHERE id = $1`
+	err := db.QueryRow(query, id).Scan(&d.ID, &d.UserID, &d.Platform, &d.SourceURL, &d.Status, &d.MediaType,
+		&d.FileID, &d.Title, &d.CreatedAt, &d.Hidden)
+	return d, err
+}
+
+func GetMediaCache(db *sql.DB, key string) (models.MediaCache, bool, error) {
+	var m models.MediaCache
+	query := `SELECT cache_key, platform, media_type, file_id, COALESCE(title, ''), bytes FROM media_cache WHERE cache_key = $1`
+	err := db.QueryRow(query, key).Scan(&m.Key, &m.Platform, &m.MediaType, &m.FileID, &m.Title, &m.Bytes)
+	if err == sql.ErrNoRows {
+		return m, false, nil
+	}
+	return m, err == nil, err
+}
+
+func SaveMediaCache(db *sql.DB, m models.MediaCache) error {
+	query := `INSERT INTO media_cache (cache_key, platform, media_type, file_id, title, bytes)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6)
+		ON CONFLICT (cache_key) DO UPDATE SET file_id = EXCLUDED.file_id, bytes = EXCLUDED.bytes, created_at = NOW()`
+	_, err := db.Exec(query, m.Key, m.Platform, m.MediaType, m.FileID, m.Title, m.Bytes)
+	return err
+}
+
+// DeleteMediaCache yaroqsiz bo'lib qolgan file_id'ni keshdan o'chiradi
+func DeleteMediaCache(db *sql.DB, key string) error {
+	_, err := db.Exec(`DELETE FROM media_cache WHERE cache_key = $1`, key)
+	return err
+}
+
+// GetUserHistory foydalanuvchining muvaffaqiyatli yuklashlari, yangilari birinchi. Bir xil fayl
+// (masalan, keshdan qayta yuborilgan havola) bir marta, oxirgi yuklash sanasi bilan chiqadi.
+func GetUserHistory(db *sql.DB, userID int64, limit, offset int) ([]models.Download, error) {
+	query := `SELECT id, user_id, platform, source_url, status, media_type, file_id, title, created_at FROM (
+			SELECT DISTINCT ON (file_id) id, user_id, platform, COALESCE(source_url, '') AS source_url, status,
+				COALESCE(media_type, '') AS media_type, file_id, COALESCE(title, '') AS title, created_at
+			FROM downloads
+			WHERE user_id = $1 AND status = 'success' AND file_id IS NOT NULL AND NOT hidden
+			ORDER BY file_id, created_at DESC
+		) d
+		ORDER BY created_at DESC LIMIT $2 OFFSET $3`
+	rows, err := db.Query(query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var downloads []models.Download
+	for rows.Next() {
+		var d models.Download
+		if err := rows.Scan(&d.ID, &d.UserID, &d.Platform, &d.SourceURL, &d.Status, &d.MediaType,
+			&d.FileID, &d.Title, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		downloads = append(downloads, d)
+	}
+	return downloads, rows.Err()
+}
+
+// HideUserHistory foydalanuvchi tarixini tozalaydi (yozuvlar statistikada qoladi)
+func HideUserHistory(db *sql.DB, userID int64) error {
+	_, err := db.Exec(`UPDATE downloads SET hidden = TRUE WHERE user_id = $1 AND NOT hidden`, userID)
+	return err
+}
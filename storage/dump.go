@@ -0,0 +1,302 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Mantiqiy backup formati: har bir qator alohida JSON obyekt (JSON-lines).
+// Birinchi qator sarlavha, keyingilari {"table": "...", "row": {...}}.
+// Faqat ma'lumotlar saqlanadi, sxema migratsiyalar orqali yaratilgan bo'lishi kerak.
+const (
+	dumpFormat  = "yuklovchiBot-jsonl"
+	dumpVersion = 1
+
+	restoreBatchSize = 500
+)
+
+type dumpHeader struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Tables    []string  `json:"tables"`
+}
+
+type dumpLine struct {
+	Table string          `json:"table"`
+	Row   json.RawMessage `json:"row"`
+}
+
+// queryer *sql.DB va *sql.Tx uchun umumiy
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// DumpDatabase public sxemadagi barcha jadvallarni w ga oqim ko'rinishida yozadi.
+// Jadvallar tashqi kalitlar bo'yicha tartiblanadi, shuning uchun tiklashda shu tartibda qo'yish mumkin.
+// Hamma jadval bitta REPEATABLE READ tranzaksiyasida o'qiladi, shuning uchun bog'langan qatorlar bir xil holatda olinadi.
+func DumpDatabase(db *sql.DB, w io.Writer) (int, error) {
+	tx, err := db.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	tables, err := dumpTables(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	bw := bufio.NewWriter(w)
+	header, err := json.Marshal(dumpHeader{Format: dumpFormat, Version: dumpVersion, CreatedAt: time.Now(), Tables: tables})
+	if err != nil {
+		return 0, err
+	}
+	bw.Write(header)
+	bw.WriteByte('\n')
+
+	var count int
+	for _, table := range tables {
+		n, err := dumpTable(tx, bw, table)
+		count += n
+		if err != nil {
+			return count, fmt.Errorf("dump %s: %w", table, err)
+		}
+	}
+
+	return count, bw.Flush()
+}
+
+func dumpTable(q queryer, w *bufio.Writer, table string) (int, error) {
+	rows, err := q.Query(fmt.Sprintf(`SELECT row_to_json(t)::TEXT FROM %s t`, quoteIdent(table)))
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	name, _ := json.Marshal(table)
+	var count int
+	for rows.Next() {
+		var row []byte
+		if err := rows.Scan(&row); err != nil {
+			return count, err
+		}
+		w.WriteString(`{"table":`)
+		w.Write(name)
+		w.WriteString(`,"row":`)
+		w.Write(row)
+		if _, err := w.WriteString("}\n"); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, rows.Err()
+}
+
+// RestoreDatabase DumpDatabase yozgan arxivni bitta tranzaksiyada tiklaydi: arxivdagi jadvallar
+// tozalanadi, qatorlar qayta qo'yiladi va BIGSERIAL ketma-ketliklari yangilanadi.
+func RestoreDatabase(db *sql.DB, r io.Reader) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return 0, err
+		}
+		return 0, fmt.Errorf("restore: empty archive")
+	}
+	var header dumpHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil || header.Format != dumpFormat {
+		return 0, fmt.Errorf("restore: not a %s archive", dumpFormat)
+	}
+	if header.Version > dumpVersion {
+		return 0, fmt.Errorf("restore: unsupported archive version %d", header.Version)
+	}
+
+	existing, err := dumpTables(db)
+	if err != nil {
+		return 0, err
+	}
+	known := make(map[string]bool, len(existing))
+	for _, t := range existing {
+		known[t] = true
+	}
+	quoted := make([]string, 0, len(header.Tables))
+	for _, t := range header.Tables {
+		if !known[t] {
+			return 0, fmt.Errorf("restore: table %q does not exist, run migrations first", t)
+		}
+		quoted = append(quoted, quoteIdent(t))
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if len(quoted) > 0 {
+		if _, err := tx.Exec(`TRUNCATE ` + strings.Join(quoted, ", ") + ` CASCADE`); err != nil {
+			return 0, err
+		}
+	}
+
+	var count int
+	var table string
+	var batch [][]byte
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		query := fmt.Sprintf(`INSERT INTO %[1]s SELECT * FROM json_populate_recordset(NULL::%[1]s, $1::JSON)`, quoteIdent(table))
+		if _, err := tx.Exec(query, string(append(append([]byte{'['}, bytes.Join(batch, []byte{','})...), ']'))); err != nil {
+			return fmt.Errorf("restore %s: %w", table, err)
+		}
+		count += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	for scanner.Scan() {
+		var line dumpLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			return count, fmt.Errorf("restore: bad line: %w", err)
+		}
+		if !known[line.Table] {
+			return count, fmt.Errorf("restore: unknown table %q", line.Table)
+		}
+		if line.Table != table || len(batch) >= restoreBatchSize {
+			if err := flush(); err != nil {
+				return count, err
+			}
+			table = line.Table
+		}
+		batch = append(batch, line.Row)
+	}
+	if err := scanner.Err(); err != nil {
+		return count, err
+	}
+	if err := flush(); err != nil {
+		return count, err
+	}
+
+	if err := resetSequences(tx, header.Tables); err != nil {
+		return count, err
+	}
+
+	return count, tx.Commit()
+}
+
+// resetSequences serial ustunlarning ketma-ketligini jadvaldagi eng katta qiymatga moslaydi
+func resetSequences(tx *sql.Tx, tables []string) error {
+	rows, err := tx.Query(`SELECT table_name, column_name FROM information_schema.columns
+		WHERE table_schema = 'public' AND table_name = ANY($1) AND column_default LIKE 'nextval(%'`, tables)
+	if err != nil {
+		return err
+	}
+
+	type serial struct{ table, column string }
+	var serials []serial
+	for rows.Next() {
+		var s serial
+		if err := rows.Scan(&s.table, &s.column); err != nil {
+			rows.Close()
+			return err
+		}
+		serials = append(serials, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, s := range serials {
+		query := fmt.Sprintf(`SELECT setval(pg_get_serial_sequence($1, $2), COALESCE(MAX(%[1]s), 1), MAX(%[1]s) IS NOT NULL) FROM %[2]s`,
+			quoteIdent(s.column), quoteIdent(s.table))
+		if _, err := tx.Exec(query, s.table, s.column); err != nil {
+			return fmt.Errorf("reset sequence %s.%s: %w", s.table, s.column, err)
+		}
+	}
+	return nil
+}
+
+// dumpTables public sxemadagi jadvallarni shunday tartibda qaytaradiki, har bir jadval
+// o'zi tashqi kalit orqali bog'langan jadvallardan keyin keladi
+func dumpTables(q queryer) ([]string, error) {
+	rows, err := q.Query(`SELECT table_name FROM information_schema.tables
+		WHERE table_schema = 'public' AND table_type = 'BASE TABLE' ORDER BY table_name`)
+	if err != nil {
+		return nil, err
+	}
+	var tables []string
+	for rows.Next() {
+		var t string
+		if err := rows.Scan(&t); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		tables = append(tables, t)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = q.Query(`SELECT c.relname, p.relname FROM pg_constraint k
+		JOIN pg_class c ON c.oid = k.conrelid
+		JOIN pg_class p ON p.oid = k.confrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE k.contype = 'f' AND n.nspname = 'public' AND c.oid <> p.oid`)
+	if err != nil {
+		return nil, err
+	}
+	deps := make(map[string][]string)
+	for rows.Next() {
+		var child, parent string
+		if err := rows.Scan(&child, &parent); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		deps[child] = append(deps[child], parent)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool, len(tables))
+	for _, t := range tables {
+		known[t] = true
+	}
+
+	ordered := make([]string, 0, len(tables))
+	visited := make(map[string]bool, len(tables))
+	var visit func(t string)
+	visit = func(t string) {
+		if visited[t] || !known[t] {
+			return
+		}
+		visited[t] = true
+		for _, parent := range deps[t] {
+			visit(parent)
+		}
+		ordered = append(ordered, t)
+	}
+	for _, t := range tables {
+		visit(t)
+	}
+
+	return ordered, nil
+}
+
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
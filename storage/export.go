@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const (
+	ExportUsers      = "users"
+	ExportDownloads  = "downloads"
+	ExportBroadcasts = "broadcasts"
+)
+
+// Har bir eksport so'rovi barcha ustunlarni matn ko'rinishida qaytaradi, ustun nomlari sarlavha bo'ladi
+var exportQueries = map[string]string{
+	ExportUsers: `SELECT u.id::TEXT AS user_id, u.status, u.language_code,
+		u.referred_by::TEXT AS referred_by, u.campaign,
+		to_char(u.created_at, 'YYYY-MM-DD HH24:MI:SS') AS created_at,
+		to_char(u.last_active_at, 'YYYY-MM-DD HH24:MI:SS') AS last_active_at,
+		(SELECT COUNT(*) FROM downloads d WHERE d.user_id = u.id)::TEXT AS downloads
+		FROM users u WHERE u.created_at >= $1 AND u.created_at < $2
+		ORDER BY u.created_at`,
+	ExportDownloads: `SELECT id::TEXT AS id, user_id::TEXT AS user_id, platform, source_url, status, error,
+		media_type, title, bytes::TEXT AS bytes, duration_ms::TEXT AS duration_ms, cached::TEXT AS cached,
+		to_char(created_at, 'YYYY-MM-DD HH24:MI:SS') AS created_at
+		FROM downloads WHERE created_at >= $1 AND created_at < $2
+		ORDER BY id`,
+	ExportBroadcasts: `SELECT b.id::TEXT AS id, b.admin_id::TEXT AS admin_id, b.audience, b.status,
+		to_char(b.created_at, 'YYYY-MM-DD HH24:MI:SS') AS created_at,
+		to_char(b.finished_at, 'YYYY-MM-DD HH24:MI:SS') AS finished_at,
+		(SELECT COUNT(*) FROM broadcast_recipients r WHERE r.broadcast_id = b.id)::TEXT AS recipients,
+		(SELECT COUNT(*) FROM broadcast_recipients r WHERE r.broadcast_id = b.id AND r.status = 'sent')::TEXT AS sent,
+		(SELECT COUNT(*) FROM broadcast_recipients r WHERE r.broadcast_id = b.id AND r.status = 'failed')::TEXT AS failed,
+		(SELECT COUNT(*) FROM broadcast_recipients r WHERE r.broadcast_id = b.id AND r.status = 'blocked')::TEXT AS blocked,
+		(SELECT COUNT(*) FROM broadcast_clicks c WHERE c.broadcast_id = b.id)::TEXT AS clicks
+		FROM broadcasts b WHERE b.created_at >= $1 AND b.created_at < $2
+		ORDER BY b.id`,
+}
+
+// ExportTable [from, to) oralig'idagi qatorlarni birma-bir fn ga uzatadi. Birinchi chaqiruvda ustun nomlari keladi.
+func ExportTable(db *sql.DB, table string, from, to time.Time, fn func(record []string) error) (int, error) {
+	query, ok := exportQueries[table]
+	if !ok {
+		return 0, fmt.Errorf("unknown export table %q", table)
+	}
+
+	rows, err := db.Query(query, from, to)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	if err := fn(columns); err != nil {
+		return 0, err
+	}
+
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	record := make([]string, len(columns))
+
+	var count int
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return count, err
+		}
+		for i, v := range values {
+			record[i] = v.String
+		}
+		if err := fn(record); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, rows.Err()
+}
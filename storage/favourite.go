@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"database/sql"
+	"yuklovchiBot/models"
+)
+
+// AddFavourite yuklangan faylni sevimlilarga qo'shadi. Fayl allaqachon sevimlilarda bo'lsa false qaytaradi.
+func AddFavourite(db *sql.DB, userID int64, d models.Download) (bool, error) {
+	query := `INSERT INTO favourites (user_id, platform, media_type, file_id, title, source_url)
+		VALUES ($1, $2, NULLIF($3, ''), $4, NULLIF($5, ''), NULLIF($6, ''))
+		ON CONFLICT (user_id, file_id) DO NOTHING`
+	res, err := db.Exec(query, userID, d.Platform, d.MediaType, d.FileID, d.Title, d.SourceURL)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// GetFavourites foydalanuvchi sevimlilari, yangilari birinchi. collectionID 0 bo'lsa barchasi.
+func GetFavourites(db *sql.DB, userID, collectionID int64, limit, offset int) ([]models.Favourite, error) {
+	query := `SELECT id, user_id, COALESCE(collection_id, 0), platform, COALESCE(media_type, ''), file_id,
+			COALESCE(title, ''), COALESCE(source_url, ''), created_at
+		FROM favourites
+		WHERE user_id = $1 AND ($2::BIGINT = 0 OR collection_id = $2)
+		ORDER BY id DESC LIMIT $3 OFFSET $4`
+	rows, err := db.Query(query, userID, collectionID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var favourites []models.Favourite
+	for rows.Next() {
+		var f models.Favourite
+		if err := rows.Scan(&f.ID, &f.UserID, &f.CollectionID, &f.Platform, &f.MediaType, &f.FileID,
+			&f.Title, &f.SourceURL, &f.CreatedAt); err != nil {
+			return nil, err
+		}
+		favourites = append(favourites, f)
+	}
+	return favourites, rows.Err()
+}
+
+func GetFavourite(db *sql.DB, id int64) (models.Favourite, error) {
+	var f models.Favourite
+	query := `SELECT id, user_id, COALESCE(collection_id, 0), platform, COALESCE(media_type, ''), file_id,
+			COALESCE(title, ''), COALESCE(source_url, ''), created_at
+		FROM favourites WHERE id = $1`
+	err := db.QueryRow(query, id).Scan(&f.ID, &f.UserID, &f.CollectionID, &f.Platform, &f.MediaType, &f.FileID,
+		&f.Title, &f.SourceURL, &f.CreatedAt)
+	return f, err
+}
+
+func CountFavourites(db *sql.DB, userID int64) (int, error) {
+	var count int
+	err := db.QueryRow(`SELECT COUNT(*) FROM favourites WHERE user_id = $1`, userID).Scan(&count)
+	return count, err
+}
+
+// DeleteFavourite faylni sevimlilardan olib tashlaydi (faqat egasi)
+func DeleteFavourite(db *sql.DB, userID, id int64) error {
+	_, err := db.Exec(`DELETE FROM favourites WHERE id = $1 AND user_id = $2`, id, userID)
+	return err
+}
+
+// MoveFavourite faylni to'plamga o'tkazadi, collectionID 0 bo'lsa to'plamdan chiqaradi.
+// To'plam ham, fayl ham shu foydalanuvchiniki bo'lishi kerak.
+func MoveFavourite(db *sql.DB, userID, id, collectionID int64) error {
+	query := `UPDATE favourites SET collection_id = NULLIF($3::BIGINT, 0)
+		WHERE id = $2 AND user_id = $1
+			AND ($3::BIGINT = 0 OR EXISTS (SELECT 1 FROM collections WHERE id = $3 AND user_id = $1))`
+	_, err := db.Exec(query, userID, id, collectionID)
+	return err
+}
+
+// AddCollection to'plam yaratadi. Shu nomli to'plam bo'lsa, uning ID'si qaytadi.
+func AddCollection(db *sql.DB, userID int64, name string) (int64, error) {
+	var id int64
+	query := `INSERT INTO collections (user_id, name) VALUES ($1, $2)
+		ON CONFLICT (user_id, name) DO UPDATE SET name = EXCLUDED.name
+		RETURNING id`
+	err := db.QueryRow(query, userID, name).Scan(&id)
+	return id, err
+}
+
+// GetCollections foydalanuvchi to'plamlari fayllar soni bilan
+func GetCollections(db *sql.DB, userID int64) ([]models.Collection, error) {
+	query := `SELECT c.id, c.user_id, c.name, c.shared, COUNT(f.id), c.created_at
+		FROM collections c
+		LEFT JOIN favourites f ON f.collection_id = c.id
+		WHERE c.user_id = $1
+		GROUP BY c.id
+		ORDER BY c.name`
+	rows, err := db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var collections []models.Collection
+	for rows.Next() {
+		var c models.Collection
+		if err := rows.Scan(&c.ID, &c.UserID, &c.Name, &c.Shared, &c.Items, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		collections = append(collections, c)
+	}
+	return collections, rows.Err()
+}
+
+func GetCollection(db *sql.DB, id int64) (models.Collection, error) {
+	var c models.Collection
+	query := `SELECT c.id, c.user_id, c.name, c.shared, COUNT(f.id), c.created_at
+		FROM collections c
+		LEFT JOIN favourites f ON f.collection_id = c.id
+		WHERE c.id = $1
+		GROUP BY c.id`
+	err := db.QueryRow(query, id).Scan(&c.ID, &c.UserID, &c.Name, &c.Shared, &c.Items, &c.CreatedAt)
+	return c, err
+}
+
+// ShareCollection to'plamni havola orqali ochiladigan qiladi
+func ShareCollection(db *sql.DB, userID, id int64) error {
+	_, err := db.Exec(`UPDATE collections SET shared = TRUE WHERE id = $1 AND user_id = $2`, id, userID)
+	return err
+}
+
+// DeleteCollection to'plamni o'chiradi, undagi fayllar sevimlilarda qoladi
+func DeleteCollection(db *sql.DB, userID, id int64) error {
+	_, err := db.Exec(`DELETE FROM collections WHERE id = $1 AND user_id = $2`, id, userID)
+	return err
+}
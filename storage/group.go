@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"database/sql"
+	"yuklovchiBot/models"
+)
+
+// GetGroupSettings guruh sozlamalarini qaytaradi. Guruh hali saqlanmagan bo'lsa standart qiymatlar va false.
+func GetGroupSettings(db *sql.DB, chatID int64) (models.GroupSettings, bool, error) {
+	s := models.DefaultGroupSettings(chatID)
+	query := `SELECT COALESCE(title, ''), COALESCE(language, ''), instagram, tiktok, youtube, delete_link, reply, quiet
+		FROM group_settings WHERE chat_id = $1`
+	err := db.QueryRow(query, chatID).Scan(&s.Title, &s.Language, &s.Instagram, &s.TikTok, &s.YouTube,
+		&s.DeleteLink, &s.Reply, &s.Quiet)
+	if err == sql.ErrNoRows {
+		return s, false, nil
+	}
+	return s, err == nil, err
+}
+
+// SaveGroupSettings sozlamalarni saqlaydi va guruhni faol deb belgilaydi
+func SaveGroupSettings(db *sql.DB, s models.GroupSettings) error {
+	query := `INSERT INTO group_settings (chat_id, title, language, instagram, tiktok, youtube, delete_link, reply, quiet)
+		VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (chat_id) DO UPDATE SET
+			title = EXCLUDED.title,
+			language = EXCLUDED.language,
+			instagram = EXCLUDED.instagram,
+			tiktok = EXCLUDED.tiktok,
+			youtube = EXCLUDED.youtube,
+			delete_link = EXCLUDED.delete_link,
+			reply = EXCLUDED.reply,
+			quiet = EXCLUDED.quiet,
+			active = TRUE,
+			updated_at = NOW()`
+	_, err := db.Exec(query, s.ChatID, s.Title, s.Language, s.Instagram, s.TikTok, s.YouTube, s.DeleteLink, s.Reply, s.Quiet)
+	return err
+}
+
+// SetGroupInactive bot guruhdan chiqarilganda chaqiriladi, sozlamalar qayta qo'shilganda saqlanib qoladi
+func SetGroupInactive(db *sql.DB, chatID int64) error {
+	_, err := db.Exec(`UPDATE group_settings SET active = FALSE, updated_at = NOW() WHERE chat_id = $1`, chatID)
+	return err
+}
+
+// MigrateGroup guruh supergroupga aylanganda sozlamalarni yangi chat ID'ga o'tkazadi
+func MigrateGroup(db *sql.DB, oldChatID, newChatID int64) error {
+	query := `UPDATE group_settings SET chat_id = $2, updated_at = NOW()
+		WHERE chat_id = $1 AND NOT EXISTS (SELECT 1 FROM group_settings WHERE chat_id = $2)`
+	_, err := db.Exec(query, oldChatID, newChatID)
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net/url"
 
 	_ "github.com/jackc/pgx/v4/stdlib" // To'g'ri `pgx` driver
 	"yuklovchiBot/config"
@@ -11,16 +12,18 @@ import (
 )
 
 func New(ctx context.Context, cfg config.Config, log logger.Logger) (*sql.DB, error) {
-	url := fmt.Sprintf(
-		"postgres://%s:%s@%s:%s/%s?sslmode=disable",
+	// Sessiya zonasi bot zonasiga tenglanadi: created_at::date va to_char kunlarni bot vaqti bilan ajratadi
+	dsn := fmt.Sprintf(
+		"postgres://%s:%s@%s:%s/%s?sslmode=disable&timezone=%s",
 		cfg.PostgresUser,
 		cfg.PostgresPassword,
 		cfg.PostgresHost,
 		cfg.PostgresPort,
 		cfg.PostgresDB,
+		url.QueryEscape(cfg.Location().String()),
 	)
 
-	db, err := sql.Open("pgx", url)
+	db, err := sql.Open("pgx", dsn)
 	if err != nil {
 		log.Error("error while connecting to db", logger.Error(err))
 		return nil, err
@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"database/sql"
+	"time"
+	"yuklovchiBot/models"
+)
+
+func AddScheduledMessage(db *sql.DB, m models.ScheduledMessage) (int64, error) {
+	var id int64
+	query := `INSERT INTO scheduled_messages (admin_id, source_chat_id, source_message_id, run_at, repeat)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	err := db.QueryRow(query, m.AdminID, m.SourceChatID, m.SourceMessageID, m.RunAt, m.Repeat).Scan(&id)
+	return id, err
+}
+
+// GetUpcomingScheduledMessages hali yuborilmagan rejalashtirilgan xabarlarni vaqt bo'yicha qaytaradi
+func GetUpcomingScheduledMessages(db *sql.DB) ([]models.ScheduledMessage, error) {
+	query := `SELECT id, admin_id, source_chat_id, source_message_id, run_at, repeat, status
+		FROM scheduled_messages WHERE status = 'pending' ORDER BY run_at`
+	return queryScheduledMessages(db, query)
+}
+
+// GetDueScheduledMessages yuborish vaqti kelgan xabarlarni qaytaradi
+func GetDueScheduledMessages(db *sql.DB, now time.Time) ([]models.ScheduledMessage, error) {
+	query := `SELECT id, admin_id, source_chat_id, source_message_id, run_at, repeat, status
+		FROM scheduled_messages WHERE status = 'pending' AND run_at <= $1 ORDER BY run_at`
+	return queryScheduledMessages(db, query, now)
+}
+
+func queryScheduledMessages(db *sql.DB, query string, args ...interface{}) ([]models.ScheduledMessage, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []models.ScheduledMessage
+	for rows.Next() {
+		var m models.ScheduledMessage
+		if err := rows.Scan(&m.ID, &m.AdminID, &m.SourceChatID, &m.SourceMessageID, &m.RunAt, &m.Repeat, &m.Status); err != nil {
+			return nil, err
+		}
+		messages = append(messages, m)
+	}
+
+	return messages, rows.Err()
+}
+
+// ClaimScheduledMessage xabarni "running" holatiga o'tkazadi. Boshqa jarayon olib ulgurgan yoki xabar
+// bekor qilingan bo'lsa false qaytaradi. Haftalik xabarning run_at'i shu so'rovning o'zida nextRunAt'ga
+// suriladi: jarayon broadcast paytida to'xtab qolsa, ResetStaleScheduledMessages uni keyingi haftaga
+// qaytaradi va bu haftaniki qayta yuborilmaydi.
+func ClaimScheduledMessage(db *sql.DB, id int64, nextRunAt time.Time) (bool, error) {
+	query := `UPDATE scheduled_messages SET status = 'running', run_at = COALESCE($2, run_at)
+		WHERE id = $1 AND status = 'pending'`
+	res, err := db.Exec(query, id, nullTime(nextRunAt))
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// ResetStaleScheduledMessages oldingi jarayondan "running" da qolib ketgan xabarlarni tiklaydi: haftalik
+// xabarlar (run_at allaqachon surilgan) kutishga qaytadi, bir martaliklari broadcast boshlangan-boshlanmagani
+// noma'lum bo'lgani uchun qayta yuborilmasdan "failed" bo'ladi. Faqat scheduler ishga tushganda chaqiriladi.
+func ResetStaleScheduledMessages(db *sql.DB) (int64, error) {
+	query := `UPDATE scheduled_messages SET
+		status = CASE WHEN repeat = 'weekly' THEN 'pending' ELSE 'failed' END
+		WHERE status = 'running'`
+	res, err := db.Exec(query)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// MarkScheduledMessageRun ishga tushirilgan xabarni broadcast bilan bog'laydi. Haftalik xabar
+// (run_at claim paytida surilgan) keyingi safar uchun kutishga qaytadi, bir martaligi yakunlanadi;
+// broadcastID 0 bo'lsa (broadcast boshlanmadi) bir martalik xabar "failed" bo'ladi.
+func MarkScheduledMessageRun(db *sql.DB, id, broadcastID int64) error {
+	query := `UPDATE scheduled_messages SET
+		status = CASE WHEN repeat = 'weekly' THEN 'pending' WHEN $2::BIGINT = 0 THEN 'failed' ELSE 'done' END,
+		last_broadcast_id = COALESCE(NULLIF($2::BIGINT, 0), last_broadcast_id)
+		WHERE id = $1`
+	_, err := db.Exec(query, id, broadcastID)
+	return err
+}
+
+func CancelScheduledMessage(db *sql.DB, id int64) (bool, error) {
+	query := `UPDATE scheduled_messages SET status = 'cancelled' WHERE id = $1 AND status = 'pending'`
+	res, err := db.Exec(query, id)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
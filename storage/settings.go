@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"database/sql"
+	"yuklovchiBot/models"
+)
+
+// GetUserSettings foydalanuvchi sozlamalarini qaytaradi, saqlanmagan bo'lsa standart qiymatlar
+func GetUserSettings(db *sql.DB, userID int64) (models.UserSettings, error) {
+	s := models.DefaultUserSettings()
+	query := `SELECT audio_mode, youtube_quality, as_document, caption, no_watermark
+		FROM user_settings WHERE user_id = $1`
+	err := db.QueryRow(query, userID).Scan(&s.AudioMode, &s.YouTubeQuality, &s.AsDocument, &s.Caption, &s.NoWatermark)
+	if err == sql.ErrNoRows {
+		return s, nil
+	}
+	return s, err
+}
+
+func SaveUserSettings(db *sql.DB, userID int64, s models.UserSettings) error {
+	query := `INSERT INTO user_settings (user_id, audio_mode, youtube_quality, as_document, caption, no_watermark)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id) DO UPDATE SET
+			audio_mode = EXCLUDED.audio_mode,
+			youtube_quality = EXCLUDED.youtube_quality,
+			as_document = EXCLUDED.as_document,
+			caption = EXCLUDED.caption,
+			no_watermark = EXCLUDED.no_watermark,
+			updated_at = NOW()`
+	_, err := db.Exec(query, userID, s.AudioMode, s.YouTubeQuality, s.AsDocument, s.Caption, s.NoWatermark)
+	return err
+}
@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"database/sql"
+	"time"
+	"yuklovchiBot/models"
+)
+
+// CountReferrals foydalanuvchi taklif havolasi orqali kelganlar soni
+func CountReferrals(db *sql.DB, userID int64) (int, error) {
+	var n int
+	err := db.QueryRow(`SELECT COUNT(*) FROM users WHERE referred_by = $1`, userID).Scan(&n)
+	return n, err
+}
+
+// GetSourceStats foydalanuvchilarni taklif, kampaniya va to'g'ridan-to'g'ri kelganlarga ajratadi
+func GetSourceStats(db *sql.DB) (models.SourceStats, error) {
+	var s models.SourceStats
+	query := `SELECT
+		COUNT(*) FILTER (WHERE referred_by IS NOT NULL),
+		COUNT(*) FILTER (WHERE campaign IS NOT NULL),
+		COUNT(*) FILTER (WHERE referred_by IS NULL AND campaign IS NULL)
+		FROM users`
+	err := db.QueryRow(query).Scan(&s.Referred, &s.Campaign, &s.Organic)
+	return s, err
+}
+
+// GetTopReferrers eng ko'p foydalanuvchi taklif qilganlar
+func GetTopReferrers(db *sql.DB, limit int) ([]models.ReferrerStats, error) {
+	query := `SELECT referred_by, COUNT(*), COUNT(*) FILTER (WHERE status = 'active')
+		FROM users WHERE referred_by IS NOT NULL
+		GROUP BY referred_by ORDER BY 2 DESC, 1 LIMIT $1`
+	rows, err := db.Query(query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []models.ReferrerStats
+	for rows.Next() {
+		var s models.ReferrerStats
+		if err := rows.Scan(&s.UserID, &s.Invited, &s.Active); err != nil {
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
+
+// GetCampaignStats kampaniya teglari bo'yicha statistika. since dan keyin kelganlar "New" ga kiradi.
+func GetCampaignStats(db *sql.DB, since time.Time, limit int) ([]models.CampaignStats, error) {
+	query := `SELECT u.campaign,
+		COUNT(*),
+		COUNT(*) FILTER (WHERE u.status = 'active'),
+		COUNT(*) FILTER (WHERE EXISTS (SELECT 1 FROM downloads d WHERE d.user_id = u.id AND d.status = 'success')),
+		COUNT(*) FILTER (WHERE u.created_at >= $1)
+		FROM users u WHERE u.campaign IS NOT NULL
+		GROUP BY u.campaign ORDER BY 2 DESC, 1 LIMIT $2`
+	rows, err := db.Query(query, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []models.CampaignStats
+	for rows.Next() {
+		var s models.CampaignStats
+		if err := rows.Scan(&s.Campaign, &s.Users, &s.Active, &s.Downloaded, &s.New); err != nil {
+			return nil, err
+		}
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
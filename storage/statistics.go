@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"database/sql"
+	"time"
+	"yuklovchiBot/models"
+)
+
+// GetUserStats foydalanuvchilar statistikasi. since dan keyin qo'shilganlar "New" ga kiradi.
+func GetUserStats(db *sql.DB, since time.Time) (models.UserStats, error) {
+	var s models.UserStats
+	query := `SELECT
+		COUNT(*),
+		COUNT(*) FILTER (WHERE created_at >= $1),
+		COUNT(*) FILTER (WHERE last_active_at >= NOW() - INTERVAL '1 day'),
+		COUNT(*) FILTER (WHERE last_active_at >= NOW() - INTERVAL '7 days'),
+		COUNT(*) FILTER (WHERE last_active_at >= NOW() - INTERVAL '30 days'),
+		COUNT(*) FILTER (WHERE status = 'blocked'),
+		COUNT(*) FILTER (WHERE status = 'deactivated')
+		FROM users`
+	err := db.QueryRow(query, since).Scan(&s.Total, &s.New, &s.DAU, &s.WAU, &s.MAU, &s.Blocked, &s.Deactivated)
+	return s, err
+}
+
+// GetPlatformStats since dan keyingi yuklashlar statistikasi platformalar bo'yicha
+func GetPlatformStats(db *sql.DB, since time.Time) ([]models.PlatformStats, error) {
+	query := `SELECT platform,
+		COUNT(*),
+		COUNT(*) FILTER (WHERE status = 'success'),
+		COUNT(*) FILTER (WHERE status = 'failed'),
+		COUNT(*) FILTER (WHERE status = 'success' AND cached),
+		COALESCE(AVG(duration_ms) FILTER (WHERE status = 'success' AND NOT cached), 0)::BIGINT,
+		COALESCE(SUM(bytes) FILTER (WHERE status = 'success'), 0)::BIGINT
+		FROM downloads WHERE created_at >= $1
+		GROUP BY platform ORDER BY platform`
+	rows, err := db.Query(query, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []models.PlatformStats
+	for rows.Next() {
+		var s models.PlatformStats
+		var avgMs int64
+		if err := rows.Scan(&s.Platform, &s.Total, &s.Success, &s.Failed, &s.Cached, &avgMs, &s.Bytes); err != nil {
+			return nil, err
+		}
+		s.AvgDuration = time.Duration(avgMs) * time.Millisecond
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
+
+// GetDailyDownloads kunlik yuklashlar soni platformalar bo'yicha
+func GetDailyDownloads(db *sql.DB, since time.Time) ([]models.DailyDownloads, error) {
+	query := `SELECT created_at::date, platform, COUNT(*), COUNT(*) FILTER (WHERE status = 'failed')
+		FROM downloads WHERE created_at >= $1
+		GROUP BY 1, 2 ORDER BY 1, 2`
+	rows, err := db.Query(query, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var days []models.DailyDownloads
+	for rows.Next() {
+		var d models.DailyDownloads
+		if err := rows.Scan(&d.Day, &d.Platform, &d.Count, &d.Failed); err != nil {
+			return nil, err
+		}
+		days = append(days, d)
+	}
+
+	return days, rows.Err()
+}
+
+// GetTopDownloadErrors eng ko'p uchragan xatolik sabablari
+func GetTopDownloadErrors(db *sql.DB, since time.Time, limit int) ([]models.ErrorCount, error) {
+	query := `SELECT error, COUNT(*) FROM downloads
+		WHERE status = 'failed' AND error IS NOT NULL AND created_at >= $1
+		GROUP BY error ORDER BY 2 DESC LIMIT $2`
+	rows, err := db.Query(query, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var errs []models.ErrorCount
+	for rows.Next() {
+		var e models.ErrorCount
+		if err := rows.Scan(&e.Reason, &e.Count); err != nil {
+			return nil, err
+		}
+		errs = append(errs, e)
+	}
+
+	return errs, rows.Err()
+}
+
+// GetUserGrowth since gacha bo'lgan foydalanuvchilar soni va undan keyingi kunlik yangi foydalanuvchilar
+func GetUserGrowth(db *sql.DB, since time.Time) (int, []models.DailyCount, error) {
+	var base int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM users WHERE created_at < $1`, since).Scan(&base); err != nil {
+		return 0, nil, err
+	}
+
+	query := `SELECT created_at::date, COUNT(*) FROM users
+		WHERE created_at >= $1
+		GROUP BY 1 ORDER BY 1`
+	rows, err := db.Query(query, since)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer rows.Close()
+
+	var days []models.DailyCount
+	for rows.Next() {
+		var d models.DailyCount
+		if err := rows.Scan(&d.Day, &d.Count); err != nil {
+			return 0, nil, err
+		}
+		days = append(days, d)
+	}
+
+	return base, days, rows.Err()
+}
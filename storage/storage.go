@@ -7,48 +7,120 @@ import (
 	"yuklovchiBot/models"
 )
 
-func AddUserToDatabase(db *sql.DB, userID int) error {
-	query := `INSERT INTO users (id) VALUES ($1) ON CONFLICT (id) DO NOTHING`
-	_, err := db.Exec(query, userID)
-	return err
+// AddUserToDatabase yangi foydalanuvchini qayerdan kelgani bilan saqlaydi va u yangi bo'lsa true qaytaradi.
+// referredBy bazada bo'lmasa yoki 0 bo'lsa, campaign bo'sh bo'lsa NULL yoziladi; mavjud foydalanuvchi o'zgarmaydi.
+func AddUserToDatabase(db *sql.DB, userID int, referredBy int64, campaign string) (bool, error) {
+	query := `INSERT INTO users (id, referred_by, campaign)
+		VALUES ($1, (SELECT id FROM users WHERE id = $2::BIGINT), NULLIF($3, ''))
+		ON CONFLICT (id) DO NOTHING`
+	res, err := db.Exec(query, userID, referredBy, campaign)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
 }
 
-func AddChannelToDatabase(db *sql.DB, channelLink string) error {
-	query := `INSERT INTO channels (username) VALUES ($1)`
-	_, err := db.Exec(query, channelLink)
-	return err
+// AddChannelToDatabase kanal shu ID yoki username bilan allaqachon bo'lsa false qaytaradi
+func AddChannelToDatabase(db *sql.DB, c models.Channel) (bool, error) {
+	query := `INSERT INTO channels (id, username, title, invite_link, join_request)
+		VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), NULLIF($4, ''), $5)
+		ON CONFLICT DO NOTHING`
+	res, err := db.Exec(query, c.ID, c.Username, c.Title, c.InviteLink, c.JoinRequest)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
 }
 
-func GetChannelsFromDatabase(db *sql.DB) ([]string, error) {
-	query := `SELECT username FROM channels`
+func GetChannelsFromDatabase(db *sql.DB) ([]models.Channel, error) {
+	query := `SELECT ` + channelColumns + ` FROM channels ORDER BY created_at`
 	rows, err := db.Query(query)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var channels []string
+	var channels []models.Channel
 	for rows.Next() {
-		var channel string
-		if err := rows.Scan(&channel); err != nil {
+		var c models.Channel
+		if err := scanChannel(rows, &c); err != nil {
 			return nil, err
 		}
-		channels = append(channels, channel)
+		channels = append(channels, c)
 	}
 
-	return channels, nil
+	return channels, rows.Err()
 }
 
-func AddAdminToDatabase(db *sql.DB, adminID int64) error {
-	query := `INSERT INTO admins (id) VALUES ($1) ON CONFLICT (id) DO NOTHING`
-	_, err := db.Exec(query, adminID)
+// AddAdminToDatabase adminni qo'shadi, u allaqachon admin bo'lsa rolini yangilaydi.
+// Owner roli faqat EnsureOwner orqali beriladi va bu yerda o'zgartirilmaydi.
+func AddAdminToDatabase(db *sql.DB, adminID int64, role string) error {
+	query := `INSERT INTO admins (id, role) VALUES ($1, $2)
+		ON CONFLICT (id) DO UPDATE SET role = EXCLUDED.role WHERE admins.role <> 'owner'`
+	_, err := db.Exec(query, adminID, role)
 	return err
 }
 
-func RemoveAdminFromDatabase(db *sql.DB, adminID int64) error {
-	query := `DELETE FROM admins WHERE id = $1`
-	_, err := db.Exec(query, adminID)
-	return err
+// RemoveAdminFromDatabase owner'ni hech qachon o'chirmaydi. O'chirilgan bo'lsa true qaytadi.
+func RemoveAdminFromDatabase(db *sql.DB, adminID int64) (bool, error) {
+	query := `DELETE FROM admins WHERE id = $1 AND role <> 'owner'`
+	res, err := db.Exec(query, adminID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// EnsureOwner config'dagi owner'ni admins jadvaliga yozadi, boshqa owner'lar oddiy adminga tushiriladi
+func EnsureOwner(db *sql.DB, ownerID int64) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`UPDATE admins SET role = 'admin' WHERE role = 'owner' AND id <> $1`, ownerID); err != nil {
+		return err
+	}
+	query := `INSERT INTO admins (id, role) VALUES ($1, 'owner') ON CONFLICT (id) DO UPDATE SET role = 'owner'`
+	if _, err := tx.Exec(query, ownerID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetAdminRole foydalanuvchining rolini qaytaradi, admin bo'lmasa ""
+func GetAdminRole(db *sql.DB, userID int64) (string, error) {
+	var role string
+	err := db.QueryRow(`SELECT role FROM admins WHERE id = $1`, userID).Scan(&role)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return role, err
+}
+
+// GetAdminList barcha adminlar, avval owner, keyin qo'shilgan vaqti bo'yicha
+func GetAdminList(db *sql.DB) ([]models.Admin, error) {
+	rows, err := db.Query(`SELECT id, role, COALESCE(created_at, NOW()) FROM admins
+		ORDER BY role = 'owner' DESC, created_at, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var admins []models.Admin
+	for rows.Next() {
+		var a models.Admin
+		if err := rows.Scan(&a.ID, &a.Role, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		admins = append(admins, a)
+	}
+
+	return admins, rows.Err()
 }
 
 func IsAdmin(userID int, db *sql.DB) bool {
@@ -58,9 +130,21 @@ func IsAdmin(userID int, db *sql.DB) bool {
 	return err == nil
 }
 
-func DeleteChannelFromDatabase(db *sql.DB, channel string) error {
-	query := `DELETE FROM channels WHERE username = $1`
-	_, err := db.Exec(query, channel)
+// SetChannelID chat ID si saqlanmagan eski kanal uchun ID va nomini yozadi
+func SetChannelID(db *sql.DB, username string, id int64, title string) error {
+	query := `UPDATE channels SET id = $2, title = COALESCE(title, NULLIF($3, ''))
+		WHERE LOWER(username) = LOWER($1) AND id IS NULL`
+	_, err := db.Exec(query, username, id, title)
+	return err
+}
+
+// DeleteChannelFromDatabase kanalni models.Channel.Key() bo'yicha uning qo'shilish so'rovlari bilan o'chiradi
+func DeleteChannelFromDatabase(db *sql.DB, key string) error {
+	query := `WITH deleted AS (
+			DELETE FROM channels WHERE LOWER(username) = LOWER($1) OR id::TEXT = $1 RETURNING id
+		)
+		DELETE FROM channel_join_requests WHERE channel_id IN (SELECT id FROM deleted)`
+	_, err := db.Exec(query, key)
 	return err
 }
 
@@ -84,7 +168,7 @@ func GetLastMonthUsers(db *sql.DB) (int, error) {
 
 func GetAllUsers(db *sql.DB) ([]models.User, error) {
 	log.Println("GetAllUsers funksiyasi ishga tushdi") // Log qo'shish
-	query := `SELECT user_id FROM users`
+	query := `SELECT id FROM users`
 	rows, err := db.Query(query)
 	if err != nil {
 		return nil, err
@@ -103,9 +187,15 @@ func GetAllUsers(db *sql.DB) ([]models.User, error) {
 	return users, nil
 }
 
-func GetAdmins(db *sql.DB) ([]int64, error) {
+// GetAdmins roles berilsa faqat shu rollardagi adminlarni qaytaradi
+func GetAdmins(db *sql.DB, roles ...string) ([]int64, error) {
 	query := `SELECT id FROM admins`
-	rows, err := db.Query(query)
+	var args []interface{}
+	if len(roles) > 0 {
+		query += ` WHERE role = ANY($1)`
+		args = append(args, roles)
+	}
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -123,3 +213,52 @@ func GetAdmins(db *sql.DB) ([]int64, error) {
 
 	return adminIDs, nil
 }
+
+// SetUserStatus foydalanuvchi holatini (active/blocked/deactivated) yangilaydi
+func SetUserStatus(db *sql.DB, userID int64, status string) error {
+	query := `UPDATE users SET status = $2, status_updated_at = NOW() WHERE id = $1 AND status <> $2`
+	_, err := db.Exec(query, userID, status)
+	return err
+}
+
+// TouchUser foydalanuvchining oxirgi faolligi va tilini yangilaydi. Foydalanuvchi
+// yana yozgan bo'lsa, demak botni blokdan chiqargan, shuning uchun holati "active" bo'ladi.
+func TouchUser(db *sql.DB, userID int64, languageCode string) error {
+	query := `UPDATE users SET
+		last_active_at = NOW(),
+		language_code = COALESCE(NULLIF($2, ''), language_code),
+		status_updated_at = CASE WHEN status <> 'active' THEN NOW() ELSE status_updated_at END,
+		status = 'active'
+		WHERE id = $1`
+	_, err := db.Exec(query, userID, languageCode)
+	return err
+}
+
+// GetUserLanguage foydalanuvchi tanlagan interfeys tilini va Telegram language_code'ini qaytaradi.
+// Til hali tanlanmagan bo'lsa language bo'sh bo'ladi.
+func GetUserLanguage(db *sql.DB, userID int64) (language string, languageCode string, err error) {
+	query := `SELECT COALESCE(language, ''), COALESCE(language_code, '') FROM users WHERE id = $1`
+	err = db.QueryRow(query, userID).Scan(&language, &languageCode)
+	if err == sql.ErrNoRows {
+		return "", "", nil
+	}
+	return language, languageCode, err
+}
+
+// SetUserLanguage foydalanuvchi tanlagan interfeys tilini saqlaydi
+func SetUserLanguage(db *sql.DB, userID int64, language string) error {
+	query := `INSERT INTO users (id, language) VALUES ($1, $2)
+		ON CONFLICT (id) DO UPDATE SET language = EXCLUDED.language`
+	_, err := db.Exec(query, userID, language)
+	return err
+}
+
+// GetInactiveUsers botni bloklagan va akkaunti o'chirilgan foydalanuvchilar soni
+func GetInactiveUsers(db *sql.DB) (blocked int, deactivated int, err error) {
+	query := `SELECT
+		COUNT(*) FILTER (WHERE status = 'blocked'),
+		COUNT(*) FILTER (WHERE status = 'deactivated')
+		FROM users`
+	err = db.QueryRow(query).Scan(&blocked, &deactivated)
+	return blocked, deactivated, err
+}
@@ -0,0 +1,214 @@
+package subscription
+
+import (
+	"database/sql"
+	"log"
+	"sync"
+	"time"
+	"yuklovchiBot/models"
+	"yuklovchiBot/storage"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// TTL a'zolik natijasi va kanallar ro'yxati shuncha vaqt keshda turadi (main'da config'dan o'rnatiladi).
+// Faqat ijobiy natijalar keshlanadi: obuna bo'lmagan foydalanuvchi "Azo bo'ldim" ni bosganda qayta tekshiriladi.
+var TTL = 10 * time.Minute
+
+type memberKey struct {
+	userID    int64
+	channelID int64
+}
+
+var (
+	mu         sync.Mutex
+	members    = make(map[memberKey]time.Time)
+	channels   []models.Channel
+	channelsAt time.Time
+)
+
+// Invalidate kanallar ro'yxati o'zgarganda (qo'shish/o'chirish) chaqiriladi
+func Invalidate() {
+	mu.Lock()
+	defer mu.Unlock()
+	channels = nil
+	channelsAt = time.Time{}
+}
+
+// Check foydalanuvchi barcha majburiy kanallarga obuna bo'lganini tekshiradi va
+// obuna bo'lmagan kanallarni qaytaradi. Qo'shilish so'rovi bilan ishlaydigan kanallarda
+// yuborilgan so'rov ham obuna hisoblanadi.
+func Check(userID int64, db *sql.DB, botInstance *tgbotapi.BotAPI) (bool, []models.Channel) {
+	list, err := getChannels(db, botInstance)
+	if err != nil {
+		log.Printf("Error getting channels from database: %v", err)
+		return false, nil
+	}
+
+	now := time.Now()
+	var requests map[int64]bool
+	var missing []models.Channel
+	for _, channel := range list {
+		if !channel.Active(now) {
+			continue
+		}
+		if channel.ID != 0 && cached(userID, channel.ID) {
+			continue
+		}
+
+		config := tgbotapi.ChatConfigWithUser{ChatID: channel.ID, UserID: int(userID)}
+		if channel.ID == 0 {
+			config.SuperGroupUsername = "@" + channel.Username
+		}
+
+		member, err := botInstance.GetChatMember(config)
+		if err != nil {
+			log.Printf("Error getting chat member info for channel %s: %v", channel.Key(), err)
+			missing = append(missing, channel)
+			continue
+		}
+
+		switch member.Status {
+		case "left":
+			if channel.JoinRequest {
+				if requests == nil {
+					if requests, err = storage.GetJoinRequests(db, userID); err != nil {
+						log.Printf("Error getting join requests: %v", err)
+					}
+				}
+				if requests[channel.ID] {
+					Remember(userID, channel.ID)
+					markJoined(db, channel, userID)
+					continue
+				}
+			}
+			markLeft(db, channel, userID)
+			missing = append(missing, channel)
+		case "kicked":
+			markLeft(db, channel, userID)
+			missing = append(missing, channel)
+		default:
+			Remember(userID, channel.ID)
+			markJoined(db, channel, userID)
+			// So'rov tasdiqlangan, keyin kanaldan chiqsa yana obuna talab qilinishi uchun o'chiramiz
+			if channel.JoinRequest {
+				if err := storage.DeleteJoinRequest(db, channel.ID, userID); err != nil {
+					log.Printf("Error deleting join request: %v", err)
+				}
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		log.Printf("User %d is not subscribed to %d channel(s)", userID, len(missing))
+	}
+	return len(missing) == 0, missing
+}
+
+// GateShown obuna talabi ko'rsatilgan kanallarni hisobot uchun yozadi
+func GateShown(userID int64, missing []models.Channel, db *sql.DB) {
+	var ids []int64
+	for _, channel := range missing {
+		if channel.ID != 0 {
+			ids = append(ids, channel.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	if err := storage.RecordGateShown(db, userID, ids); err != nil {
+		log.Printf("Error recording subscription gate: %v", err)
+	}
+}
+
+// markJoined obuna talabi ko'rsatilgan foydalanuvchi obuna bo'lganini hisobot uchun yozadi
+func markJoined(db *sql.DB, channel models.Channel, userID int64) {
+	if channel.ID == 0 {
+		return
+	}
+	if err := storage.MarkChannelJoined(db, channel.ID, userID); err != nil {
+		log.Printf("Error recording channel join: %v", err)
+	}
+}
+
+func markLeft(db *sql.DB, channel models.Channel, userID int64) {
+	if channel.ID == 0 {
+		return
+	}
+	if err := storage.MarkChannelLeft(db, channel.ID, userID); err != nil {
+		log.Printf("Error recording channel leave: %v", err)
+	}
+}
+
+func cached(userID, channelID int64) bool {
+	mu.Lock()
+	defer mu.Unlock()
+
+	key := memberKey{userID, channelID}
+	expires, ok := members[key]
+	if ok && time.Now().After(expires) {
+		delete(members, key)
+		return false
+	}
+	return ok
+}
+
+// Remember foydalanuvchini TTL davomida kanalga obuna bo'lgan deb belgilaydi
+func Remember(userID, channelID int64) {
+	// Chat ID si aniqlanmagan kanallar keshlanmaydi, aks holda ular bir-biri bilan aralashadi
+	if channelID == 0 {
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	now := time.Now()
+	// Kesh cheksiz o'smasligi uchun vaqti o'tganlar vaqti-vaqti bilan tozalanadi
+	if len(members) > 100000 {
+		for k, expires := range members {
+			if now.After(expires) {
+				delete(members, k)
+			}
+		}
+	}
+	members[memberKey{userID, channelID}] = now.Add(TTL)
+}
+
+// getChannels kanallar ro'yxatini keshdan oladi. Chat ID si saqlanmagan eski kanallar
+// bir marta getChat orqali aniqlanib, bazaga yoziladi.
+func getChannels(db *sql.DB, botInstance *tgbotapi.BotAPI) ([]models.Channel, error) {
+	mu.Lock()
+	if !channelsAt.IsZero() && time.Since(channelsAt) < TTL {
+		list := channels
+		mu.Unlock()
+		return list, nil
+	}
+	mu.Unlock()
+
+	list, err := storage.GetChannelsFromDatabase(db)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, channel := range list {
+		if channel.ID != 0 {
+			continue
+		}
+		chat, err := botInstance.GetChat(tgbotapi.ChatConfig{SuperGroupUsername: "@" + channel.Username})
+		if err != nil {
+			log.Printf("Error getting chat info for channel %s: %v", channel.Username, err)
+			continue
+		}
+		list[i].ID, list[i].Title = chat.ID, chat.Title
+		if err := storage.SetChannelID(db, channel.Username, chat.ID, chat.Title); err != nil {
+			log.Printf("Error saving chat ID for channel %s: %v", channel.Username, err)
+		}
+	}
+
+	mu.Lock()
+	channels, channelsAt = list, time.Now()
+	mu.Unlock()
+	return list, nil
+}